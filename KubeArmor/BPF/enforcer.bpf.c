@@ -2,6 +2,7 @@
 
 #include "vmlinux.h"
 #include <bpf/bpf_core_read.h>
+#include <bpf/bpf_endian.h>
 #include <bpf/bpf_helpers.h>
 #include <bpf/bpf_tracing.h>
 
@@ -13,6 +14,32 @@ char LICENSE[] SEC("license") = "Dual BSD/GPL";
 #define MAX_BUFFERS 1
 #define PATH_BUFFER 0
 
+#define AF_INET 2
+#define AF_INET6 10
+
+#define ENDPOINT 4 // Endpoint Rule Key
+#define PREFIXES 5 // Prefix Lengths Hint Key
+#define LISTENER 6 // Listener Rule Key
+#define LISTENER_PREFIXES 7 // Listener Prefix Lengths Hint Key
+#define SYSCALL 8 // Syscall Rule Key
+
+#define MAX_ENDPOINT_PREFIXES 8
+#define MAX_ENDPOINT_PORTS 4
+
+// x86_64 system call numbers of the syscall rules
+#define SYS_PTRACE 101
+#define SYS_MOUNT 165
+#define SYS_INIT_MODULE 175
+#define SYS_KEXEC_LOAD 246
+#define SYS_FINIT_MODULE 313
+#define SYS_KEXEC_FILE_LOAD 320
+#define SYS_BPF 321
+
+#define PTRACE_MODE_ATTACH 0x02
+
+// the process ID of KubeArmor, which is never restricted by the syscall rules
+const volatile u32 kubearmor_pid = 0;
+
 typedef struct buffers {
   char buf[MAX_BUFFER_SIZE];
 } bufs_t;
@@ -525,6 +552,145 @@ decision:
   return 0;
 }
 
+// read_sockaddr copies the address and the port of an AF_INET or AF_INET6
+// socket address, returning the length of the address (0 for the others)
+static __always_inline int read_sockaddr(struct sockaddr *address, u16 family,
+                                         u8 *addr, u16 *port) {
+  if (family == AF_INET) {
+    struct sockaddr_in *in = (struct sockaddr_in *)address;
+    bpf_probe_read(addr, 4, &in->sin_addr.s_addr);
+    *port = bpf_ntohs(BPF_CORE_READ(in, sin_port));
+    return 4;
+  } else if (family == AF_INET6) {
+    struct sockaddr_in6 *in6 = (struct sockaddr_in6 *)address;
+    bpf_probe_read(addr, 16, &in6->sin6_addr);
+    *port = bpf_ntohs(BPF_CORE_READ(in6, sin6_port));
+    return 16;
+  }
+
+  return 0;
+}
+
+// unmap_ipv4 turns an IPv4-mapped IPv6 address (::ffff:a.b.c.d) into the IPv4
+// address, so that dual-stack sockets are matched with IPv4 rules
+static __always_inline int unmap_ipv4(u16 *family, u8 *addr, int addr_len) {
+  if (*family != AF_INET6 || addr_len != 16)
+    return addr_len;
+
+#pragma unroll
+  for (int i = 0; i < 10; i++) {
+    if (addr[i] != 0)
+      return addr_len;
+  }
+
+  if (addr[10] != 0xff || addr[11] != 0xff)
+    return addr_len;
+
+#pragma unroll
+  for (int i = 0; i < 4; i++)
+    addr[i] = addr[12 + i];
+
+#pragma unroll
+  for (int i = 4; i < 16; i++)
+    addr[i] = 0;
+
+  *family = AF_INET;
+
+  return 4;
+}
+
+// match_address looks up an address and a port in the rules of the given
+// type for each prefix length in use, with and without the source
+static __always_inline bool match_address(u32 *inner, bufs_k *p, bufs_k *z,
+                                          u16 family, u8 *addr, int addr_len,
+                                          u16 port, void *source, u8 rule,
+                                          u8 hint_rule) {
+  u32 zero = 0;
+
+  bpf_map_update_elem(&bufk, &zero, z, BPF_ANY);
+
+  p->path[0] = hint_rule;
+  p->path[1] = family;
+
+  u8 *hint = bpf_map_lookup_elem(inner, p);
+  if (hint == NULL)
+    return false;
+
+  u8 prefixes[MAX_ENDPOINT_PREFIXES];
+  bpf_probe_read(prefixes, MAX_ENDPOINT_PREFIXES, hint);
+
+  for (int i = 0; i < MAX_ENDPOINT_PREFIXES; i++) {
+    if (prefixes[i] == 0)
+      break;
+
+    u8 prefix = prefixes[i] - 1;
+
+    bpf_map_update_elem(&bufk, &zero, z, BPF_ANY);
+
+    p->path[0] = rule;
+    p->path[1] = family;
+    p->path[2] = prefix;
+
+#pragma unroll
+    for (int j = 0; j < 16; j++) {
+      if (j >= addr_len)
+        break;
+
+      if (prefix >= (j + 1) * 8) {
+        p->path[4 + j] = addr[j];
+      } else if (prefix > j * 8) {
+        p->path[4 + j] = addr[j] & (u8)(0xff << (8 - (prefix - j * 8)));
+      }
+    }
+
+    for (int k = 0; k < 2; k++) {
+      if (k == 1) {
+        // Check with From Source
+        bpf_probe_read_str(p->source, MAX_STRING_SIZE, source);
+      }
+
+      for (int s = 0; s < MAX_ENDPOINT_PORTS; s++) {
+        p->path[3] = s;
+
+        u8 *ports = bpf_map_lookup_elem(inner, p);
+        if (ports == NULL)
+          break;
+
+        u16 min = ((u16)ports[0] << 8) | ports[1];
+        u16 max = ((u16)ports[2] << 8) | ports[3];
+
+        if (port >= min && port <= max) {
+          bpf_printk("address match of rule %d with prefix %d and port %d \n",
+                     rule, prefix, port);
+          return true;
+        }
+      }
+    }
+  }
+
+  return false;
+}
+
+// match_endpoint looks up the destination address of a connection in the
+// endpoint rules
+static __always_inline bool match_endpoint(u32 *inner, bufs_k *p, bufs_k *z,
+                                           struct sockaddr *address,
+                                           void *source) {
+  u8 addr[16] = {};
+  u16 port = 0;
+
+  u16 family = BPF_CORE_READ(address, sa_family);
+
+  int addr_len = read_sockaddr(address, family, addr, &port);
+  if (addr_len == 0)
+    return false;
+
+  addr_len = unmap_ipv4(&family, addr, addr_len);
+
+  return match_address(inner, p, z, family, addr, addr_len, port, source,
+                       ENDPOINT, PREFIXES);
+}
+
 SEC("lsm/socket_connect")
 int BPF_PROG(enforce_net, struct socket *sock, struct sockaddr *address,
              int addrlen) {
@@ -612,6 +778,13 @@ int BPF_PROG(enforce_net, struct socket *sock, struct sockaddr *address,
     match = true;
     goto decision;
   }
+
+  // Endpoint Check
+  if (match_endpoint(inner, p, z, address, ptr)) {
+    match = true;
+    goto decision;
+  }
+
 decision:
 
   bpf_map_update_elem(&bufk, &zero, z, BPF_ANY);
@@ -635,3 +808,245 @@ decision:
   }
   return 0;
 }
+
+// enforce_listener decides if the current task may bind or listen on a local
+// address and port, by the listener rules of its container
+static __always_inline int enforce_listener(u16 family, u8 *addr, int addr_len,
+                                            u16 port) {
+  struct task_struct *t = (struct task_struct *)bpf_get_current_task();
+
+  bool match = false;
+
+  struct outer_key okey = {.pid_ns = get_task_pid_ns_id(t),
+                           .mnt_ns = get_task_mnt_ns_id(t)};
+
+  if (okey.pid_ns == PROC_PID_INIT_INO) {
+    okey.pid_ns = 0;
+    okey.mnt_ns = 0;
+  }
+
+  u32 *inner = bpf_map_lookup_elem(&kubearmor_containers, &okey);
+
+  if (!inner) {
+    return 0;
+  }
+
+  u32 zero = 0;
+  u32 one = 1;
+  bufs_k *p = bpf_map_lookup_elem(&bufk, &zero);
+  if (p == NULL)
+    return 0;
+
+  bufs_k *z = bpf_map_lookup_elem(&bufk, &one);
+  if (z == NULL)
+    return 0;
+
+  struct file *file_p = get_task_file(t);
+  if (file_p == NULL)
+    return 0;
+  bufs_t *src_buf = get_buf(PATH_BUFFER);
+  if (src_buf == NULL)
+    return 0;
+  struct path f_src = BPF_CORE_READ(file_p, f_path);
+  if (!prepend_path(&f_src, src_buf))
+    return 0;
+
+  u32 *src_offset = get_buf_off(PATH_BUFFER);
+  if (src_offset == NULL)
+    return 0;
+
+  void *ptr = &src_buf->buf[*src_offset];
+
+  // Listener Check
+  if (match_address(inner, p, z, family, addr, addr_len, port, ptr, LISTENER,
+                    LISTENER_PREFIXES)) {
+    match = true;
+  }
+
+  bpf_map_update_elem(&bufk, &zero, z, BPF_ANY);
+  p->path[0] = 104;
+  struct data_t *allow = bpf_map_lookup_elem(inner, p);
+
+  if (allow) {
+    if (!match) {
+      bpf_printk("denying listener family %d, port %d due to not in "
+                 "allowlist \n",
+                 family, port);
+      return -EPERM;
+    }
+  } else {
+    if (match) {
+      bpf_printk("denying listener family %d, port %d due to in blacklist \n",
+                 family, port);
+      return -EPERM;
+    }
+  }
+  return 0;
+}
+
+SEC("lsm/socket_bind")
+int BPF_PROG(enforce_bind, struct socket *sock, struct sockaddr *address,
+             int addrlen) {
+  u8 addr[16] = {};
+  u16 port = 0;
+
+  u16 family = BPF_CORE_READ(address, sa_family);
+
+  int addr_len = read_sockaddr(address, family, addr, &port);
+  if (addr_len == 0)
+    return 0;
+
+  addr_len = unmap_ipv4(&family, addr, addr_len);
+
+  // ephemeral ports are checked once the socket starts listening
+  if (port == 0)
+    return 0;
+
+  return enforce_listener(family, addr, addr_len, port);
+}
+
+SEC("lsm/socket_listen")
+int BPF_PROG(enforce_listen, struct socket *sock, int backlog) {
+  u8 addr[16] = {};
+
+  struct sock *sk = BPF_CORE_READ(sock, sk);
+  u16 family = BPF_CORE_READ(sk, __sk_common.skc_family);
+
+  // the local port in host byte order, 0 if the socket is not bound yet
+  u16 port = BPF_CORE_READ(sk, __sk_common.skc_num);
+
+  int addr_len = 0;
+
+  if (family == AF_INET) {
+    bpf_probe_read(addr, 4, &sk->__sk_common.skc_rcv_saddr);
+    addr_len = 4;
+  } else if (family == AF_INET6) {
+    bpf_probe_read(addr, 16, &sk->__sk_common.skc_v6_rcv_saddr);
+    addr_len = 16;
+  } else {
+    return 0;
+  }
+
+  addr_len = unmap_ipv4(&family, addr, addr_len);
+
+  return enforce_listener(family, addr, addr_len, port);
+}
+
+// enforce_syscall decides if the current task may make a system call, by the
+// syscall rules of its container
+static __always_inline int enforce_syscall(u16 nr) {
+  // KubeArmor itself loads BPF programs and updates the rules
+  if ((bpf_get_current_pid_tgid() >> 32) == kubearmor_pid)
+    return 0;
+
+  struct task_struct *t = (struct task_struct *)bpf_get_current_task();
+
+  struct outer_key okey = {.pid_ns = get_task_pid_ns_id(t),
+                           .mnt_ns = get_task_mnt_ns_id(t)};
+
+  if (okey.pid_ns == PROC_PID_INIT_INO) {
+    okey.pid_ns = 0;
+    okey.mnt_ns = 0;
+  }
+
+  u32 *inner = bpf_map_lookup_elem(&kubearmor_containers, &okey);
+
+  if (!inner) {
+    return 0;
+  }
+
+  u32 zero = 0;
+  u32 one = 1;
+  bufs_k *p = bpf_map_lookup_elem(&bufk, &zero);
+  if (p == NULL)
+    return 0;
+
+  bufs_k *z = bpf_map_lookup_elem(&bufk, &one);
+  if (z == NULL)
+    return 0;
+
+  bpf_map_update_elem(&bufk, &zero, z, BPF_ANY);
+
+  // syscalls are only blocked, so the rules are looked up without the hints
+  p->path[0] = SYSCALL;
+  p->path[1] = nr & 0xff;
+  p->path[2] = nr >> 8;
+
+  if (bpf_map_lookup_elem(inner, p)) {
+    bpf_printk("denying syscall %d due to in blacklist \n", nr);
+    return -EPERM;
+  }
+
+  // Check with From Source
+  struct file *file_p = get_task_file(t);
+  if (file_p == NULL)
+    return 0;
+  bufs_t *src_buf = get_buf(PATH_BUFFER);
+  if (src_buf == NULL)
+    return 0;
+  struct path f_src = BPF_CORE_READ(file_p, f_path);
+  if (!prepend_path(&f_src, src_buf))
+    return 0;
+
+  u32 *src_offset = get_buf_off(PATH_BUFFER);
+  if (src_offset == NULL)
+    return 0;
+
+  void *ptr = &src_buf->buf[*src_offset];
+  bpf_probe_read_str(p->source, MAX_STRING_SIZE, ptr);
+
+  if (bpf_map_lookup_elem(inner, p)) {
+    bpf_printk("denying syscall %d from source due to in blacklist \n", nr);
+    return -EPERM;
+  }
+
+  return 0;
+}
+
+SEC("lsm/ptrace_access_check")
+int BPF_PROG(enforce_ptrace, struct task_struct *child, unsigned int mode) {
+  // reading /proc/<pid> files is not tracing
+  if (!(mode & PTRACE_MODE_ATTACH))
+    return 0;
+
+  return enforce_syscall(SYS_PTRACE);
+}
+
+SEC("lsm/ptrace_traceme")
+int BPF_PROG(enforce_traceme, struct task_struct *parent) {
+  return enforce_syscall(SYS_PTRACE);
+}
+
+SEC("lsm/sb_mount")
+int BPF_PROG(enforce_mount, const char *dev_name, const struct path *path,
+             const char *type, unsigned long flags, void *data) {
+  return enforce_syscall(SYS_MOUNT);
+}
+
+SEC("lsm/bpf")
+int BPF_PROG(enforce_bpf, int cmd, union bpf_attr *attr, unsigned int size) {
+  return enforce_syscall(SYS_BPF);
+}
+
+SEC("lsm/kernel_load_data")
+int BPF_PROG(enforce_load_data, enum kernel_load_data_id id) {
+  if (id == LOADING_MODULE)
+    return enforce_syscall(SYS_INIT_MODULE);
+
+  if (id == LOADING_KEXEC_IMAGE)
+    return enforce_syscall(SYS_KEXEC_LOAD);
+
+  return 0;
+}
+
+SEC("lsm/kernel_read_file")
+int BPF_PROG(enforce_read_file, struct file *file,
+             enum kernel_read_file_id id) {
+  if (id == READING_MODULE)
+    return enforce_syscall(SYS_FINIT_MODULE);
+
+  if (id == READING_KEXEC_IMAGE)
+    return enforce_syscall(SYS_KEXEC_FILE_LOAD);
+
+  return 0;
+}
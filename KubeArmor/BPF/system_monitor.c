@@ -107,6 +107,17 @@ enum {
     _SYS_EXECVEAT = 322,
     _DO_EXIT = 351,
 
+    // syscall
+    _SYS_PTRACE = 101,
+    _SYS_MOUNT = 165,
+    _SYS_INIT_MODULE = 175,
+    _SYS_KEXEC_LOAD = 246,
+    _SYS_UNSHARE = 272,
+    _SYS_SETNS = 308,
+    _SYS_FINIT_MODULE = 313,
+    _SYS_KEXEC_FILE_LOAD = 320,
+    _SYS_BPF = 321,
+
     // lsm
     _SECURITY_BPRM_CHECK = 352,
 
@@ -1345,6 +1356,141 @@ int kretprobe__listen(struct pt_regs *ctx)
     return trace_ret_generic(_SYS_LISTEN, ctx, ARG_TYPE0(INT_T)|ARG_TYPE1(INT_T));
 }
 
+SEC("kprobe/__x64_sys_ptrace")
+int kprobe__ptrace(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_PTRACE, ctx);
+}
+
+SEC("kretprobe/__x64_sys_ptrace")
+int kretprobe__ptrace(struct pt_regs *ctx)
+{
+    return trace_ret_generic(_SYS_PTRACE, ctx, ARG_TYPE0(INT_T)|ARG_TYPE1(INT_T));
+}
+
+SEC("kprobe/__x64_sys_mount")
+int kprobe__mount(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_MOUNT, ctx);
+}
+
+SEC("kretprobe/__x64_sys_mount")
+int kretprobe__mount(struct pt_regs *ctx)
+{
+    return trace_ret_generic(_SYS_MOUNT, ctx, ARG_TYPE1(STR_T)|ARG_TYPE3(INT_T));
+}
+
+SEC("kprobe/__x64_sys_unshare")
+int kprobe__unshare(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_UNSHARE, ctx);
+}
+
+SEC("kretprobe/__x64_sys_unshare")
+int kretprobe__unshare(struct pt_regs *ctx)
+{
+    return trace_ret_generic(_SYS_UNSHARE, ctx, ARG_TYPE0(INT_T));
+}
+
+SEC("kprobe/__x64_sys_setns")
+int kprobe__setns(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_SETNS, ctx);
+}
+
+SEC("kretprobe/__x64_sys_setns")
+int kretprobe__setns(struct pt_regs *ctx)
+{
+    return trace_ret_generic(_SYS_SETNS, ctx, ARG_TYPE0(INT_T)|ARG_TYPE1(INT_T));
+}
+
+SEC("kprobe/__x64_sys_init_module")
+int kprobe__init_module(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_INIT_MODULE, ctx);
+}
+
+SEC("kretprobe/__x64_sys_init_module")
+int kretprobe__init_module(struct pt_regs *ctx)
+{
+    return trace_ret_generic(_SYS_INIT_MODULE, ctx, ARG_TYPE1(INT_T));
+}
+
+SEC("kprobe/__x64_sys_finit_module")
+int kprobe__finit_module(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_FINIT_MODULE, ctx);
+}
+
+SEC("kretprobe/__x64_sys_finit_module")
+int kretprobe__finit_module(struct pt_regs *ctx)
+{
+    return trace_ret_generic(_SYS_FINIT_MODULE, ctx, ARG_TYPE0(INT_T)|ARG_TYPE2(INT_T));
+}
+
+SEC("kprobe/__x64_sys_kexec_load")
+int kprobe__kexec_load(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_KEXEC_LOAD, ctx);
+}
+
+SEC("kretprobe/__x64_sys_kexec_load")
+int kretprobe__kexec_load(struct pt_regs *ctx)
+{
+    return trace_ret_generic(_SYS_KEXEC_LOAD, ctx, ARG_TYPE3(INT_T));
+}
+
+SEC("kprobe/__x64_sys_kexec_file_load")
+int kprobe__kexec_file_load(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_KEXEC_FILE_LOAD, ctx);
+}
+
+SEC("kretprobe/__x64_sys_kexec_file_load")
+int kretprobe__kexec_file_load(struct pt_regs *ctx)
+{
+    return trace_ret_generic(_SYS_KEXEC_FILE_LOAD, ctx, ARG_TYPE0(INT_T)|ARG_TYPE4(INT_T));
+}
+
+SEC("kprobe/__x64_sys_bpf")
+int kprobe__bpf(struct pt_regs *ctx)
+{
+    if (skip_syscall())
+        return 0;
+
+    return save_args(_SYS_BPF, ctx);
+}
+
+SEC("kretprobe/__x64_sys_bpf")
+int kretprobe__bpf(struct pt_regs *ctx)
+{
+    return trace_ret_generic(_SYS_BPF, ctx, ARG_TYPE0(INT_T));
+}
+
 
 static __always_inline int get_connection_info(struct sock_common *conn,struct sockaddr_in *sockv4, struct sockaddr_in6 *sockv6,sys_context_t *context, args_t *args, u32 event ) {
     switch (conn->skc_family)
@@ -18,6 +18,7 @@ import (
 
 	kc "github.com/kubearmor/KubeArmor/KubeArmor/config"
 	kg "github.com/kubearmor/KubeArmor/KubeArmor/log"
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -87,22 +88,44 @@ func ObjCommaExpand(v reflect.Value) []string {
 	return strings.Split(v.Field(0).Interface().(string), ",")
 }
 
+// objCommaExpandDupOthers Function
+func objCommaExpandDupOthers(objptr interface{}) {
+	old := reflect.ValueOf(objptr).Elem()
+	new := reflect.New(reflect.TypeOf(objptr).Elem()).Elem()
+
+	for i := 0; i < old.Len(); i++ {
+		for _, f := range ObjCommaExpand(old.Index(i)) {
+			field := strings.ReplaceAll(f, " ", "")
+			new.Set(reflect.Append(new, old.Index(i)))
+			new.Index(new.Len() - 1).Field(0).SetString(field)
+		}
+	}
+
+	reflect.ValueOf(objptr).Elem().Set(new)
+}
+
 // ObjCommaExpandFirstDupOthers Function
 func ObjCommaExpandFirstDupOthers(objptr interface{}) {
 	if ObjCommaCanBeExpanded(objptr) {
-		old := reflect.ValueOf(objptr).Elem()
-		new := reflect.New(reflect.TypeOf(objptr).Elem()).Elem()
-
-		for i := 0; i < old.Len(); i++ {
-			for _, f := range ObjCommaExpand(old.Index(i)) {
-				field := strings.ReplaceAll(f, " ", "")
-				new.Set(reflect.Append(new, old.Index(i)))
-				new.Index(new.Len() - 1).Field(0).SetString(field)
-			}
-		}
+		objCommaExpandDupOthers(objptr)
+	}
+}
 
-		reflect.ValueOf(objptr).Elem().Set(new)
+// ObjCommaExpandAllDupOthers Function (expands comma-separated values in any element, not only when the first one has them)
+func ObjCommaExpandAllDupOthers(objptr interface{}) {
+	ov := reflect.ValueOf(objptr)
+	if ov.Kind() != reflect.Ptr || ov.Elem().Kind() != reflect.Slice {
+		return
 	}
+
+	for i := 0; i < ov.Elem().Len(); i++ {
+		elm := ov.Elem().Index(i)
+		if elm.Kind() != reflect.Struct || elm.NumField() == 0 || elm.Field(0).Kind() != reflect.String {
+			return
+		}
+	}
+
+	objCommaExpandDupOthers(objptr)
 }
 
 // CopyFile Function
@@ -313,6 +336,56 @@ func GetExternalIPAddr() string {
 	return GetIPAddr(iface)
 }
 
+// ParseCIDR Function (a CIDR or an IP address, e.g., 10.0.0.0/8 or 10.0.0.1)
+func ParseCIDR(cidr string) (*net.IPNet, error) {
+	if !strings.Contains(cidr, "/") {
+		ip := net.ParseIP(cidr)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address %s", cidr)
+		}
+
+		if ipv4 := ip.To4(); ipv4 != nil {
+			return &net.IPNet{IP: ipv4, Mask: net.CIDRMask(32, 32)}, nil
+		}
+
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+	}
+
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+
+	if ipv4 := ipNet.IP.To4(); ipv4 != nil {
+		ipNet.IP = ipv4
+	}
+
+	return ipNet, nil
+}
+
+// ParsePortRange Function (a port or a port range, e.g., 5432 or 8000-8080)
+func ParsePortRange(port string) (tp.PortRange, error) {
+	ports := strings.SplitN(port, "-", 2)
+
+	min, err := strconv.ParseUint(strings.TrimSpace(ports[0]), 10, 16)
+	if err != nil {
+		return tp.PortRange{}, fmt.Errorf("invalid port %s", port)
+	}
+
+	max := min
+	if len(ports) == 2 {
+		if max, err = strconv.ParseUint(strings.TrimSpace(ports[1]), 10, 16); err != nil {
+			return tp.PortRange{}, fmt.Errorf("invalid port %s", port)
+		}
+	}
+
+	if min > max {
+		return tp.PortRange{}, fmt.Errorf("invalid port range %s", port)
+	}
+
+	return tp.PortRange{Min: uint16(min), Max: uint16(max)}, nil
+}
+
 // ================ //
 // == Kubernetes == //
 // ================ //
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"flag"
 
@@ -23,6 +24,50 @@ type KubearmorConfig struct {
 	LogPath           string // Log file to use
 	SELinuxProfileDir string // Directory to store SELinux profiles
 	CRISocket         string // Container runtime to use
+	LogFormat         string // Format of stdout and file logs
+
+	LogFileMaxSize          int           // Maximum size of the log file in MB before rotation
+	LogFileRotationInterval time.Duration // Maximum age of the log file before rotation
+	LogFileMaxBackups       int           // Number of rotated log files to keep
+	LogFileCompress         bool          // Enable/Disable gzip compression of rotated log files
+
+	LogSigningKeyPath     string        // Private key to sign the checkpoints of the hash-chained log file
+	LogCheckpointInterval time.Duration // Interval of signed checkpoints in the log file
+
+	GRPCQueueSize        int // Per-client queue size of gRPC log streams
+	GRPCReplayBufferSize int // Number of recent alerts and logs kept for resumed streams
+
+	TLSCertPath     string // Server certificate file for gRPC
+	TLSKeyPath      string // Server key file for gRPC
+	TLSClientCAPath string // Client CA file for gRPC mutual TLS
+	GRPCAuthPath    string // Client scope file for gRPC authorization
+
+	EventPipelineWorkers   int // Number of policy matching workers
+	EventPipelineQueueSize int // Per-worker queue size of the event pipeline
+
+	ExporterConfigPath string // Exporter configuration file
+
+	RedactionConfigPath string // Redaction rule file
+
+	AlertAggregationWindow time.Duration // Window to collapse identical alerts
+
+	K8sEvents                 bool          // Enable/Disable Kubernetes Events on blocked operations
+	K8sEventAggregationWindow time.Duration // Window in which identical alerts update the same Kubernetes Event
+	K8sEventRateLimit         int           // Maximum Kubernetes Event requests/s
+
+	LearningCollapseThreshold int // Number of sibling paths collapsed into a directory in learned policies
+	LearningMaxPaths          int // Maximum paths recorded for each pod in learning mode
+
+	ContainerEventRateLimit int // Maximum events/s per container
+	HostEventRateLimit      int // Maximum events/s for the host
+
+	MetricsPort string // Port for Prometheus metrics
+
+	EventStorePath      string        // Database file of the local event store
+	EventStoreRetention time.Duration // Time to keep events in the local event store
+	EventStoreMaxSize   int           // Maximum size of the local event store in MB
+
+	ProcessAncestryDepth int // Maximum number of ancestors attached to events
 
 	Visibility     string // Container visibility to use
 	HostVisibility string // Host visibility to use
@@ -70,6 +115,96 @@ const ConfigSELinuxProfileDir string = "seLinuxProfileDir"
 // ConfigCRISocket key
 const ConfigCRISocket string = "criSocket"
 
+// ConfigLogFormat Log format key
+const ConfigLogFormat string = "logFormat"
+
+// ConfigLogFileMaxSize Log file max size key
+const ConfigLogFileMaxSize string = "logFileMaxSize"
+
+// ConfigLogFileRotationInterval Log file rotation interval key
+const ConfigLogFileRotationInterval string = "logFileRotationInterval"
+
+// ConfigLogFileMaxBackups Log file max backups key
+const ConfigLogFileMaxBackups string = "logFileMaxBackups"
+
+// ConfigLogFileCompress Log file compression key
+const ConfigLogFileCompress string = "logFileCompress"
+
+// ConfigLogSigningKeyPath Log signing key key
+const ConfigLogSigningKeyPath string = "logSigningKeyPath"
+
+// ConfigLogCheckpointInterval Log checkpoint interval key
+const ConfigLogCheckpointInterval string = "logCheckpointInterval"
+
+// ConfigGRPCQueueSize Per-client gRPC queue size key
+const ConfigGRPCQueueSize string = "gRPCQueueSize"
+
+// ConfigGRPCReplayBufferSize gRPC replay buffer size key
+const ConfigGRPCReplayBufferSize string = "gRPCReplayBufferSize"
+
+// ConfigTLSCertPath gRPC server certificate key
+const ConfigTLSCertPath string = "tlsCertPath"
+
+// ConfigTLSKeyPath gRPC server key key
+const ConfigTLSKeyPath string = "tlsKeyPath"
+
+// ConfigTLSClientCAPath gRPC client CA key
+const ConfigTLSClientCAPath string = "tlsClientCAPath"
+
+// ConfigGRPCAuthPath gRPC client scope file key
+const ConfigGRPCAuthPath string = "gRPCAuthPath"
+
+// ConfigEventPipelineWorkers Event pipeline workers key
+const ConfigEventPipelineWorkers string = "eventPipelineWorkers"
+
+// ConfigEventPipelineQueueSize Event pipeline queue size key
+const ConfigEventPipelineQueueSize string = "eventPipelineQueueSize"
+
+// ConfigExporterConfigPath Exporter configuration file key
+const ConfigExporterConfigPath string = "exporterConfigPath"
+
+// ConfigRedactionConfigPath Redaction rule file key
+const ConfigRedactionConfigPath string = "redactionConfigPath"
+
+// ConfigAlertAggregationWindow Alert aggregation window key
+const ConfigAlertAggregationWindow string = "alertAggregationWindow"
+
+// ConfigK8sEvents Kubernetes Events key
+const ConfigK8sEvents string = "k8sEvents"
+
+// ConfigK8sEventAggregationWindow Kubernetes Event aggregation window key
+const ConfigK8sEventAggregationWindow string = "k8sEventAggregationWindow"
+
+// ConfigK8sEventRateLimit Kubernetes Event rate limit key
+const ConfigK8sEventRateLimit string = "k8sEventRateLimit"
+
+// ConfigLearningCollapseThreshold Learned policy directory collapse threshold key
+const ConfigLearningCollapseThreshold string = "learningCollapseThreshold"
+
+// ConfigLearningMaxPaths Learning mode path limit key
+const ConfigLearningMaxPaths string = "learningMaxPaths"
+
+// ConfigContainerEventRateLimit Per-container event rate limit key
+const ConfigContainerEventRateLimit string = "containerEventRateLimit"
+
+// ConfigHostEventRateLimit Host event rate limit key
+const ConfigHostEventRateLimit string = "hostEventRateLimit"
+
+// ConfigMetricsPort Prometheus metrics port key
+const ConfigMetricsPort string = "metricsPort"
+
+// ConfigEventStorePath Event store path key
+const ConfigEventStorePath string = "eventStorePath"
+
+// ConfigEventStoreRetention Event store retention key
+const ConfigEventStoreRetention string = "eventStoreRetention"
+
+// ConfigEventStoreMaxSize Event store max size key
+const ConfigEventStoreMaxSize string = "eventStoreMaxSize"
+
+// ConfigProcessAncestryDepth Process ancestry depth key
+const ConfigProcessAncestryDepth string = "processAncestryDepth"
+
 // ConfigVisibility Container visibility key
 const ConfigVisibility string = "visibility"
 
@@ -119,6 +254,51 @@ func readCmdLineParams() {
 	seLinuxProfileDirStr := flag.String(ConfigSELinuxProfileDir, "/tmp/kubearmor.selinux", "SELinux profile directory")
 	criSocket := flag.String(ConfigCRISocket, "", "path to CRI socket (format: unix:///path/to/file.sock)")
 
+	logFormatStr := flag.String(ConfigLogFormat, "json", "format of stdout and file logs {json|cef|leef}")
+
+	logFileMaxSize := flag.Int(ConfigLogFileMaxSize, 0, "maximum size of the log file in MB before rotation (0 for no limit)")
+	logFileRotationInterval := flag.Duration(ConfigLogFileRotationInterval, 0, "maximum age of the log file before rotation (0 for no limit)")
+	logFileMaxBackups := flag.Int(ConfigLogFileMaxBackups, 5, "number of rotated log files to keep (0 for all)")
+	logFileCompressB := flag.Bool(ConfigLogFileCompress, true, "enabling gzip compression of rotated log files")
+
+	logSigningKeyPath := flag.String(ConfigLogSigningKeyPath, "", "path to the ed25519 private key (PKCS #8 PEM) signing the hash-chained log file (hash chaining is disabled if empty)")
+	logCheckpointInterval := flag.Duration(ConfigLogCheckpointInterval, 1*time.Minute, "interval of signed checkpoints in the hash-chained log file")
+
+	grpcQueueSize := flag.Int(ConfigGRPCQueueSize, 1024, "per-client queue size of gRPC log streams")
+	grpcReplayBufferSize := flag.Int(ConfigGRPCReplayBufferSize, 4096, "number of recent alerts and logs kept for resumed gRPC streams")
+
+	tlsCertPath := flag.String(ConfigTLSCertPath, "", "path to the gRPC server certificate (TLS is disabled if empty)")
+	tlsKeyPath := flag.String(ConfigTLSKeyPath, "", "path to the gRPC server key")
+	tlsClientCAPath := flag.String(ConfigTLSClientCAPath, "", "path to the CA to verify gRPC clients (mutual TLS is disabled if empty)")
+	grpcAuthPath := flag.String(ConfigGRPCAuthPath, "", "path to the gRPC client scope file (authorization is disabled if empty)")
+
+	eventPipelineWorkers := flag.Int(ConfigEventPipelineWorkers, 4, "number of workers matching events with policies (0 to hand over each event to its own goroutine)")
+	eventPipelineQueueSize := flag.Int(ConfigEventPipelineQueueSize, 4096, "per-worker queue size of the event pipeline")
+
+	exporterConfigPath := flag.String(ConfigExporterConfigPath, "", "path to the exporter configuration file (exporters are disabled if empty)")
+
+	redactionConfigPath := flag.String(ConfigRedactionConfigPath, "", "path to the redaction rule file (redaction is disabled if empty)")
+
+	alertAggregationWindow := flag.Duration(ConfigAlertAggregationWindow, 0, "window to collapse identical alerts into one with a repeat count (0 to disable)")
+
+	k8sEventsB := flag.Bool(ConfigK8sEvents, false, "enabling Kubernetes Events on pods for blocked operations")
+	k8sEventAggregationWindow := flag.Duration(ConfigK8sEventAggregationWindow, 10*time.Minute, "window in which identical alerts update the same Kubernetes Event")
+	k8sEventRateLimit := flag.Int(ConfigK8sEventRateLimit, 10, "maximum Kubernetes Event requests per second")
+
+	learningCollapseThreshold := flag.Int(ConfigLearningCollapseThreshold, 8, "number of sibling paths collapsed into a directory in policies learned from pods with the learning annotation")
+	learningMaxPaths := flag.Int(ConfigLearningMaxPaths, 10000, "maximum paths recorded for each pod in learning mode")
+
+	containerEventRateLimit := flag.Int(ConfigContainerEventRateLimit, 0, "maximum events per second for each container, overridden by the kubearmor-event-rate-limit annotation (0 for no limit)")
+	hostEventRateLimit := flag.Int(ConfigHostEventRateLimit, 0, "maximum events per second for the host (0 for no limit)")
+
+	metricsPort := flag.String(ConfigMetricsPort, "", "port number to serve Prometheus metrics on /metrics (disabled if empty)")
+
+	eventStorePath := flag.String(ConfigEventStorePath, "", "database file to keep alerts and logs for historical queries (disabled if empty)")
+	eventStoreRetention := flag.Duration(ConfigEventStoreRetention, 24*time.Hour, "time to keep events in the event store (0 for no limit)")
+	eventStoreMaxSize := flag.Int(ConfigEventStoreMaxSize, 256, "maximum size of the event store in MB (0 for no limit)")
+
+	processAncestryDepth := flag.Int(ConfigProcessAncestryDepth, 5, "maximum number of ancestor processes attached to alerts and logs (0 to disable)")
+
 	visStr := flag.String(ConfigVisibility, "process,file,network,capabilities", "Container Visibility to use [process,file,network,capabilities,none]")
 	hostVisStr := flag.String(ConfigHostVisibility, "default", "Host Visibility to use [process,file,network,capabilities,none] (default \"none\" for k8s, \"process,file,network,capabilities\" for VM)")
 
@@ -154,6 +334,51 @@ func readCmdLineParams() {
 	viper.SetDefault(ConfigSELinuxProfileDir, *seLinuxProfileDirStr)
 	viper.SetDefault(ConfigCRISocket, *criSocket)
 
+	viper.SetDefault(ConfigLogFormat, *logFormatStr)
+
+	viper.SetDefault(ConfigLogFileMaxSize, *logFileMaxSize)
+	viper.SetDefault(ConfigLogFileRotationInterval, *logFileRotationInterval)
+	viper.SetDefault(ConfigLogFileMaxBackups, *logFileMaxBackups)
+	viper.SetDefault(ConfigLogFileCompress, *logFileCompressB)
+
+	viper.SetDefault(ConfigLogSigningKeyPath, *logSigningKeyPath)
+	viper.SetDefault(ConfigLogCheckpointInterval, *logCheckpointInterval)
+
+	viper.SetDefault(ConfigGRPCQueueSize, *grpcQueueSize)
+	viper.SetDefault(ConfigGRPCReplayBufferSize, *grpcReplayBufferSize)
+
+	viper.SetDefault(ConfigTLSCertPath, *tlsCertPath)
+	viper.SetDefault(ConfigTLSKeyPath, *tlsKeyPath)
+	viper.SetDefault(ConfigTLSClientCAPath, *tlsClientCAPath)
+	viper.SetDefault(ConfigGRPCAuthPath, *grpcAuthPath)
+
+	viper.SetDefault(ConfigEventPipelineWorkers, *eventPipelineWorkers)
+	viper.SetDefault(ConfigEventPipelineQueueSize, *eventPipelineQueueSize)
+
+	viper.SetDefault(ConfigExporterConfigPath, *exporterConfigPath)
+
+	viper.SetDefault(ConfigRedactionConfigPath, *redactionConfigPath)
+
+	viper.SetDefault(ConfigAlertAggregationWindow, *alertAggregationWindow)
+
+	viper.SetDefault(ConfigK8sEvents, *k8sEventsB)
+	viper.SetDefault(ConfigK8sEventAggregationWindow, *k8sEventAggregationWindow)
+	viper.SetDefault(ConfigK8sEventRateLimit, *k8sEventRateLimit)
+
+	viper.SetDefault(ConfigLearningCollapseThreshold, *learningCollapseThreshold)
+	viper.SetDefault(ConfigLearningMaxPaths, *learningMaxPaths)
+
+	viper.SetDefault(ConfigContainerEventRateLimit, *containerEventRateLimit)
+	viper.SetDefault(ConfigHostEventRateLimit, *hostEventRateLimit)
+
+	viper.SetDefault(ConfigMetricsPort, *metricsPort)
+
+	viper.SetDefault(ConfigEventStorePath, *eventStorePath)
+	viper.SetDefault(ConfigEventStoreRetention, *eventStoreRetention)
+	viper.SetDefault(ConfigEventStoreMaxSize, *eventStoreMaxSize)
+
+	viper.SetDefault(ConfigProcessAncestryDepth, *processAncestryDepth)
+
 	viper.SetDefault(ConfigVisibility, *visStr)
 	viper.SetDefault(ConfigHostVisibility, *hostVisStr)
 
@@ -212,6 +437,103 @@ func LoadConfig() error {
 		return fmt.Errorf("CRI socket must start with 'unix://' (%s is invalid)", GlobalCfg.CRISocket)
 	}
 
+	GlobalCfg.LogFormat = viper.GetString(ConfigLogFormat)
+	if GlobalCfg.LogFormat != "json" && GlobalCfg.LogFormat != "cef" && GlobalCfg.LogFormat != "leef" {
+		return fmt.Errorf("log format must be one of json, cef and leef (%s is invalid)", GlobalCfg.LogFormat)
+	}
+
+	GlobalCfg.LogFileMaxSize = viper.GetInt(ConfigLogFileMaxSize)
+	GlobalCfg.LogFileRotationInterval = viper.GetDuration(ConfigLogFileRotationInterval)
+	GlobalCfg.LogFileMaxBackups = viper.GetInt(ConfigLogFileMaxBackups)
+	GlobalCfg.LogFileCompress = viper.GetBool(ConfigLogFileCompress)
+
+	if GlobalCfg.LogFileMaxSize < 0 || GlobalCfg.LogFileRotationInterval < 0 || GlobalCfg.LogFileMaxBackups < 0 {
+		return fmt.Errorf("log file rotation options must not be negative")
+	}
+
+	GlobalCfg.LogSigningKeyPath = viper.GetString(ConfigLogSigningKeyPath)
+	GlobalCfg.LogCheckpointInterval = viper.GetDuration(ConfigLogCheckpointInterval)
+
+	if GlobalCfg.LogSigningKeyPath != "" && GlobalCfg.LogCheckpointInterval <= 0 {
+		return fmt.Errorf("log checkpoint interval must be positive (%s is invalid)", GlobalCfg.LogCheckpointInterval)
+	}
+
+	GlobalCfg.GRPCQueueSize = viper.GetInt(ConfigGRPCQueueSize)
+	if GlobalCfg.GRPCQueueSize < 0 {
+		return fmt.Errorf("gRPC queue size must not be negative (%d is invalid)", GlobalCfg.GRPCQueueSize)
+	}
+
+	GlobalCfg.GRPCReplayBufferSize = viper.GetInt(ConfigGRPCReplayBufferSize)
+	if GlobalCfg.GRPCReplayBufferSize < 0 {
+		return fmt.Errorf("gRPC replay buffer size must not be negative (%d is invalid)", GlobalCfg.GRPCReplayBufferSize)
+	}
+
+	GlobalCfg.TLSCertPath = viper.GetString(ConfigTLSCertPath)
+	GlobalCfg.TLSKeyPath = viper.GetString(ConfigTLSKeyPath)
+	GlobalCfg.TLSClientCAPath = viper.GetString(ConfigTLSClientCAPath)
+	GlobalCfg.GRPCAuthPath = viper.GetString(ConfigGRPCAuthPath)
+
+	if (GlobalCfg.TLSCertPath == "") != (GlobalCfg.TLSKeyPath == "") {
+		return fmt.Errorf("both %s and %s are required to enable TLS", ConfigTLSCertPath, ConfigTLSKeyPath)
+	}
+
+	if GlobalCfg.TLSClientCAPath != "" && GlobalCfg.TLSCertPath == "" {
+		return fmt.Errorf("%s requires %s and %s", ConfigTLSClientCAPath, ConfigTLSCertPath, ConfigTLSKeyPath)
+	}
+
+	GlobalCfg.EventPipelineWorkers = viper.GetInt(ConfigEventPipelineWorkers)
+	GlobalCfg.EventPipelineQueueSize = viper.GetInt(ConfigEventPipelineQueueSize)
+
+	if GlobalCfg.EventPipelineWorkers < 0 || GlobalCfg.EventPipelineQueueSize <= 0 {
+		return fmt.Errorf("event pipeline workers must not be negative and its queue size must be positive")
+	}
+
+	GlobalCfg.ExporterConfigPath = viper.GetString(ConfigExporterConfigPath)
+
+	GlobalCfg.RedactionConfigPath = viper.GetString(ConfigRedactionConfigPath)
+
+	GlobalCfg.AlertAggregationWindow = viper.GetDuration(ConfigAlertAggregationWindow)
+	if GlobalCfg.AlertAggregationWindow < 0 {
+		return fmt.Errorf("alert aggregation window must not be negative (%s is invalid)", GlobalCfg.AlertAggregationWindow)
+	}
+
+	GlobalCfg.K8sEvents = viper.GetBool(ConfigK8sEvents)
+	GlobalCfg.K8sEventAggregationWindow = viper.GetDuration(ConfigK8sEventAggregationWindow)
+	GlobalCfg.K8sEventRateLimit = viper.GetInt(ConfigK8sEventRateLimit)
+
+	if GlobalCfg.K8sEvents && (GlobalCfg.K8sEventAggregationWindow <= 0 || GlobalCfg.K8sEventRateLimit <= 0) {
+		return fmt.Errorf("Kubernetes Event aggregation window and rate limit must be positive")
+	}
+
+	GlobalCfg.LearningCollapseThreshold = viper.GetInt(ConfigLearningCollapseThreshold)
+	GlobalCfg.LearningMaxPaths = viper.GetInt(ConfigLearningMaxPaths)
+
+	if GlobalCfg.LearningCollapseThreshold < 2 || GlobalCfg.LearningMaxPaths <= 0 {
+		return fmt.Errorf("learning collapse threshold must be at least 2 and the path limit must be positive")
+	}
+
+	GlobalCfg.ContainerEventRateLimit = viper.GetInt(ConfigContainerEventRateLimit)
+	GlobalCfg.HostEventRateLimit = viper.GetInt(ConfigHostEventRateLimit)
+
+	if GlobalCfg.ContainerEventRateLimit < 0 || GlobalCfg.HostEventRateLimit < 0 {
+		return fmt.Errorf("event rate limits must not be negative")
+	}
+
+	GlobalCfg.MetricsPort = viper.GetString(ConfigMetricsPort)
+
+	GlobalCfg.EventStorePath = viper.GetString(ConfigEventStorePath)
+	GlobalCfg.EventStoreRetention = viper.GetDuration(ConfigEventStoreRetention)
+	GlobalCfg.EventStoreMaxSize = viper.GetInt(ConfigEventStoreMaxSize)
+
+	if GlobalCfg.EventStoreRetention < 0 || GlobalCfg.EventStoreMaxSize < 0 {
+		return fmt.Errorf("event store retention and size must not be negative")
+	}
+
+	GlobalCfg.ProcessAncestryDepth = viper.GetInt(ConfigProcessAncestryDepth)
+	if GlobalCfg.ProcessAncestryDepth < 0 {
+		return fmt.Errorf("process ancestry depth must not be negative (%d is invalid)", GlobalCfg.ProcessAncestryDepth)
+	}
+
 	GlobalCfg.Visibility = viper.GetString(ConfigVisibility)
 	GlobalCfg.HostVisibility = viper.GetString(ConfigHostVisibility)
 
@@ -8,6 +8,7 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -15,6 +16,7 @@ import (
 	"path/filepath"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
@@ -22,6 +24,8 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 
 	kl "github.com/kubearmor/KubeArmor/KubeArmor/common"
+	cfg "github.com/kubearmor/KubeArmor/KubeArmor/config"
+	fd "github.com/kubearmor/KubeArmor/KubeArmor/feeder"
 	kg "github.com/kubearmor/KubeArmor/KubeArmor/log"
 )
 
@@ -35,6 +39,14 @@ var K8s *K8sHandler
 // init Function
 func init() {
 	K8s = NewK8sHandler()
+
+	// Kubernetes Events through the handler
+	fd.RegisterExporter("k8sEvents", func(spec fd.ExporterSpec) (fd.Exporter, error) {
+		if K8s.K8sClient == nil {
+			return nil, errors.New("the Kubernetes client is not initialized")
+		}
+		return fd.NewK8sEventExporter(spec, K8s, cfg.GlobalCfg.Host)
+	})
 }
 
 // K8sHandler Structure
@@ -380,6 +392,33 @@ func (kh *K8sHandler) WatchK8sPods() *http.Response {
 	return nil
 }
 
+// ============ //
+// == Events == //
+// ============ //
+
+// GetPodUID Function
+func (kh *K8sHandler) GetPodUID(namespaceName, podName string) (types.UID, error) {
+	pod, err := kh.K8sClient.CoreV1().Pods(namespaceName).Get(context.Background(), podName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	return pod.UID, nil
+}
+
+// CreateEvent Function
+func (kh *K8sHandler) CreateEvent(event *corev1.Event) (*corev1.Event, error) {
+	return kh.K8sClient.CoreV1().Events(event.Namespace).Create(context.Background(), event, metav1.CreateOptions{})
+}
+
+// UpdateEventCount Function
+func (kh *K8sHandler) UpdateEventCount(namespaceName, eventName string, count int32, lastTimestamp time.Time) error {
+	patch := fmt.Sprintf(`{"count":%d,"lastTimestamp":"%s"}`, count, lastTimestamp.UTC().Format(time.RFC3339))
+
+	_, err := kh.K8sClient.CoreV1().Events(namespaceName).Patch(context.Background(), eventName, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+	return err
+}
+
 // ====================== //
 // == Custom Resources == //
 // ====================== //
@@ -14,9 +14,11 @@ import (
 	kl "github.com/kubearmor/KubeArmor/KubeArmor/common"
 	cfg "github.com/kubearmor/KubeArmor/KubeArmor/config"
 	kg "github.com/kubearmor/KubeArmor/KubeArmor/log"
+	"github.com/kubearmor/KubeArmor/KubeArmor/metrics"
 	"github.com/kubearmor/KubeArmor/KubeArmor/policy"
 	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
-	"google.golang.org/grpc/reflection"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	efc "github.com/kubearmor/KubeArmor/KubeArmor/enforcer"
 	fd "github.com/kubearmor/KubeArmor/KubeArmor/feeder"
@@ -86,6 +88,10 @@ type KubeArmorDaemon struct {
 	// kvm agent
 	KVMAgent *kvm.KVMAgent
 
+	// metrics
+	MetricsServer *metrics.MetricsServer
+	MetricsGauges []prometheus.Collector
+
 	// WgDaemon Handler
 	WgDaemon sync.WaitGroup
 }
@@ -124,6 +130,9 @@ func NewKubeArmorDaemon() *KubeArmorDaemon {
 	dm.RuntimeEnforcer = nil
 	dm.KVMAgent = nil
 
+	dm.MetricsServer = nil
+	dm.MetricsGauges = []prometheus.Collector{}
+
 	dm.WgDaemon = sync.WaitGroup{}
 
 	return dm
@@ -152,6 +161,13 @@ func (dm *KubeArmorDaemon) DestroyKubeArmorDaemon() {
 		}
 	}
 
+	if dm.MetricsServer != nil {
+		// close metrics server
+		if dm.CloseMetricsServer() {
+			dm.Logger.Print("Stopped Metrics Server")
+		}
+	}
+
 	if dm.Logger != nil {
 		dm.Logger.Print("Terminated KubeArmor")
 	} else {
@@ -210,6 +226,77 @@ func (dm *KubeArmorDaemon) CloseLogger() bool {
 	return true
 }
 
+// ============= //
+// == Metrics == //
+// ============= //
+
+// InitMetricsServer Function
+func (dm *KubeArmorDaemon) InitMetricsServer() bool {
+	server, err := metrics.NewMetricsServer(cfg.GlobalCfg.MetricsPort)
+	if err != nil {
+		dm.Logger.Errf("Failed to listen on the metrics port (%s)", err.Error())
+		return false
+	}
+	dm.MetricsServer = server
+
+	dm.MetricsGauges = []prometheus.Collector{
+		metrics.NewGaugeFunc("containers", "Number of tracked containers", func() float64 {
+			dm.ContainersLock.RLock()
+			defer dm.ContainersLock.RUnlock()
+
+			return float64(len(dm.Containers))
+		}),
+		metrics.NewGaugeFunc("endpoints", "Number of tracked endpoints", func() float64 {
+			dm.EndPointsLock.RLock()
+			defer dm.EndPointsLock.RUnlock()
+
+			return float64(len(dm.EndPoints))
+		}),
+		metrics.NewGaugeFunc("active_pids", "Number of active processes tracked in containers", func() float64 {
+			dm.ActivePidMapLock.RLock()
+			defer dm.ActivePidMapLock.RUnlock()
+
+			count := 0
+			for _, pidMap := range dm.ActiveHostPidMap {
+				count += len(pidMap)
+			}
+
+			return float64(count)
+		}),
+	}
+
+	for _, gauge := range dm.MetricsGauges {
+		if err := metrics.Registry.Register(gauge); err != nil {
+			dm.Logger.Warnf("Failed to register a metric (%s)", err.Error())
+		}
+	}
+
+	return true
+}
+
+// ServeMetrics Function
+func (dm *KubeArmorDaemon) ServeMetrics() {
+	dm.WgDaemon.Add(1)
+	defer dm.WgDaemon.Done()
+
+	dm.MetricsServer.Serve()
+}
+
+// CloseMetricsServer Function
+func (dm *KubeArmorDaemon) CloseMetricsServer() bool {
+	for _, gauge := range dm.MetricsGauges {
+		metrics.Registry.Unregister(gauge)
+	}
+	dm.MetricsGauges = []prometheus.Collector{}
+
+	if err := dm.MetricsServer.Close(); err != nil {
+		dm.Logger.Errf("Failed to close the metrics server (%s)", err.Error())
+		return false
+	}
+
+	return true
+}
+
 // ==================== //
 // == System Monitor == //
 // ==================== //
@@ -410,6 +497,24 @@ func KubeArmor() {
 	}
 	dm.Logger.Print("Initialized KubeArmor Logger")
 
+	// Kubernetes Events on blocked operations
+	if dm.K8sEnabled && cfg.GlobalCfg.K8sEvents {
+		if err := dm.Logger.AddExporter(fd.ExporterSpec{
+			Name:          "k8s-events",
+			Type:          "k8sEvents",
+			BatchSize:     256,
+			FlushInterval: metav1.Duration{Duration: 5 * time.Second},
+			K8sEvents: &fd.K8sEventsSpec{
+				AggregationWindow: metav1.Duration{Duration: cfg.GlobalCfg.K8sEventAggregationWindow},
+				RateLimit:         cfg.GlobalCfg.K8sEventRateLimit,
+			},
+		}); err != nil {
+			dm.Logger.Errf("Failed to enable Kubernetes Events (%s)", err.Error())
+		} else {
+			dm.Logger.Print("Enabled Kubernetes Events for blocked operations")
+		}
+	}
+
 	// == //
 
 	if cfg.GlobalCfg.Policy || cfg.GlobalCfg.HostPolicy {
@@ -446,6 +551,9 @@ func KubeArmor() {
 		}
 	}
 
+	// events are available once the system monitor is initialized
+	dm.Logger.SetServingStatus(pb.LogService_ServiceDesc.ServiceName, true)
+
 	// == //
 
 	if dm.K8sEnabled && cfg.GlobalCfg.Policy {
@@ -583,7 +691,9 @@ func KubeArmor() {
 		dm.Node.PolicyEnabled = tp.KubeArmorPolicyEnabled
 
 		pb.RegisterPolicyServiceServer(dm.Logger.LogServer, policyService)
-		reflection.Register(dm.Logger.LogServer)
+
+		// policies are only enforced if the runtime enforcer is initialized
+		dm.Logger.SetServingStatus(pb.PolicyService_ServiceDesc.ServiceName, dm.RuntimeEnforcer != nil)
 
 		dm.Logger.Print("Started to monitor host security policies on gRPC")
 	}
@@ -592,10 +702,28 @@ func KubeArmor() {
 	go dm.ServeLogFeeds()
 	dm.Logger.Print("Started to serve gRPC-based log feeds")
 
+	// serve metrics
+	if cfg.GlobalCfg.MetricsPort != "" {
+		if !dm.InitMetricsServer() {
+			dm.Logger.Err("Failed to initialize Metrics Server")
+
+			// destroy the daemon
+			dm.DestroyKubeArmorDaemon()
+
+			return
+		}
+
+		go dm.ServeMetrics()
+		dm.Logger.Printf("Started to serve Prometheus metrics on port %s", cfg.GlobalCfg.MetricsPort)
+	}
+
 	// == //
 
 	dm.Logger.Print("Initialized KubeArmor")
 
+	// report the daemon as serving
+	dm.Logger.SetServingStatus("", true)
+
 	// == //
 
 	if cfg.GlobalCfg.KVMAgent || (!cfg.GlobalCfg.K8sEnv && cfg.GlobalCfg.HostPolicy) {
@@ -11,12 +11,15 @@ import (
 	"io/ioutil"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	kl "github.com/kubearmor/KubeArmor/KubeArmor/common"
 	cfg "github.com/kubearmor/KubeArmor/KubeArmor/config"
 	kg "github.com/kubearmor/KubeArmor/KubeArmor/log"
+	"github.com/kubearmor/KubeArmor/KubeArmor/metrics"
+	"github.com/kubearmor/KubeArmor/KubeArmor/policy"
 	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -170,6 +173,28 @@ func (dm *KubeArmorDaemon) WatchK8sNodes() {
 // == Pod Update == //
 // ================ //
 
+// getEventRateLimit Function
+func getEventRateLimit(annotations map[string]string) int {
+	val, ok := annotations["kubearmor-event-rate-limit"]
+	if !ok {
+		return 0 // global default
+	}
+
+	if val == "unlimited" {
+		return -1
+	}
+
+	rate, err := strconv.Atoi(val)
+	if err != nil || rate < 0 {
+		kg.Warnf("Invalid kubearmor-event-rate-limit annotation (%s), using the global default", val)
+		return 0
+	} else if rate == 0 {
+		return -1
+	}
+
+	return rate
+}
+
 // UpdateEndPointWithPod Function
 func (dm *KubeArmorDaemon) UpdateEndPointWithPod(action string, pod tp.K8sPod) {
 	if action == "ADDED" {
@@ -197,6 +222,8 @@ func (dm *KubeArmorDaemon) UpdateEndPointWithPod(action string, pod tp.K8sPod) {
 			newPoint.PolicyEnabled = tp.KubeArmorPolicyEnabled
 		} else if pod.Annotations["kubearmor-policy"] == "audited" {
 			newPoint.PolicyEnabled = tp.KubeArmorPolicyAudited
+		} else if pod.Annotations["kubearmor-policy"] == "learning" {
+			newPoint.PolicyEnabled = tp.KubeArmorPolicyLearning
 		} else { // disabled
 			newPoint.PolicyEnabled = tp.KubeArmorPolicyDisabled
 		}
@@ -214,6 +241,14 @@ func (dm *KubeArmorDaemon) UpdateEndPointWithPod(action string, pod tp.K8sPod) {
 			}
 		}
 
+		// learning mode records every operation
+		if newPoint.PolicyEnabled == tp.KubeArmorPolicyLearning {
+			newPoint.ProcessVisibilityEnabled = true
+			newPoint.FileVisibilityEnabled = true
+			newPoint.NetworkVisibilityEnabled = true
+			newPoint.CapabilitiesVisibilityEnabled = true
+		}
+
 		newPoint.Containers = []string{}
 		newPoint.AppArmorProfiles = []string{}
 
@@ -246,6 +281,8 @@ func (dm *KubeArmorDaemon) UpdateEndPointWithPod(action string, pod tp.K8sPod) {
 			container.NetworkVisibilityEnabled = newPoint.NetworkVisibilityEnabled
 			container.CapabilitiesVisibilityEnabled = newPoint.CapabilitiesVisibilityEnabled
 
+			container.EventRateLimit = getEventRateLimit(pod.Annotations)
+
 			if !kl.ContainsElement(newPoint.AppArmorProfiles, container.AppArmorProfile) {
 				newPoint.AppArmorProfiles = append(newPoint.AppArmorProfiles, container.AppArmorProfile)
 			}
@@ -317,6 +354,8 @@ func (dm *KubeArmorDaemon) UpdateEndPointWithPod(action string, pod tp.K8sPod) {
 			newEndPoint.PolicyEnabled = tp.KubeArmorPolicyEnabled
 		} else if pod.Annotations["kubearmor-policy"] == "audited" {
 			newEndPoint.PolicyEnabled = tp.KubeArmorPolicyAudited
+		} else if pod.Annotations["kubearmor-policy"] == "learning" {
+			newEndPoint.PolicyEnabled = tp.KubeArmorPolicyLearning
 		} else { // disabled
 			newEndPoint.PolicyEnabled = tp.KubeArmorPolicyDisabled
 		}
@@ -339,6 +378,14 @@ func (dm *KubeArmorDaemon) UpdateEndPointWithPod(action string, pod tp.K8sPod) {
 			}
 		}
 
+		// learning mode records every operation
+		if newEndPoint.PolicyEnabled == tp.KubeArmorPolicyLearning {
+			newEndPoint.ProcessVisibilityEnabled = true
+			newEndPoint.FileVisibilityEnabled = true
+			newEndPoint.NetworkVisibilityEnabled = true
+			newEndPoint.CapabilitiesVisibilityEnabled = true
+		}
+
 		newEndPoint.Containers = []string{}
 		newEndPoint.AppArmorProfiles = []string{}
 		newEndPoint.SELinuxProfiles = []string{}
@@ -372,6 +419,8 @@ func (dm *KubeArmorDaemon) UpdateEndPointWithPod(action string, pod tp.K8sPod) {
 			container.NetworkVisibilityEnabled = newEndPoint.NetworkVisibilityEnabled
 			container.CapabilitiesVisibilityEnabled = newEndPoint.CapabilitiesVisibilityEnabled
 
+			container.EventRateLimit = getEventRateLimit(pod.Annotations)
+
 			if !kl.ContainsElement(newEndPoint.AppArmorProfiles, container.AppArmorProfile) {
 				newEndPoint.AppArmorProfiles = append(newEndPoint.AppArmorProfiles, container.AppArmorProfile)
 			}
@@ -427,6 +476,11 @@ func (dm *KubeArmorDaemon) UpdateEndPointWithPod(action string, pod tp.K8sPod) {
 			}
 		}
 		dm.EndPointsLock.Unlock()
+
+		// forget the behavior learned from the pod
+		if dm.Logger.Learner != nil {
+			dm.Logger.Learner.Forget(pod.Metadata["namespaceName"], pod.Metadata["podName"])
+		}
 	}
 }
 
@@ -523,7 +577,7 @@ func (dm *KubeArmorDaemon) WatchK8sPods() {
 					pod.Annotations["kubearmor-policy"] = "enabled"
 				}
 
-				if pod.Annotations["kubearmor-policy"] != "enabled" && pod.Annotations["kubearmor-policy"] != "disabled" && pod.Annotations["kubearmor-policy"] != "audited" {
+				if pod.Annotations["kubearmor-policy"] != "enabled" && pod.Annotations["kubearmor-policy"] != "disabled" && pod.Annotations["kubearmor-policy"] != "audited" && pod.Annotations["kubearmor-policy"] != "learning" {
 					pod.Annotations["kubearmor-policy"] = "enabled"
 				}
 
@@ -726,6 +780,9 @@ func (dm *KubeArmorDaemon) GetSecurityPolicies(identities []string) []tp.Securit
 
 // UpdateSecurityPolicy Function
 func (dm *KubeArmorDaemon) UpdateSecurityPolicy(action string, secPolicy tp.SecurityPolicy) {
+	start := time.Now()
+	defer metrics.ObservePolicyUpdate("KubeArmorPolicy", action, start)
+
 	dm.EndPointsLock.Lock()
 	defer dm.EndPointsLock.Unlock()
 
@@ -806,337 +863,12 @@ func (dm *KubeArmorDaemon) WatchSecurityPolicies() {
 
 				// create a security policy
 
-				secPolicy := tp.SecurityPolicy{}
-
-				secPolicy.Metadata = map[string]string{}
-				secPolicy.Metadata["namespaceName"] = event.Object.Metadata.Namespace
-				secPolicy.Metadata["policyName"] = event.Object.Metadata.Name
-
-				if err := kl.Clone(event.Object.Spec, &secPolicy.Spec); err != nil {
+				secPolicy, err := policy.NewSecurityPolicy(event.Object)
+				if err != nil {
 					dm.Logger.Errf("Failed to clone a spec (%s)", err.Error())
 					continue
 				}
 
-				kl.ObjCommaExpandFirstDupOthers(&secPolicy.Spec.Network.MatchProtocols)
-				kl.ObjCommaExpandFirstDupOthers(&secPolicy.Spec.Capabilities.MatchCapabilities)
-
-				if secPolicy.Spec.Severity == 0 {
-					secPolicy.Spec.Severity = 1 // the lowest severity, by default
-				}
-
-				switch secPolicy.Spec.Action {
-				case "allow":
-					secPolicy.Spec.Action = "Allow"
-				case "audit":
-					secPolicy.Spec.Action = "Audit"
-				case "block":
-					secPolicy.Spec.Action = "Block"
-				case "":
-					secPolicy.Spec.Action = "Block" // by default
-				}
-
-				// add identities
-
-				secPolicy.Spec.Selector.Identities = []string{"namespaceName=" + event.Object.Metadata.Namespace}
-
-				for k, v := range secPolicy.Spec.Selector.MatchLabels {
-					secPolicy.Spec.Selector.Identities = append(secPolicy.Spec.Selector.Identities, k+"="+v)
-				}
-
-				sort.Slice(secPolicy.Spec.Selector.Identities, func(i, j int) bool {
-					return secPolicy.Spec.Selector.Identities[i] < secPolicy.Spec.Selector.Identities[j]
-				})
-
-				// add severities, tags, messages, and actions
-
-				if len(secPolicy.Spec.Process.MatchPaths) > 0 {
-					for idx, path := range secPolicy.Spec.Process.MatchPaths {
-						if path.Severity == 0 {
-							if secPolicy.Spec.Process.Severity != 0 {
-								secPolicy.Spec.Process.MatchPaths[idx].Severity = secPolicy.Spec.Process.Severity
-							} else {
-								secPolicy.Spec.Process.MatchPaths[idx].Severity = secPolicy.Spec.Severity
-							}
-						}
-
-						if len(path.Tags) == 0 {
-							if len(secPolicy.Spec.Process.Tags) > 0 {
-								secPolicy.Spec.Process.MatchPaths[idx].Tags = secPolicy.Spec.Process.Tags
-							} else {
-								secPolicy.Spec.Process.MatchPaths[idx].Tags = secPolicy.Spec.Tags
-							}
-						}
-
-						if len(path.Message) == 0 {
-							if len(secPolicy.Spec.Process.Message) > 0 {
-								secPolicy.Spec.Process.MatchPaths[idx].Message = secPolicy.Spec.Process.Message
-							} else {
-								secPolicy.Spec.Process.MatchPaths[idx].Message = secPolicy.Spec.Message
-							}
-						}
-
-						if len(path.Action) == 0 {
-							if len(secPolicy.Spec.Process.Action) > 0 {
-								secPolicy.Spec.Process.MatchPaths[idx].Action = secPolicy.Spec.Process.Action
-							} else {
-								secPolicy.Spec.Process.MatchPaths[idx].Action = secPolicy.Spec.Action
-							}
-						}
-					}
-				}
-
-				if len(secPolicy.Spec.Process.MatchDirectories) > 0 {
-					for idx, dir := range secPolicy.Spec.Process.MatchDirectories {
-						if dir.Severity == 0 {
-							if secPolicy.Spec.Process.Severity != 0 {
-								secPolicy.Spec.Process.MatchDirectories[idx].Severity = secPolicy.Spec.Process.Severity
-							} else {
-								secPolicy.Spec.Process.MatchDirectories[idx].Severity = secPolicy.Spec.Severity
-							}
-						}
-
-						if len(dir.Tags) == 0 {
-							if len(secPolicy.Spec.Process.Tags) > 0 {
-								secPolicy.Spec.Process.MatchDirectories[idx].Tags = secPolicy.Spec.Process.Tags
-							} else {
-								secPolicy.Spec.Process.MatchDirectories[idx].Tags = secPolicy.Spec.Tags
-							}
-						}
-
-						if len(dir.Message) == 0 {
-							if len(secPolicy.Spec.Process.Message) > 0 {
-								secPolicy.Spec.Process.MatchDirectories[idx].Message = secPolicy.Spec.Process.Message
-							} else {
-								secPolicy.Spec.Process.MatchDirectories[idx].Message = secPolicy.Spec.Message
-							}
-						}
-
-						if len(dir.Action) == 0 {
-							if len(secPolicy.Spec.Process.Action) > 0 {
-								secPolicy.Spec.Process.MatchDirectories[idx].Action = secPolicy.Spec.Process.Action
-							} else {
-								secPolicy.Spec.Process.MatchDirectories[idx].Action = secPolicy.Spec.Action
-							}
-						}
-					}
-				}
-
-				if len(secPolicy.Spec.Process.MatchPatterns) > 0 {
-					for idx, pat := range secPolicy.Spec.Process.MatchPatterns {
-						if pat.Severity == 0 {
-							if secPolicy.Spec.Process.Severity != 0 {
-								secPolicy.Spec.Process.MatchPatterns[idx].Severity = secPolicy.Spec.Process.Severity
-							} else {
-								secPolicy.Spec.Process.MatchPatterns[idx].Severity = secPolicy.Spec.Severity
-							}
-						}
-
-						if len(pat.Tags) == 0 {
-							if len(secPolicy.Spec.Process.Tags) > 0 {
-								secPolicy.Spec.Process.MatchPatterns[idx].Tags = secPolicy.Spec.Process.Tags
-							} else {
-								secPolicy.Spec.Process.MatchPatterns[idx].Tags = secPolicy.Spec.Tags
-							}
-						}
-
-						if len(pat.Message) == 0 {
-							if len(secPolicy.Spec.Process.Message) > 0 {
-								secPolicy.Spec.Process.MatchPatterns[idx].Message = secPolicy.Spec.Process.Message
-							} else {
-								secPolicy.Spec.Process.MatchPatterns[idx].Message = secPolicy.Spec.Message
-							}
-						}
-
-						if len(pat.Action) == 0 {
-							if len(secPolicy.Spec.Process.Action) > 0 {
-								secPolicy.Spec.Process.MatchPatterns[idx].Action = secPolicy.Spec.Process.Action
-							} else {
-								secPolicy.Spec.Process.MatchPatterns[idx].Action = secPolicy.Spec.Action
-							}
-						}
-					}
-				}
-
-				if len(secPolicy.Spec.File.MatchPaths) > 0 {
-					for idx, path := range secPolicy.Spec.File.MatchPaths {
-						if path.Severity == 0 {
-							if secPolicy.Spec.File.Severity != 0 {
-								secPolicy.Spec.File.MatchPaths[idx].Severity = secPolicy.Spec.File.Severity
-							} else {
-								secPolicy.Spec.File.MatchPaths[idx].Severity = secPolicy.Spec.Severity
-							}
-						}
-
-						if len(path.Tags) == 0 {
-							if len(secPolicy.Spec.File.Tags) > 0 {
-								secPolicy.Spec.File.MatchPaths[idx].Tags = secPolicy.Spec.File.Tags
-							} else {
-								secPolicy.Spec.File.MatchPaths[idx].Tags = secPolicy.Spec.Tags
-							}
-						}
-
-						if len(path.Message) == 0 {
-							if len(secPolicy.Spec.File.Message) > 0 {
-								secPolicy.Spec.File.MatchPaths[idx].Message = secPolicy.Spec.File.Message
-							} else {
-								secPolicy.Spec.File.MatchPaths[idx].Message = secPolicy.Spec.Message
-							}
-						}
-
-						if len(path.Action) == 0 {
-							if len(secPolicy.Spec.File.Action) > 0 {
-								secPolicy.Spec.File.MatchPaths[idx].Action = secPolicy.Spec.File.Action
-							} else {
-								secPolicy.Spec.File.MatchPaths[idx].Action = secPolicy.Spec.Action
-							}
-						}
-					}
-				}
-
-				if len(secPolicy.Spec.File.MatchDirectories) > 0 {
-					for idx, dir := range secPolicy.Spec.File.MatchDirectories {
-						if dir.Severity == 0 {
-							if secPolicy.Spec.File.Severity != 0 {
-								secPolicy.Spec.File.MatchDirectories[idx].Severity = secPolicy.Spec.File.Severity
-							} else {
-								secPolicy.Spec.File.MatchDirectories[idx].Severity = secPolicy.Spec.Severity
-							}
-						}
-
-						if len(dir.Tags) == 0 {
-							if len(secPolicy.Spec.File.Tags) > 0 {
-								secPolicy.Spec.File.MatchDirectories[idx].Tags = secPolicy.Spec.File.Tags
-							} else {
-								secPolicy.Spec.File.MatchDirectories[idx].Tags = secPolicy.Spec.Tags
-							}
-						}
-
-						if len(dir.Message) == 0 {
-							if len(secPolicy.Spec.File.Message) > 0 {
-								secPolicy.Spec.File.MatchDirectories[idx].Message = secPolicy.Spec.File.Message
-							} else {
-								secPolicy.Spec.File.MatchDirectories[idx].Message = secPolicy.Spec.Message
-							}
-						}
-
-						if len(dir.Action) == 0 {
-							if len(secPolicy.Spec.File.Action) > 0 {
-								secPolicy.Spec.File.MatchDirectories[idx].Action = secPolicy.Spec.File.Action
-							} else {
-								secPolicy.Spec.File.MatchDirectories[idx].Action = secPolicy.Spec.Action
-							}
-						}
-					}
-				}
-
-				if len(secPolicy.Spec.File.MatchPatterns) > 0 {
-					for idx, pat := range secPolicy.Spec.File.MatchPatterns {
-						if pat.Severity == 0 {
-							if secPolicy.Spec.File.Severity != 0 {
-								secPolicy.Spec.File.MatchPatterns[idx].Severity = secPolicy.Spec.File.Severity
-							} else {
-								secPolicy.Spec.File.MatchPatterns[idx].Severity = secPolicy.Spec.Severity
-							}
-						}
-
-						if len(pat.Tags) == 0 {
-							if len(secPolicy.Spec.File.Tags) > 0 {
-								secPolicy.Spec.File.MatchPatterns[idx].Tags = secPolicy.Spec.File.Tags
-							} else {
-								secPolicy.Spec.File.MatchPatterns[idx].Tags = secPolicy.Spec.Tags
-							}
-						}
-
-						if len(pat.Message) == 0 {
-							if len(secPolicy.Spec.File.Message) > 0 {
-								secPolicy.Spec.File.MatchPatterns[idx].Message = secPolicy.Spec.File.Message
-							} else {
-								secPolicy.Spec.File.MatchPatterns[idx].Message = secPolicy.Spec.Message
-							}
-						}
-
-						if len(pat.Action) == 0 {
-							if len(secPolicy.Spec.File.Action) > 0 {
-								secPolicy.Spec.File.MatchPatterns[idx].Action = secPolicy.Spec.File.Action
-							} else {
-								secPolicy.Spec.File.MatchPatterns[idx].Action = secPolicy.Spec.Action
-							}
-						}
-					}
-				}
-
-				if len(secPolicy.Spec.Network.MatchProtocols) > 0 {
-					for idx, proto := range secPolicy.Spec.Network.MatchProtocols {
-						if proto.Severity == 0 {
-							if secPolicy.Spec.Network.Severity != 0 {
-								secPolicy.Spec.Network.MatchProtocols[idx].Severity = secPolicy.Spec.Network.Severity
-							} else {
-								secPolicy.Spec.Network.MatchProtocols[idx].Severity = secPolicy.Spec.Severity
-							}
-						}
-
-						if len(proto.Tags) == 0 {
-							if len(secPolicy.Spec.Network.Tags) > 0 {
-								secPolicy.Spec.Network.MatchProtocols[idx].Tags = secPolicy.Spec.Network.Tags
-							} else {
-								secPolicy.Spec.Network.MatchProtocols[idx].Tags = secPolicy.Spec.Tags
-							}
-						}
-
-						if len(proto.Message) == 0 {
-							if len(secPolicy.Spec.Network.Message) > 0 {
-								secPolicy.Spec.Network.MatchProtocols[idx].Message = secPolicy.Spec.Network.Message
-							} else {
-								secPolicy.Spec.Network.MatchProtocols[idx].Message = secPolicy.Spec.Message
-							}
-						}
-
-						if len(proto.Action) == 0 {
-							if len(secPolicy.Spec.Network.Action) > 0 {
-								secPolicy.Spec.Network.MatchProtocols[idx].Action = secPolicy.Spec.Network.Action
-							} else {
-								secPolicy.Spec.Network.MatchProtocols[idx].Action = secPolicy.Spec.Action
-							}
-						}
-					}
-				}
-
-				if len(secPolicy.Spec.Capabilities.MatchCapabilities) > 0 {
-					for idx, cap := range secPolicy.Spec.Capabilities.MatchCapabilities {
-						if cap.Severity == 0 {
-							if secPolicy.Spec.Capabilities.Severity != 0 {
-								secPolicy.Spec.Capabilities.MatchCapabilities[idx].Severity = secPolicy.Spec.Capabilities.Severity
-							} else {
-								secPolicy.Spec.Capabilities.MatchCapabilities[idx].Severity = secPolicy.Spec.Severity
-							}
-						}
-
-						if len(cap.Tags) == 0 {
-							if len(secPolicy.Spec.Capabilities.Tags) > 0 {
-								secPolicy.Spec.Capabilities.MatchCapabilities[idx].Tags = secPolicy.Spec.Capabilities.Tags
-							} else {
-								secPolicy.Spec.Capabilities.MatchCapabilities[idx].Tags = secPolicy.Spec.Tags
-							}
-						}
-
-						if len(cap.Message) == 0 {
-							if len(secPolicy.Spec.Capabilities.Message) > 0 {
-								secPolicy.Spec.Capabilities.MatchCapabilities[idx].Message = secPolicy.Spec.Capabilities.Message
-							} else {
-								secPolicy.Spec.Capabilities.MatchCapabilities[idx].Message = secPolicy.Spec.Message
-							}
-						}
-
-						if len(cap.Action) == 0 {
-							if len(secPolicy.Spec.Capabilities.Action) > 0 {
-								secPolicy.Spec.Capabilities.MatchCapabilities[idx].Action = secPolicy.Spec.Capabilities.Action
-							} else {
-								secPolicy.Spec.Capabilities.MatchCapabilities[idx].Action = secPolicy.Spec.Action
-							}
-						}
-					}
-				}
-
 				// update a security policy into the policy list
 
 				dm.SecurityPoliciesLock.Lock()
@@ -1215,330 +947,17 @@ func (dm *KubeArmorDaemon) UpdateHostSecurityPolicies() {
 
 // ParseAndUpdateHostSecurityPolicy Function
 func (dm *KubeArmorDaemon) ParseAndUpdateHostSecurityPolicy(event tp.K8sKubeArmorHostPolicyEvent) {
-	// create a host security policy
-
-	secPolicy := tp.HostSecurityPolicy{}
+	start := time.Now()
+	defer metrics.ObservePolicyUpdate("KubeArmorHostPolicy", event.Type, start)
 
-	secPolicy.Metadata = map[string]string{}
-	secPolicy.Metadata["policyName"] = event.Object.Metadata.Name
+	// create a host security policy
 
-	if err := kl.Clone(event.Object.Spec, &secPolicy.Spec); err != nil {
+	secPolicy, err := policy.NewHostSecurityPolicy(event.Object)
+	if err != nil {
 		dm.Logger.Errf("Failed to clone a spec (%s)", err.Error())
 		return
 	}
 
-	kl.ObjCommaExpandFirstDupOthers(&secPolicy.Spec.Network.MatchProtocols)
-	kl.ObjCommaExpandFirstDupOthers(&secPolicy.Spec.Capabilities.MatchCapabilities)
-
-	if secPolicy.Spec.Severity == 0 {
-		secPolicy.Spec.Severity = 1 // the lowest severity, by default
-	}
-
-	switch secPolicy.Spec.Action {
-	case "allow":
-		secPolicy.Spec.Action = "Allow"
-	case "audit":
-		secPolicy.Spec.Action = "Audit"
-	case "block":
-		secPolicy.Spec.Action = "Block"
-	case "":
-		secPolicy.Spec.Action = "Block" // by default
-	}
-
-	// add identities
-
-	secPolicy.Spec.NodeSelector.Identities = []string{}
-
-	for k, v := range secPolicy.Spec.NodeSelector.MatchLabels {
-		secPolicy.Spec.NodeSelector.Identities = append(secPolicy.Spec.NodeSelector.Identities, k+"="+v)
-	}
-
-	sort.Slice(secPolicy.Spec.NodeSelector.Identities, func(i, j int) bool {
-		return secPolicy.Spec.NodeSelector.Identities[i] < secPolicy.Spec.NodeSelector.Identities[j]
-	})
-
-	// add severities, tags, messages, and actions
-
-	if len(secPolicy.Spec.Process.MatchPaths) > 0 {
-		for idx, path := range secPolicy.Spec.Process.MatchPaths {
-			if path.Severity == 0 {
-				if secPolicy.Spec.Process.Severity != 0 {
-					secPolicy.Spec.Process.MatchPaths[idx].Severity = secPolicy.Spec.Process.Severity
-				} else {
-					secPolicy.Spec.Process.MatchPaths[idx].Severity = secPolicy.Spec.Severity
-				}
-			}
-
-			if len(path.Tags) == 0 {
-				if len(secPolicy.Spec.Process.Tags) > 0 {
-					secPolicy.Spec.Process.MatchPaths[idx].Tags = secPolicy.Spec.Process.Tags
-				} else {
-					secPolicy.Spec.Process.MatchPaths[idx].Tags = secPolicy.Spec.Tags
-				}
-			}
-
-			if len(path.Message) == 0 {
-				if len(secPolicy.Spec.Process.Message) > 0 {
-					secPolicy.Spec.Process.MatchPaths[idx].Message = secPolicy.Spec.Process.Message
-				} else {
-					secPolicy.Spec.Process.MatchPaths[idx].Message = secPolicy.Spec.Message
-				}
-			}
-
-			if len(path.Action) == 0 {
-				if len(secPolicy.Spec.Process.Action) > 0 {
-					secPolicy.Spec.Process.MatchPaths[idx].Action = secPolicy.Spec.Process.Action
-				} else {
-					secPolicy.Spec.Process.MatchPaths[idx].Action = secPolicy.Spec.Action
-				}
-			}
-		}
-	} else if len(secPolicy.Spec.Process.MatchDirectories) > 0 {
-		for idx, dir := range secPolicy.Spec.Process.MatchDirectories {
-			if dir.Severity == 0 {
-				if secPolicy.Spec.Process.Severity != 0 {
-					secPolicy.Spec.Process.MatchDirectories[idx].Severity = secPolicy.Spec.Process.Severity
-				} else {
-					secPolicy.Spec.Process.MatchDirectories[idx].Severity = secPolicy.Spec.Severity
-				}
-			}
-
-			if len(dir.Tags) == 0 {
-				if len(secPolicy.Spec.Process.Tags) > 0 {
-					secPolicy.Spec.Process.MatchDirectories[idx].Tags = secPolicy.Spec.Process.Tags
-				} else {
-					secPolicy.Spec.Process.MatchDirectories[idx].Tags = secPolicy.Spec.Tags
-				}
-			}
-
-			if len(dir.Message) == 0 {
-				if len(secPolicy.Spec.Process.Message) > 0 {
-					secPolicy.Spec.Process.MatchDirectories[idx].Message = secPolicy.Spec.Process.Message
-				} else {
-					secPolicy.Spec.Process.MatchDirectories[idx].Message = secPolicy.Spec.Message
-				}
-			}
-
-			if len(dir.Action) == 0 {
-				if len(secPolicy.Spec.Process.Action) > 0 {
-					secPolicy.Spec.Process.MatchDirectories[idx].Action = secPolicy.Spec.Process.Action
-				} else {
-					secPolicy.Spec.Process.MatchDirectories[idx].Action = secPolicy.Spec.Action
-				}
-			}
-		}
-	} else if len(secPolicy.Spec.Process.MatchPatterns) > 0 {
-		for idx, pat := range secPolicy.Spec.Process.MatchPatterns {
-			if pat.Severity == 0 {
-				if secPolicy.Spec.Process.Severity != 0 {
-					secPolicy.Spec.Process.MatchPatterns[idx].Severity = secPolicy.Spec.Process.Severity
-				} else {
-					secPolicy.Spec.Process.MatchPatterns[idx].Severity = secPolicy.Spec.Severity
-				}
-			}
-
-			if len(pat.Tags) == 0 {
-				if len(secPolicy.Spec.Process.Tags) > 0 {
-					secPolicy.Spec.Process.MatchPatterns[idx].Tags = secPolicy.Spec.Process.Tags
-				} else {
-					secPolicy.Spec.Process.MatchPatterns[idx].Tags = secPolicy.Spec.Tags
-				}
-			}
-
-			if len(pat.Message) == 0 {
-				if len(secPolicy.Spec.Process.Message) > 0 {
-					secPolicy.Spec.Process.MatchPatterns[idx].Message = secPolicy.Spec.Process.Message
-				} else {
-					secPolicy.Spec.Process.MatchPatterns[idx].Message = secPolicy.Spec.Message
-				}
-			}
-
-			if len(pat.Action) == 0 {
-				if len(secPolicy.Spec.Process.Action) > 0 {
-					secPolicy.Spec.Process.MatchPatterns[idx].Action = secPolicy.Spec.Process.Action
-				} else {
-					secPolicy.Spec.Process.MatchPatterns[idx].Action = secPolicy.Spec.Action
-				}
-			}
-		}
-	}
-
-	if len(secPolicy.Spec.File.MatchPaths) > 0 {
-		for idx, path := range secPolicy.Spec.File.MatchPaths {
-			if path.Severity == 0 {
-				if secPolicy.Spec.File.Severity != 0 {
-					secPolicy.Spec.File.MatchPaths[idx].Severity = secPolicy.Spec.File.Severity
-				} else {
-					secPolicy.Spec.File.MatchPaths[idx].Severity = secPolicy.Spec.Severity
-				}
-			}
-
-			if len(path.Tags) == 0 {
-				if len(secPolicy.Spec.File.Tags) > 0 {
-					secPolicy.Spec.File.MatchPaths[idx].Tags = secPolicy.Spec.File.Tags
-				} else {
-					secPolicy.Spec.File.MatchPaths[idx].Tags = secPolicy.Spec.Tags
-				}
-			}
-
-			if len(path.Message) == 0 {
-				if len(secPolicy.Spec.File.Message) > 0 {
-					secPolicy.Spec.File.MatchPaths[idx].Message = secPolicy.Spec.File.Message
-				} else {
-					secPolicy.Spec.File.MatchPaths[idx].Message = secPolicy.Spec.Message
-				}
-			}
-
-			if len(path.Action) == 0 {
-				if len(secPolicy.Spec.File.Action) > 0 {
-					secPolicy.Spec.File.MatchPaths[idx].Action = secPolicy.Spec.File.Action
-				} else {
-					secPolicy.Spec.File.MatchPaths[idx].Action = secPolicy.Spec.Action
-				}
-			}
-		}
-	} else if len(secPolicy.Spec.File.MatchDirectories) > 0 {
-		for idx, dir := range secPolicy.Spec.File.MatchDirectories {
-			if dir.Severity == 0 {
-				if secPolicy.Spec.File.Severity != 0 {
-					secPolicy.Spec.File.MatchDirectories[idx].Severity = secPolicy.Spec.File.Severity
-				} else {
-					secPolicy.Spec.File.MatchDirectories[idx].Severity = secPolicy.Spec.Severity
-				}
-			}
-
-			if len(dir.Tags) == 0 {
-				if len(secPolicy.Spec.File.Tags) > 0 {
-					secPolicy.Spec.File.MatchDirectories[idx].Tags = secPolicy.Spec.File.Tags
-				} else {
-					secPolicy.Spec.File.MatchDirectories[idx].Tags = secPolicy.Spec.Tags
-				}
-			}
-
-			if len(dir.Message) == 0 {
-				if len(secPolicy.Spec.File.Message) > 0 {
-					secPolicy.Spec.File.MatchDirectories[idx].Message = secPolicy.Spec.File.Message
-				} else {
-					secPolicy.Spec.File.MatchDirectories[idx].Message = secPolicy.Spec.Message
-				}
-			}
-
-			if len(dir.Action) == 0 {
-				if len(secPolicy.Spec.File.Action) > 0 {
-					secPolicy.Spec.File.MatchDirectories[idx].Action = secPolicy.Spec.File.Action
-				} else {
-					secPolicy.Spec.File.MatchDirectories[idx].Action = secPolicy.Spec.Action
-				}
-			}
-		}
-	} else if len(secPolicy.Spec.File.MatchPatterns) > 0 {
-		for idx, pat := range secPolicy.Spec.File.MatchPatterns {
-			if pat.Severity == 0 {
-				if secPolicy.Spec.File.Severity != 0 {
-					secPolicy.Spec.File.MatchPatterns[idx].Severity = secPolicy.Spec.File.Severity
-				} else {
-					secPolicy.Spec.File.MatchPatterns[idx].Severity = secPolicy.Spec.Severity
-				}
-			}
-
-			if len(pat.Tags) == 0 {
-				if len(secPolicy.Spec.File.Tags) > 0 {
-					secPolicy.Spec.File.MatchPatterns[idx].Tags = secPolicy.Spec.File.Tags
-				} else {
-					secPolicy.Spec.File.MatchPatterns[idx].Tags = secPolicy.Spec.Tags
-				}
-			}
-
-			if len(pat.Message) == 0 {
-				if len(secPolicy.Spec.File.Message) > 0 {
-					secPolicy.Spec.File.MatchPatterns[idx].Message = secPolicy.Spec.File.Message
-				} else {
-					secPolicy.Spec.File.MatchPatterns[idx].Message = secPolicy.Spec.Message
-				}
-			}
-
-			if len(pat.Action) == 0 {
-				if len(secPolicy.Spec.File.Action) > 0 {
-					secPolicy.Spec.File.MatchPatterns[idx].Action = secPolicy.Spec.File.Action
-				} else {
-					secPolicy.Spec.File.MatchPatterns[idx].Action = secPolicy.Spec.Action
-				}
-			}
-		}
-	}
-
-	if len(secPolicy.Spec.Network.MatchProtocols) > 0 {
-		for idx, proto := range secPolicy.Spec.Network.MatchProtocols {
-			if proto.Severity == 0 {
-				if secPolicy.Spec.Network.Severity != 0 {
-					secPolicy.Spec.Network.MatchProtocols[idx].Severity = secPolicy.Spec.Network.Severity
-				} else {
-					secPolicy.Spec.Network.MatchProtocols[idx].Severity = secPolicy.Spec.Severity
-				}
-			}
-
-			if len(proto.Tags) == 0 {
-				if len(secPolicy.Spec.Network.Tags) > 0 {
-					secPolicy.Spec.Network.MatchProtocols[idx].Tags = secPolicy.Spec.Network.Tags
-				} else {
-					secPolicy.Spec.Network.MatchProtocols[idx].Tags = secPolicy.Spec.Tags
-				}
-			}
-
-			if len(proto.Message) == 0 {
-				if len(secPolicy.Spec.Network.Message) > 0 {
-					secPolicy.Spec.Network.MatchProtocols[idx].Message = secPolicy.Spec.Network.Message
-				} else {
-					secPolicy.Spec.Network.MatchProtocols[idx].Message = secPolicy.Spec.Message
-				}
-			}
-
-			if len(proto.Action) == 0 {
-				if len(secPolicy.Spec.Network.Action) > 0 {
-					secPolicy.Spec.Network.MatchProtocols[idx].Action = secPolicy.Spec.Network.Action
-				} else {
-					secPolicy.Spec.Network.MatchProtocols[idx].Action = secPolicy.Spec.Action
-				}
-			}
-		}
-	}
-
-	if len(secPolicy.Spec.Capabilities.MatchCapabilities) > 0 {
-		for idx, cap := range secPolicy.Spec.Capabilities.MatchCapabilities {
-			if cap.Severity == 0 {
-				if secPolicy.Spec.Capabilities.Severity != 0 {
-					secPolicy.Spec.Capabilities.MatchCapabilities[idx].Severity = secPolicy.Spec.Capabilities.Severity
-				} else {
-					secPolicy.Spec.Capabilities.MatchCapabilities[idx].Severity = secPolicy.Spec.Severity
-				}
-			}
-
-			if len(cap.Tags) == 0 {
-				if len(secPolicy.Spec.Capabilities.Tags) > 0 {
-					secPolicy.Spec.Capabilities.MatchCapabilities[idx].Tags = secPolicy.Spec.Capabilities.Tags
-				} else {
-					secPolicy.Spec.Capabilities.MatchCapabilities[idx].Tags = secPolicy.Spec.Tags
-				}
-			}
-
-			if len(cap.Message) == 0 {
-				if len(secPolicy.Spec.Capabilities.Message) > 0 {
-					secPolicy.Spec.Capabilities.MatchCapabilities[idx].Message = secPolicy.Spec.Capabilities.Message
-				} else {
-					secPolicy.Spec.Capabilities.MatchCapabilities[idx].Message = secPolicy.Spec.Message
-				}
-			}
-
-			if len(cap.Action) == 0 {
-				if len(secPolicy.Spec.Capabilities.Action) > 0 {
-					secPolicy.Spec.Capabilities.MatchCapabilities[idx].Action = secPolicy.Spec.Capabilities.Action
-				} else {
-					secPolicy.Spec.Capabilities.MatchCapabilities[idx].Action = secPolicy.Spec.Action
-				}
-			}
-		}
-	}
-
 	// update a security policy into the policy list
 
 	dm.HostSecurityPoliciesLock.Lock()
@@ -35,6 +35,30 @@ type AppArmorEnforcer struct {
 	// profiles for containers
 	AppArmorProfiles     map[string][]string
 	AppArmorProfilesLock *sync.RWMutex
+
+	// fine-grained network rules (e.g., network bind inet port=8080)
+	NetworkRules bool
+}
+
+// supportsNetworkRules Function (AppArmor 4 parser and a kernel that mediates inet sockets)
+func supportsNetworkRules() bool {
+	if _, err := os.Stat("/sys/kernel/security/apparmor/features/network_v9/af_inet"); err != nil {
+		return false
+	}
+
+	out, err := kl.GetCommandOutputWithErr("apparmor_parser", []string{"--version"})
+	if err != nil {
+		return false
+	}
+
+	// e.g., AppArmor parser version 4.0.1
+	for _, word := range strings.Fields(out) {
+		if major, err := strconv.Atoi(strings.SplitN(word, ".", 2)[0]); err == nil && strings.Contains(word, ".") {
+			return major >= 4
+		}
+	}
+
+	return false
 }
 
 // NewAppArmorEnforcer Function
@@ -85,6 +109,12 @@ func NewAppArmorEnforcer(node tp.Node, logger *fd.Feeder) *AppArmorEnforcer {
 	// host profile
 	ae.HostProfile = ""
 
+	// listener rules are left to the BPF-LSM enforcer unless AppArmor can express them
+	ae.NetworkRules = supportsNetworkRules()
+	if !ae.NetworkRules {
+		ae.Logger.Print("AppArmor does not support network rules with addresses and ports, listener rules are only audited")
+	}
+
 	// profiles
 	ae.AppArmorProfiles = map[string][]string{}
 	ae.AppArmorProfilesLock = &sync.RWMutex{}
@@ -262,3 +262,53 @@ func TestHostAppArmorProfile(t *testing.T) {
 	}
 	t.Log("[PASS] Destroyed logger")
 }
+
+func TestAppArmorListenerRules(t *testing.T) {
+	ae := &AppArmorEnforcer{NetworkRules: true}
+
+	secPolicy := tp.SecurityPolicy{}
+	secPolicy.Spec.Network.MatchListeners = []tp.NetworkListenerType{
+		{Address: "127.0.0.1", Ports: []string{"8080"}, Action: "Block"},
+		{Ports: []string{"9000"}, Action: "Block"},
+		{Address: "10.0.0.0/8", Ports: []string{"22"}, Action: "Block"},
+		{Ports: []string{"8000-8080"}, Action: "Block"},
+		{Ports: []string{"443"}, Action: "Allow"},
+	}
+
+	defaultPosture := tp.DefaultPosture{FileAction: "audit", NetworkAction: "audit", CapabilitiesAction: "audit"}
+
+	_, body := ae.GenerateProfileBody([]tp.SecurityPolicy{secPolicy}, defaultPosture)
+
+	for _, line := range []string{
+		"  deny network bind inet ip=127.0.0.1 port=8080,\n",
+		"  deny network listen inet ip=127.0.0.1 port=8080,\n",
+		"  deny network bind inet port=9000,\n",
+		"  deny network bind inet6 port=9000,\n",
+		"  deny network listen inet6 port=9000,\n",
+	} {
+		if !strings.Contains(body, line) {
+			t.Errorf("[FAIL] Missing a listener rule (%s)", strings.TrimSpace(line))
+			return
+		}
+	}
+
+	for _, rule := range []string{"port=22", "port=8000", "port=443"} {
+		if strings.Contains(body, rule) {
+			t.Errorf("[FAIL] Translated a listener that AppArmor cannot express (%s)", rule)
+			return
+		}
+	}
+
+	t.Log("[PASS] Translated listener rules into AppArmor network rules")
+
+	// without AppArmor 4, the profile must stay loadable by older parsers
+	ae.NetworkRules = false
+
+	_, body = ae.GenerateProfileBody([]tp.SecurityPolicy{secPolicy}, defaultPosture)
+
+	if strings.Contains(body, "network bind") || strings.Contains(body, "network listen") {
+		t.Error("[FAIL] Translated listener rules without AppArmor 4")
+		return
+	}
+	t.Log("[PASS] Left listener rules to the BPF-LSM enforcer without AppArmor 4")
+}
@@ -491,6 +491,33 @@ func (ae *AppArmorEnforcer) BlockedHostNetworkMatchProtocols(proto tp.NetworkPro
 	}
 }
 
+// BlockedHostNetworkMatchListeners Function
+func (ae *AppArmorEnforcer) BlockedHostNetworkMatchListeners(listener tp.NetworkListenerType, fromSources map[string][]string) {
+	if !ae.NetworkRules || len(listener.FromSource) == 0 {
+		return
+	}
+
+	rules := getListenerRules(listener)
+
+	for _, src := range listener.FromSource {
+		if len(src.Path) == 0 {
+			continue
+		}
+
+		source := src.Path
+		if _, ok := fromSources[source]; !ok {
+			fromSources[source] = []string{}
+		}
+
+		for _, rule := range rules {
+			line := fmt.Sprintf("  deny %s,\n", rule)
+			if !kl.ContainsElement(fromSources[source], line) {
+				fromSources[source] = append(fromSources[source], line)
+			}
+		}
+	}
+}
+
 // BlockedHostCapabilitiesMatchCapabilities Function
 func (ae *AppArmorEnforcer) BlockedHostCapabilitiesMatchCapabilities(cap tp.CapabilitiesCapabilityType, fromSources map[string][]string) {
 	if len(cap.FromSource) == 0 {
@@ -632,6 +659,13 @@ func (ae *AppArmorEnforcer) GenerateHostProfileBody(securityPolicies []tp.HostSe
 				}
 			}
 		}
+		if len(secPolicy.Spec.Network.MatchListeners) > 0 {
+			for _, listener := range secPolicy.Spec.Network.MatchListeners {
+				if listener.Action == "Block" {
+					ae.BlockedHostNetworkMatchListeners(listener, fromSources)
+				}
+			}
+		}
 
 		if len(secPolicy.Spec.Capabilities.MatchCapabilities) > 0 {
 			for _, cap := range secPolicy.Spec.Capabilities.MatchCapabilities {
@@ -17,6 +17,59 @@ import (
 
 // == //
 
+// getListenerRules Function (network bind and listen rules for a listener, none if AppArmor cannot express its address or ports)
+func getListenerRules(listener tp.NetworkListenerType) []string {
+	domains := []string{"inet", "inet6"}
+	address := ""
+
+	if len(listener.Address) > 0 {
+		ipNet, err := kl.ParseCIDR(listener.Address)
+		if err != nil {
+			return nil
+		}
+
+		if ipNet.IP.To4() != nil {
+			domains = []string{"inet"}
+		} else {
+			domains = []string{"inet6"}
+		}
+
+		// AppArmor matches a single local address, so only a host address or any address can be translated
+		prefix, bits := ipNet.Mask.Size()
+		if prefix == bits {
+			address = " ip=" + ipNet.IP.String()
+		} else if prefix != 0 {
+			return nil
+		}
+	}
+
+	ports := []string{""}
+
+	if len(listener.Ports) > 0 {
+		ports = []string{}
+
+		for _, port := range listener.Ports {
+			portRange, err := kl.ParsePortRange(port)
+			if err != nil || portRange.Min != portRange.Max {
+				return nil // AppArmor has no port ranges
+			}
+			ports = append(ports, fmt.Sprintf(" port=%d", portRange.Min))
+		}
+	}
+
+	rules := []string{}
+
+	for _, perm := range []string{"bind", "listen"} {
+		for _, domain := range domains {
+			for _, port := range ports {
+				rules = append(rules, fmt.Sprintf("network %s %s%s%s", perm, domain, address, port))
+			}
+		}
+	}
+
+	return rules
+}
+
 // ResolvedProcessWhiteListConflicts Function
 func (ae *AppArmorEnforcer) ResolvedProcessWhiteListConflicts(processWhiteList *[]string, fromSources map[string][]string, fusionProcessWhiteList *[]string) {
 	prunedProcessWhiteList := make([]string, len(*processWhiteList))
@@ -641,6 +694,43 @@ func (ae *AppArmorEnforcer) BlockedNetworkMatchProtocols(proto tp.NetworkProtoco
 	}
 }
 
+// BlockedNetworkMatchListeners Function (allowed listeners are left to the BPF enforcer, as a network white list would also deny every other socket)
+func (ae *AppArmorEnforcer) BlockedNetworkMatchListeners(listener tp.NetworkListenerType, networkBlackList *[]string, fromSources map[string][]string) {
+	if !ae.NetworkRules {
+		return // older parsers reject the whole profile
+	}
+
+	rules := getListenerRules(listener)
+
+	if len(listener.FromSource) == 0 {
+		for _, rule := range rules {
+			line := fmt.Sprintf("  deny %s,\n", rule)
+			if !kl.ContainsElement(*networkBlackList, line) {
+				*networkBlackList = append(*networkBlackList, line)
+			}
+		}
+		return
+	}
+
+	for _, src := range listener.FromSource {
+		if len(src.Path) == 0 {
+			continue
+		}
+
+		source := src.Path
+		if _, ok := fromSources[source]; !ok {
+			fromSources[source] = []string{}
+		}
+
+		for _, rule := range rules {
+			line := fmt.Sprintf("  deny %s,\n", rule)
+			if !kl.ContainsElement(fromSources[source], line) {
+				fromSources[source] = append(fromSources[source], line)
+			}
+		}
+	}
+}
+
 // BlockedCapabilitiesMatchCapabilities Function
 func (ae *AppArmorEnforcer) BlockedCapabilitiesMatchCapabilities(cap tp.CapabilitiesCapabilityType, capabilityBlackList *[]string, fromSources map[string][]string) {
 	if len(cap.FromSource) == 0 {
@@ -829,6 +919,13 @@ func (ae *AppArmorEnforcer) GenerateProfileBody(securityPolicies []tp.SecurityPo
 				}
 			}
 		}
+		if len(secPolicy.Spec.Network.MatchListeners) > 0 {
+			for _, listener := range secPolicy.Spec.Network.MatchListeners {
+				if listener.Action == "Block" {
+					ae.BlockedNetworkMatchListeners(listener, &networkBlackList, fromSources)
+				}
+			}
+		}
 
 		if len(secPolicy.Spec.Capabilities.MatchCapabilities) > 0 {
 			for _, cap := range secPolicy.Spec.Capabilities.MatchCapabilities {
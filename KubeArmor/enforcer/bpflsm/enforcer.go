@@ -5,6 +5,7 @@ package bpflsm
 
 import (
 	"errors"
+	"os"
 	"sync"
 
 	"github.com/cilium/ebpf"
@@ -79,7 +80,21 @@ func NewBPFEnforcer(node tp.Node, logger *fd.Feeder) (*BPFEnforcer, error) {
 		return be, err
 	}
 
-	if err := loadEnforcerObjects(&be.obj, &ebpf.CollectionOptions{
+	spec, err := loadEnforcer()
+	if err != nil {
+		be.Logger.Errf("error loading BPF LSM objects: %v", err)
+		return be, err
+	}
+
+	// KubeArmor is never restricted by syscall rules (e.g., bpf in host policies)
+	if err := spec.RewriteConstants(map[string]interface{}{
+		"kubearmor_pid": uint32(os.Getpid()),
+	}); err != nil {
+		be.Logger.Errf("error setting BPF LSM constants: %v", err)
+		return be, err
+	}
+
+	if err := spec.LoadAndAssign(&be.obj, &ebpf.CollectionOptions{
 		Maps: ebpf.MapOptions{
 			PinPath: "/sys/fs/bpf",
 		},
@@ -106,6 +121,54 @@ func NewBPFEnforcer(node tp.Node, logger *fd.Feeder) (*BPFEnforcer, error) {
 		return be, err
 	}
 
+	be.Probes[be.obj.EnforceBind.String()], err = link.AttachLSM(link.LSMOptions{Program: be.obj.EnforceBind})
+	if err != nil {
+		be.Logger.Errf("opening kprobe %s: %s", be.obj.EnforceBind.String(), err)
+		return be, err
+	}
+
+	be.Probes[be.obj.EnforceListen.String()], err = link.AttachLSM(link.LSMOptions{Program: be.obj.EnforceListen})
+	if err != nil {
+		be.Logger.Errf("opening kprobe %s: %s", be.obj.EnforceListen.String(), err)
+		return be, err
+	}
+
+	be.Probes[be.obj.EnforcePtrace.String()], err = link.AttachLSM(link.LSMOptions{Program: be.obj.EnforcePtrace})
+	if err != nil {
+		be.Logger.Errf("opening kprobe %s: %s", be.obj.EnforcePtrace.String(), err)
+		return be, err
+	}
+
+	be.Probes[be.obj.EnforceTraceme.String()], err = link.AttachLSM(link.LSMOptions{Program: be.obj.EnforceTraceme})
+	if err != nil {
+		be.Logger.Errf("opening kprobe %s: %s", be.obj.EnforceTraceme.String(), err)
+		return be, err
+	}
+
+	be.Probes[be.obj.EnforceMount.String()], err = link.AttachLSM(link.LSMOptions{Program: be.obj.EnforceMount})
+	if err != nil {
+		be.Logger.Errf("opening kprobe %s: %s", be.obj.EnforceMount.String(), err)
+		return be, err
+	}
+
+	be.Probes[be.obj.EnforceBpf.String()], err = link.AttachLSM(link.LSMOptions{Program: be.obj.EnforceBpf})
+	if err != nil {
+		be.Logger.Errf("opening kprobe %s: %s", be.obj.EnforceBpf.String(), err)
+		return be, err
+	}
+
+	be.Probes[be.obj.EnforceLoadData.String()], err = link.AttachLSM(link.LSMOptions{Program: be.obj.EnforceLoadData})
+	if err != nil {
+		be.Logger.Errf("opening kprobe %s: %s", be.obj.EnforceLoadData.String(), err)
+		return be, err
+	}
+
+	be.Probes[be.obj.EnforceReadFile.String()], err = link.AttachLSM(link.LSMOptions{Program: be.obj.EnforceReadFile})
+	if err != nil {
+		be.Logger.Errf("opening kprobe %s: %s", be.obj.EnforceReadFile.String(), err)
+		return be, err
+	}
+
 	if cfg.GlobalCfg.HostPolicy {
 		be.AddHostToMap()
 	}
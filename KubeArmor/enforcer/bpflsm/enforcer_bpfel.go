@@ -35,9 +35,9 @@ func loadEnforcer() (*ebpf.CollectionSpec, error) {
 //
 // The following types are suitable as obj argument:
 //
-//     *enforcerObjects
-//     *enforcerPrograms
-//     *enforcerMaps
+//	*enforcerObjects
+//	*enforcerPrograms
+//	*enforcerMaps
 //
 // See ebpf.CollectionSpec.LoadAndAssign documentation for details.
 func loadEnforcerObjects(obj interface{}, opts *ebpf.CollectionOptions) error {
@@ -61,9 +61,17 @@ type enforcerSpecs struct {
 //
 // It can be passed ebpf.CollectionSpec.Assign.
 type enforcerProgramSpecs struct {
-	EnforceFile *ebpf.ProgramSpec `ebpf:"enforce_file"`
-	EnforceNet  *ebpf.ProgramSpec `ebpf:"enforce_net"`
-	EnforceProc *ebpf.ProgramSpec `ebpf:"enforce_proc"`
+	EnforceBind     *ebpf.ProgramSpec `ebpf:"enforce_bind"`
+	EnforceBpf      *ebpf.ProgramSpec `ebpf:"enforce_bpf"`
+	EnforceFile     *ebpf.ProgramSpec `ebpf:"enforce_file"`
+	EnforceListen   *ebpf.ProgramSpec `ebpf:"enforce_listen"`
+	EnforceLoadData *ebpf.ProgramSpec `ebpf:"enforce_load_data"`
+	EnforceMount    *ebpf.ProgramSpec `ebpf:"enforce_mount"`
+	EnforceNet      *ebpf.ProgramSpec `ebpf:"enforce_net"`
+	EnforceProc     *ebpf.ProgramSpec `ebpf:"enforce_proc"`
+	EnforcePtrace   *ebpf.ProgramSpec `ebpf:"enforce_ptrace"`
+	EnforceReadFile *ebpf.ProgramSpec `ebpf:"enforce_read_file"`
+	EnforceTraceme  *ebpf.ProgramSpec `ebpf:"enforce_traceme"`
 }
 
 // enforcerMapSpecs contains maps before they are loaded into the kernel.
@@ -114,16 +122,32 @@ func (m *enforcerMaps) Close() error {
 //
 // It can be passed to loadEnforcerObjects or ebpf.CollectionSpec.LoadAndAssign.
 type enforcerPrograms struct {
-	EnforceFile *ebpf.Program `ebpf:"enforce_file"`
-	EnforceNet  *ebpf.Program `ebpf:"enforce_net"`
-	EnforceProc *ebpf.Program `ebpf:"enforce_proc"`
+	EnforceBind     *ebpf.Program `ebpf:"enforce_bind"`
+	EnforceBpf      *ebpf.Program `ebpf:"enforce_bpf"`
+	EnforceFile     *ebpf.Program `ebpf:"enforce_file"`
+	EnforceListen   *ebpf.Program `ebpf:"enforce_listen"`
+	EnforceLoadData *ebpf.Program `ebpf:"enforce_load_data"`
+	EnforceMount    *ebpf.Program `ebpf:"enforce_mount"`
+	EnforceNet      *ebpf.Program `ebpf:"enforce_net"`
+	EnforceProc     *ebpf.Program `ebpf:"enforce_proc"`
+	EnforcePtrace   *ebpf.Program `ebpf:"enforce_ptrace"`
+	EnforceReadFile *ebpf.Program `ebpf:"enforce_read_file"`
+	EnforceTraceme  *ebpf.Program `ebpf:"enforce_traceme"`
 }
 
 func (p *enforcerPrograms) Close() error {
 	return _EnforcerClose(
+		p.EnforceBind,
+		p.EnforceBpf,
 		p.EnforceFile,
+		p.EnforceListen,
+		p.EnforceLoadData,
+		p.EnforceMount,
 		p.EnforceNet,
 		p.EnforceProc,
+		p.EnforcePtrace,
+		p.EnforceReadFile,
+		p.EnforceTraceme,
 	)
 }
 
@@ -137,5 +161,6 @@ func _EnforcerClose(closers ...io.Closer) error {
 }
 
 // Do not access this directly.
+//
 //go:embed enforcer_bpfel.o
 var _EnforcerBytes []byte
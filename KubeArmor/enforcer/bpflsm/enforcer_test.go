@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2022 Authors of KubeArmor
+
+package bpflsm
+
+import (
+	"testing"
+
+	"github.com/cilium/ebpf"
+)
+
+func TestEnforcerObjects(t *testing.T) {
+	// load the embedded object (enforcer_bpfel.o or enforcer_bpfeb.o)
+	spec, err := loadEnforcer()
+	if err != nil {
+		t.Errorf("[FAIL] Failed to load the BPF LSM objects (%s)", err.Error())
+		return
+	}
+	t.Log("[PASS] Loaded the BPF LSM objects")
+
+	// every program and map of the bindings must be in the object
+	if err := spec.Assign(&enforcerSpecs{}); err != nil {
+		t.Errorf("[FAIL] The BPF LSM objects do not match the bindings, regenerate them with go generate (%s)", err.Error())
+		return
+	}
+	t.Log("[PASS] Matched the BPF LSM objects with the bindings")
+
+	// the constants set by NewBPFEnforcer must be in the object
+	if err := spec.RewriteConstants(map[string]interface{}{
+		"kubearmor_pid": uint32(1),
+	}); err != nil {
+		t.Errorf("[FAIL] Failed to set BPF LSM constants, regenerate the objects with go generate (%s)", err.Error())
+		return
+	}
+	t.Log("[PASS] Set BPF LSM constants")
+
+	// the programs must be attached to the LSM hooks that their rules are enforced in
+	hooks := map[string]string{
+		"enforce_proc":      "bprm_check_security",
+		"enforce_file":      "file_open",
+		"enforce_net":       "socket_connect",
+		"enforce_bind":      "socket_bind",
+		"enforce_listen":    "socket_listen",
+		"enforce_ptrace":    "ptrace_access_check",
+		"enforce_traceme":   "ptrace_traceme",
+		"enforce_mount":     "sb_mount",
+		"enforce_bpf":       "bpf",
+		"enforce_load_data": "kernel_load_data",
+		"enforce_read_file": "kernel_read_file",
+	}
+
+	for name, hook := range hooks {
+		prog, ok := spec.Programs[name]
+		if !ok {
+			t.Errorf("[FAIL] Failed to find %s in the BPF LSM objects", name)
+			return
+		}
+
+		if prog.Type != ebpf.LSM || prog.AttachTo != hook {
+			t.Errorf("[FAIL] %s is attached to %s, not to lsm/%s", name, prog.SectionName, hook)
+			return
+		}
+	}
+	t.Log("[PASS] Matched the BPF LSM programs with their hooks")
+
+	// the container map is pinned by NewBPFEnforcer, so the object has to declare the same map
+	containers, ok := spec.Maps["kubearmor_containers"]
+	if !ok {
+		t.Error("[FAIL] Failed to find kubearmor_containers in the BPF LSM objects")
+		return
+	}
+
+	if containers.Type != ebpf.HashOfMaps || containers.KeySize != 8 || containers.ValueSize != 4 || containers.MaxEntries != 256 {
+		t.Errorf("[FAIL] kubearmor_containers does not match the pinned map (%s)", containers.String())
+		return
+	}
+	t.Log("[PASS] Matched kubearmor_containers with the pinned map")
+}
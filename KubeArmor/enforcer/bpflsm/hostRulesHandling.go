@@ -178,6 +178,82 @@ func (be *BPFEnforcer) UpdateHostRules(securityPolicies []tp.HostSecurityPolicy)
 				}
 			}
 		}
+
+		for _, endpoint := range secPolicy.Spec.Network.MatchEndpoints {
+			// incoming connections are not seen by socket_connect, so they are only audited
+			if endpoint.Direction != "connect" {
+				continue
+			}
+
+			if len(endpoint.FromSource) == 0 {
+				if endpoint.Action == "Allow" && cfg.GlobalCfg.HostDefaultNetworkPosture == "block" {
+					newrules.NetWhiteListPosture = true
+					if err := endpointToMap(endpoint, "", newrules.NetworkWhiteList); err != nil {
+						be.Logger.Errf("error adding rule to map for container %s: %s", id, err)
+					}
+				} else if endpoint.Action == "Block" && !newrules.NetWhiteListPosture {
+					if err := endpointToMap(endpoint, "", newrules.NetworkBlackList); err != nil {
+						be.Logger.Errf("error adding rule to map for container %s: %s", id, err)
+					}
+				}
+			} else {
+				for _, src := range endpoint.FromSource {
+					if endpoint.Action == "Allow" && cfg.GlobalCfg.HostDefaultNetworkPosture == "block" {
+						newrules.NetWhiteListPosture = true
+						if err := endpointToMap(endpoint, src.Path, newrules.NetworkWhiteList); err != nil {
+							be.Logger.Errf("error adding rule to map for container %s: %s", id, err)
+						}
+					} else if endpoint.Action == "Block" && !newrules.NetWhiteListPosture {
+						if err := endpointToMap(endpoint, src.Path, newrules.NetworkBlackList); err != nil {
+							be.Logger.Errf("error adding rule to map for container %s: %s", id, err)
+						}
+					}
+				}
+			}
+		}
+
+		for _, listener := range secPolicy.Spec.Network.MatchListeners {
+			if len(listener.FromSource) == 0 {
+				if listener.Action == "Allow" && cfg.GlobalCfg.HostDefaultNetworkPosture == "block" {
+					newrules.ListenWhiteListPosture = true
+					if err := listenerToMap(listener, "", newrules.ListenWhiteList); err != nil {
+						be.Logger.Errf("error adding rule to map for container %s: %s", id, err)
+					}
+				} else if listener.Action == "Block" && !newrules.ListenWhiteListPosture {
+					if err := listenerToMap(listener, "", newrules.ListenBlackList); err != nil {
+						be.Logger.Errf("error adding rule to map for container %s: %s", id, err)
+					}
+				}
+			} else {
+				for _, src := range listener.FromSource {
+					if listener.Action == "Allow" && cfg.GlobalCfg.HostDefaultNetworkPosture == "block" {
+						newrules.ListenWhiteListPosture = true
+						if err := listenerToMap(listener, src.Path, newrules.ListenWhiteList); err != nil {
+							be.Logger.Errf("error adding rule to map for container %s: %s", id, err)
+						}
+					} else if listener.Action == "Block" && !newrules.ListenWhiteListPosture {
+						if err := listenerToMap(listener, src.Path, newrules.ListenBlackList); err != nil {
+							be.Logger.Errf("error adding rule to map for container %s: %s", id, err)
+						}
+					}
+				}
+			}
+		}
+
+		for _, sc := range secPolicy.Spec.Syscalls.MatchSyscalls {
+			// syscalls are only blocked, the others are audited
+			if sc.Action != "Block" {
+				continue
+			}
+
+			if len(sc.FromSource) == 0 {
+				syscallToMap(sc.Syscall, "", newrules.SyscallBlackList)
+			} else {
+				for _, src := range sc.FromSource {
+					syscallToMap(sc.Syscall, src.Path, newrules.SyscallBlackList)
+				}
+			}
+		}
 	}
 
 	be.ContainerMapLock.Lock()
@@ -194,12 +270,15 @@ func (be *BPFEnforcer) UpdateHostRules(securityPolicies []tp.HostSecurityPolicy)
 	be.resolveConflicts(newrules.ProcWhiteListPosture, be.ContainerMap[id].Rules.ProcWhiteListPosture, newrules.ProcessBlackList, be.ContainerMap[id].Rules.ProcessBlackList, newrules.ProcessWhiteList, be.ContainerMap[id].Rules.ProcessWhiteList, be.ContainerMap[id].Map)
 	be.resolveConflicts(newrules.FileWhiteListPosture, be.ContainerMap[id].Rules.FileWhiteListPosture, newrules.FileBlackList, be.ContainerMap[id].Rules.FileBlackList, newrules.FileWhiteList, be.ContainerMap[id].Rules.FileWhiteList, be.ContainerMap[id].Map)
 	be.resolveConflicts(newrules.NetWhiteListPosture, be.ContainerMap[id].Rules.NetWhiteListPosture, newrules.NetworkBlackList, be.ContainerMap[id].Rules.NetworkBlackList, newrules.NetworkWhiteList, be.ContainerMap[id].Rules.NetworkWhiteList, be.ContainerMap[id].Map)
+	be.resolveConflicts(newrules.ListenWhiteListPosture, be.ContainerMap[id].Rules.ListenWhiteListPosture, newrules.ListenBlackList, be.ContainerMap[id].Rules.ListenBlackList, newrules.ListenWhiteList, be.ContainerMap[id].Rules.ListenWhiteList, be.ContainerMap[id].Map)
+	be.resolveConflicts(false, false, newrules.SyscallBlackList, be.ContainerMap[id].Rules.SyscallBlackList, nil, nil, be.ContainerMap[id].Map)
 
 	// Update Posture
 	if list, ok := be.ContainerMap[id]; ok {
 		list.Rules.ProcWhiteListPosture = newrules.ProcWhiteListPosture
 		list.Rules.FileWhiteListPosture = newrules.FileWhiteListPosture
 		list.Rules.NetWhiteListPosture = newrules.NetWhiteListPosture
+		list.Rules.ListenWhiteListPosture = newrules.ListenWhiteListPosture
 
 		be.ContainerMap[id] = list
 	}
@@ -275,4 +354,35 @@ func (be *BPFEnforcer) UpdateHostRules(securityPolicies []tp.HostSecurityPolicy)
 			}
 		}
 	}
+
+	if newrules.ListenWhiteListPosture {
+		if err := be.ContainerMap[id].Map.Put(LISTENWHITELIST, [8]byte{}); err != nil {
+			be.Logger.Errf("error adding rule to map for container %s: %s", id, err)
+		}
+		for key, val := range newrules.ListenWhiteList {
+			be.ContainerMap[id].Rules.ListenWhiteList[key] = val
+			if err := be.ContainerMap[id].Map.Put(key, val); err != nil {
+				be.Logger.Errf("error adding rule to map for container %s: %s", id, err)
+			}
+		}
+	} else {
+		if err := be.ContainerMap[id].Map.Delete(LISTENWHITELIST); err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				be.Logger.Err(err.Error())
+			}
+		}
+		for key, val := range newrules.ListenBlackList {
+			be.ContainerMap[id].Rules.ListenBlackList[key] = val
+			if err := be.ContainerMap[id].Map.Put(key, val); err != nil {
+				be.Logger.Errf("error adding rule to map for container %s: %s", id, err)
+			}
+		}
+	}
+
+	for key, val := range newrules.SyscallBlackList {
+		be.ContainerMap[id].Rules.SyscallBlackList[key] = val
+		if err := be.ContainerMap[id].Map.Put(key, val); err != nil {
+			be.Logger.Errf("error adding rule to map for container %s: %s", id, err)
+		}
+	}
 }
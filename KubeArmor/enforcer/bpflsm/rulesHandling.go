@@ -4,11 +4,14 @@
 package bpflsm
 
 import (
+	"encoding/binary"
 	"errors"
+	"fmt"
 	"os"
 	"strings"
 
 	"github.com/cilium/ebpf"
+	kl "github.com/kubearmor/KubeArmor/KubeArmor/common"
 	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
 )
 
@@ -25,9 +28,10 @@ const (
 
 // Map Key Identifiers for Whitelist/Posture
 var (
-	PROCWHITELIST = InnerKey{Path: [256]byte{101}}
-	FILEWHITELIST = InnerKey{Path: [256]byte{102}}
-	NETWHITELIST  = InnerKey{Path: [256]byte{103}}
+	PROCWHITELIST   = InnerKey{Path: [256]byte{101}}
+	FILEWHITELIST   = InnerKey{Path: [256]byte{102}}
+	NETWHITELIST    = InnerKey{Path: [256]byte{103}}
+	LISTENWHITELIST = InnerKey{Path: [256]byte{104}}
 )
 
 // Protocol Identifiers for Network Rules
@@ -48,19 +52,53 @@ const (
 	FAMILY   uint8 = 1
 	TYPE     uint8 = 2
 	PROTOCOL uint8 = 3
+	ENDPOINT uint8 = 4
+	PREFIXES uint8 = 5
+
+	LISTENER         uint8 = 6
+	LISTENERPREFIXES uint8 = 7
+
+	SYSCALL uint8 = 8
+)
+
+// x86_64 System Call Numbers for Syscall Rules (unshare and setns have no LSM hooks, so they are only audited)
+var syscalls = map[string]uint16{
+	"ptrace":          101,
+	"mount":           165,
+	"init_module":     175,
+	"kexec_load":      246,
+	"finit_module":    313,
+	"kexec_file_load": 320,
+	"bpf":             321,
+}
+
+// Address Family Identifiers for Endpoint and Listener Rules
+const (
+	AFINET  uint8 = 2
+	AFINET6 uint8 = 10
+)
+
+// Limits of Endpoint and Listener Rules (bounded loops in the socket_connect, socket_bind and socket_listen hooks)
+const (
+	MaxEndpointPrefixes = 8 // distinct prefix lengths per address family
+	MaxEndpointPorts    = 4 // port ranges per address and source
 )
 
 // RuleList Structure contains all the data required to set rules for a particular container
 type RuleList struct {
-	ProcessWhiteList     map[InnerKey][8]byte
-	ProcessBlackList     map[InnerKey][8]byte
-	FileWhiteList        map[InnerKey][8]byte
-	FileBlackList        map[InnerKey][8]byte
-	NetworkWhiteList     map[InnerKey][8]byte
-	NetworkBlackList     map[InnerKey][8]byte
-	ProcWhiteListPosture bool
-	FileWhiteListPosture bool
-	NetWhiteListPosture  bool
+	ProcessWhiteList       map[InnerKey][8]byte
+	ProcessBlackList       map[InnerKey][8]byte
+	FileWhiteList          map[InnerKey][8]byte
+	FileBlackList          map[InnerKey][8]byte
+	NetworkWhiteList       map[InnerKey][8]byte
+	NetworkBlackList       map[InnerKey][8]byte
+	ListenWhiteList        map[InnerKey][8]byte
+	ListenBlackList        map[InnerKey][8]byte
+	SyscallBlackList       map[InnerKey][8]byte
+	ProcWhiteListPosture   bool
+	FileWhiteListPosture   bool
+	NetWhiteListPosture    bool
+	ListenWhiteListPosture bool
 }
 
 // Init prepares the RuleList object
@@ -76,6 +114,12 @@ func (r *RuleList) Init() {
 	r.NetworkBlackList = make(map[InnerKey][8]byte)
 	r.NetworkWhiteList = make(map[InnerKey][8]byte)
 	r.NetWhiteListPosture = false
+
+	r.ListenBlackList = make(map[InnerKey][8]byte)
+	r.ListenWhiteList = make(map[InnerKey][8]byte)
+	r.ListenWhiteListPosture = false
+
+	r.SyscallBlackList = make(map[InnerKey][8]byte)
 }
 
 // UpdateContainerRules updates individual container map with new rules and resolves conflicting rules
@@ -243,6 +287,82 @@ func (be *BPFEnforcer) UpdateContainerRules(id string, securityPolicies []tp.Sec
 				}
 			}
 		}
+
+		for _, endpoint := range secPolicy.Spec.Network.MatchEndpoints {
+			// incoming connections are not seen by socket_connect, so they are only audited
+			if endpoint.Direction != "connect" {
+				continue
+			}
+
+			if len(endpoint.FromSource) == 0 {
+				if endpoint.Action == "Allow" && defaultPosture.NetworkAction == "block" {
+					newrules.NetWhiteListPosture = true
+					if err := endpointToMap(endpoint, "", newrules.NetworkWhiteList); err != nil {
+						be.Logger.Errf("error adding rule to map for container %s: %s", id, err)
+					}
+				} else if endpoint.Action == "Block" && !newrules.NetWhiteListPosture {
+					if err := endpointToMap(endpoint, "", newrules.NetworkBlackList); err != nil {
+						be.Logger.Errf("error adding rule to map for container %s: %s", id, err)
+					}
+				}
+			} else {
+				for _, src := range endpoint.FromSource {
+					if endpoint.Action == "Allow" && defaultPosture.NetworkAction == "block" {
+						newrules.NetWhiteListPosture = true
+						if err := endpointToMap(endpoint, src.Path, newrules.NetworkWhiteList); err != nil {
+							be.Logger.Errf("error adding rule to map for container %s: %s", id, err)
+						}
+					} else if endpoint.Action == "Block" && !newrules.NetWhiteListPosture {
+						if err := endpointToMap(endpoint, src.Path, newrules.NetworkBlackList); err != nil {
+							be.Logger.Errf("error adding rule to map for container %s: %s", id, err)
+						}
+					}
+				}
+			}
+		}
+
+		for _, listener := range secPolicy.Spec.Network.MatchListeners {
+			if len(listener.FromSource) == 0 {
+				if listener.Action == "Allow" && defaultPosture.NetworkAction == "block" {
+					newrules.ListenWhiteListPosture = true
+					if err := listenerToMap(listener, "", newrules.ListenWhiteList); err != nil {
+						be.Logger.Errf("error adding rule to map for container %s: %s", id, err)
+					}
+				} else if listener.Action == "Block" && !newrules.ListenWhiteListPosture {
+					if err := listenerToMap(listener, "", newrules.ListenBlackList); err != nil {
+						be.Logger.Errf("error adding rule to map for container %s: %s", id, err)
+					}
+				}
+			} else {
+				for _, src := range listener.FromSource {
+					if listener.Action == "Allow" && defaultPosture.NetworkAction == "block" {
+						newrules.ListenWhiteListPosture = true
+						if err := listenerToMap(listener, src.Path, newrules.ListenWhiteList); err != nil {
+							be.Logger.Errf("error adding rule to map for container %s: %s", id, err)
+						}
+					} else if listener.Action == "Block" && !newrules.ListenWhiteListPosture {
+						if err := listenerToMap(listener, src.Path, newrules.ListenBlackList); err != nil {
+							be.Logger.Errf("error adding rule to map for container %s: %s", id, err)
+						}
+					}
+				}
+			}
+		}
+
+		for _, sc := range secPolicy.Spec.Syscalls.MatchSyscalls {
+			// syscalls are only blocked, the others are audited
+			if sc.Action != "Block" {
+				continue
+			}
+
+			if len(sc.FromSource) == 0 {
+				syscallToMap(sc.Syscall, "", newrules.SyscallBlackList)
+			} else {
+				for _, src := range sc.FromSource {
+					syscallToMap(sc.Syscall, src.Path, newrules.SyscallBlackList)
+				}
+			}
+		}
 	}
 
 	be.ContainerMapLock.Lock()
@@ -259,12 +379,15 @@ func (be *BPFEnforcer) UpdateContainerRules(id string, securityPolicies []tp.Sec
 	be.resolveConflicts(newrules.ProcWhiteListPosture, be.ContainerMap[id].Rules.ProcWhiteListPosture, newrules.ProcessBlackList, be.ContainerMap[id].Rules.ProcessBlackList, newrules.ProcessWhiteList, be.ContainerMap[id].Rules.ProcessWhiteList, be.ContainerMap[id].Map)
 	be.resolveConflicts(newrules.FileWhiteListPosture, be.ContainerMap[id].Rules.FileWhiteListPosture, newrules.FileBlackList, be.ContainerMap[id].Rules.FileBlackList, newrules.FileWhiteList, be.ContainerMap[id].Rules.FileWhiteList, be.ContainerMap[id].Map)
 	be.resolveConflicts(newrules.NetWhiteListPosture, be.ContainerMap[id].Rules.NetWhiteListPosture, newrules.NetworkBlackList, be.ContainerMap[id].Rules.NetworkBlackList, newrules.NetworkWhiteList, be.ContainerMap[id].Rules.NetworkWhiteList, be.ContainerMap[id].Map)
+	be.resolveConflicts(newrules.ListenWhiteListPosture, be.ContainerMap[id].Rules.ListenWhiteListPosture, newrules.ListenBlackList, be.ContainerMap[id].Rules.ListenBlackList, newrules.ListenWhiteList, be.ContainerMap[id].Rules.ListenWhiteList, be.ContainerMap[id].Map)
+	be.resolveConflicts(false, false, newrules.SyscallBlackList, be.ContainerMap[id].Rules.SyscallBlackList, nil, nil, be.ContainerMap[id].Map)
 
 	// Update Posture
 	if list, ok := be.ContainerMap[id]; ok {
 		list.Rules.ProcWhiteListPosture = newrules.ProcWhiteListPosture
 		list.Rules.FileWhiteListPosture = newrules.FileWhiteListPosture
 		list.Rules.NetWhiteListPosture = newrules.NetWhiteListPosture
+		list.Rules.ListenWhiteListPosture = newrules.ListenWhiteListPosture
 
 		be.ContainerMap[id] = list
 	}
@@ -340,6 +463,37 @@ func (be *BPFEnforcer) UpdateContainerRules(id string, securityPolicies []tp.Sec
 			}
 		}
 	}
+
+	if newrules.ListenWhiteListPosture {
+		if err := be.ContainerMap[id].Map.Put(LISTENWHITELIST, [8]byte{}); err != nil {
+			be.Logger.Errf("error adding rule to map for container %s: %s", id, err)
+		}
+		for key, val := range newrules.ListenWhiteList {
+			be.ContainerMap[id].Rules.ListenWhiteList[key] = val
+			if err := be.ContainerMap[id].Map.Put(key, val); err != nil {
+				be.Logger.Errf("error adding rule to map for container %s: %s", id, err)
+			}
+		}
+	} else {
+		if err := be.ContainerMap[id].Map.Delete(LISTENWHITELIST); err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				be.Logger.Err(err.Error())
+			}
+		}
+		for key, val := range newrules.ListenBlackList {
+			be.ContainerMap[id].Rules.ListenBlackList[key] = val
+			if err := be.ContainerMap[id].Map.Put(key, val); err != nil {
+				be.Logger.Errf("error adding rule to map for container %s: %s", id, err)
+			}
+		}
+	}
+
+	for key, val := range newrules.SyscallBlackList {
+		be.ContainerMap[id].Rules.SyscallBlackList[key] = val
+		if err := be.ContainerMap[id].Map.Put(key, val); err != nil {
+			be.Logger.Errf("error adding rule to map for container %s: %s", id, err)
+		}
+	}
 }
 
 func (be *BPFEnforcer) resolveConflicts(newPosture, oldPosture bool, newBlackList, oldBlackList, newWhiteList, oldWhiteList map[InnerKey][8]byte, cmap *ebpf.Map) {
@@ -396,6 +550,22 @@ func (be *BPFEnforcer) resolveConflicts(newPosture, oldPosture bool, newBlackLis
 	}
 }
 
+// syscallToMap adds a syscall to the Container Rule Map, skipping the syscalls that cannot be enforced
+func syscallToMap(syscall, src string, m map[InnerKey][8]byte) {
+	nr, ok := syscalls[syscall]
+	if !ok {
+		return
+	}
+
+	var key InnerKey
+	key.Path[0] = SYSCALL
+	binary.LittleEndian.PutUint16(key.Path[1:3], nr)
+	if src != "" {
+		copy(key.Source[:], []byte(src))
+	}
+	m[key] = [8]byte{}
+}
+
 // dirtoMap extracts parent directories from the Path Key and adds it as hints in the Container Rule Map
 func dirtoMap(p, src string, m map[InnerKey][8]byte, val [8]byte) {
 	var key InnerKey
@@ -420,3 +590,104 @@ func dirtoMap(p, src string, m map[InnerKey][8]byte, val [8]byte) {
 		m[key] = val
 	}
 }
+
+// endpointToMap adds an endpoint with its port ranges to the Container Rule Map, and its prefix length as a hint
+func endpointToMap(endpoint tp.NetworkEndpointType, src string, m map[InnerKey][8]byte) error {
+	return addressToMap(ENDPOINT, PREFIXES, endpoint.CIDR, endpoint.Ports, src, m)
+}
+
+// listenerToMap adds a local address with its port ranges to the Container Rule Map, any address of both families if not given
+func listenerToMap(listener tp.NetworkListenerType, src string, m map[InnerKey][8]byte) error {
+	if listener.Address != "" {
+		return addressToMap(LISTENER, LISTENERPREFIXES, listener.Address, listener.Ports, src, m)
+	}
+
+	for _, cidr := range []string{"0.0.0.0/0", "::/0"} {
+		if err := addressToMap(LISTENER, LISTENERPREFIXES, cidr, listener.Ports, src, m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addressToMap adds a CIDR with its port ranges to the Container Rule Map as the given rule type, and its prefix length as a hint
+func addressToMap(rule, hintRule uint8, cidr string, portList []string, src string, m map[InnerKey][8]byte) error {
+	ipNet, err := kl.ParseCIDR(cidr)
+	if err != nil {
+		return err
+	}
+
+	ports := []tp.PortRange{}
+	for _, port := range portList {
+		portRange, err := kl.ParsePortRange(port)
+		if err != nil {
+			return err
+		}
+		ports = append(ports, portRange)
+	}
+	if len(ports) == 0 {
+		ports = append(ports, tp.PortRange{Min: 0, Max: 65535})
+	}
+
+	family := AFINET
+	if ipNet.IP.To4() == nil {
+		family = AFINET6
+	}
+
+	prefix, _ := ipNet.Mask.Size()
+
+	// the hint holds the prefix lengths (+1) to be looked up for an address family
+	var hint InnerKey
+	hint.Path[0] = hintRule
+	hint.Path[1] = family
+
+	prefixes := m[hint]
+	for i := 0; ; i++ {
+		if i == MaxEndpointPrefixes {
+			return fmt.Errorf("too many prefix lengths with %s (up to %d)", cidr, MaxEndpointPrefixes)
+		}
+		if prefixes[i] == uint8(prefix+1) {
+			break
+		}
+		if prefixes[i] == 0 {
+			prefixes[i] = uint8(prefix + 1)
+			break
+		}
+	}
+
+	var key InnerKey
+	key.Path[0] = rule
+	key.Path[1] = family
+	key.Path[2] = uint8(prefix)
+	copy(key.Path[4:], ipNet.IP)
+	if src != "" {
+		copy(key.Source[:], []byte(src))
+	}
+
+	// port ranges are stored in consecutive slots
+	slot := 0
+	for ; slot < MaxEndpointPorts; slot++ {
+		key.Path[3] = uint8(slot)
+		if _, ok := m[key]; !ok {
+			break
+		}
+	}
+	if slot+len(ports) > MaxEndpointPorts {
+		return fmt.Errorf("too many port ranges with %s (up to %d)", cidr, MaxEndpointPorts)
+	}
+
+	m[hint] = prefixes
+
+	for _, port := range ports {
+		var val [8]byte
+		binary.BigEndian.PutUint16(val[0:2], port.Min)
+		binary.BigEndian.PutUint16(val[2:4], port.Max)
+
+		key.Path[3] = uint8(slot)
+		m[key] = val
+		slot++
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of KubeArmor
+
+package feeder
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+)
+
+// ====================== //
+// == Alert Aggregator == //
+// ====================== //
+
+// AggregationCheckInterval Interval to check expired aggregation windows
+const AggregationCheckInterval = 1 * time.Second
+
+// AggregatedAlert Structure
+type AggregatedAlert struct {
+	WindowStart time.Time
+
+	// the latest duplicate and the number of duplicates (not including the first alert, which was emitted immediately)
+	Log   tp.Log
+	Count int32
+
+	// the time of the first duplicate
+	FirstSeen string
+}
+
+// AlertAggregator Structure
+type AlertAggregator struct {
+	Window time.Duration
+
+	Alerts     map[string]*AggregatedAlert
+	AlertsLock *sync.Mutex
+
+	// callback to emit aggregated alerts
+	Emit func(log tp.Log)
+
+	StopChan     chan struct{}
+	WgAggregator sync.WaitGroup
+}
+
+// NewAlertAggregator Function
+func NewAlertAggregator(window time.Duration, emit func(log tp.Log)) *AlertAggregator {
+	aa := &AlertAggregator{}
+
+	aa.Window = window
+
+	aa.Alerts = map[string]*AggregatedAlert{}
+	aa.AlertsLock = new(sync.Mutex)
+
+	aa.Emit = emit
+
+	aa.StopChan = make(chan struct{})
+
+	aa.WgAggregator.Add(1)
+	go aa.flushPeriodically()
+
+	return aa
+}
+
+// GetAggregationKey Function
+func GetAggregationKey(log tp.Log) string {
+	return strings.Join([]string{
+		log.HostName, log.ContainerID,
+		log.Type, log.PolicyName, log.Action,
+		log.Operation, log.Resource,
+		log.ProcessName, log.Result,
+	}, "\x00")
+}
+
+// Add Function (returns true if the alert should be emitted now)
+func (aa *AlertAggregator) Add(log tp.Log) bool {
+	key := GetAggregationKey(log)
+
+	aa.AlertsLock.Lock()
+	defer aa.AlertsLock.Unlock()
+
+	alert, ok := aa.Alerts[key]
+	if !ok {
+		// the first alert opens a window and goes out immediately
+		aa.Alerts[key] = &AggregatedAlert{WindowStart: time.Now()}
+		return true
+	}
+
+	if alert.Count == 0 {
+		alert.FirstSeen = log.UpdatedTime
+	}
+	alert.Log = log
+	alert.Count++
+
+	return false
+}
+
+// flush Function
+func (aa *AlertAggregator) flush(all bool) {
+	logs := []tp.Log{}
+
+	aa.AlertsLock.Lock()
+	for key, alert := range aa.Alerts {
+		if !all && time.Since(alert.WindowStart) < aa.Window {
+			continue
+		}
+
+		if alert.Count > 0 {
+			log := alert.Log
+			log.Count = alert.Count
+			log.FirstSeen = alert.FirstSeen
+			log.LastSeen = alert.Log.UpdatedTime
+			logs = append(logs, log)
+		}
+
+		delete(aa.Alerts, key)
+	}
+	aa.AlertsLock.Unlock()
+
+	for _, log := range logs {
+		aa.Emit(log)
+	}
+}
+
+// flushPeriodically Function
+func (aa *AlertAggregator) flushPeriodically() {
+	defer aa.WgAggregator.Done()
+
+	ticker := time.NewTicker(AggregationCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-aa.StopChan:
+			return
+		case <-ticker.C:
+			aa.flush(false)
+		}
+	}
+}
+
+// Stop Function
+func (aa *AlertAggregator) Stop() {
+	close(aa.StopChan)
+	aa.WgAggregator.Wait()
+
+	// emit the remaining duplicates
+	aa.flush(true)
+}
@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of KubeArmor
+
+package feeder
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"sigs.k8s.io/yaml"
+)
+
+// =================== //
+// == Authorization == //
+// =================== //
+
+// AllNamespaces Wildcard to allow all namespaces
+const AllNamespaces = "*"
+
+// ClientScope Structure
+type ClientScope struct {
+	Name string `json:"name"`
+
+	// identities
+	Tokens           []string `json:"tokens,omitempty"`
+	CertificateNames []string `json:"certificateNames,omitempty"`
+
+	// visibility
+	Namespaces []string `json:"namespaces,omitempty"`
+	HostLogs   bool     `json:"hostLogs,omitempty"`
+
+	namespaceSet map[string]bool
+}
+
+// Authorizer Structure
+type Authorizer struct {
+	Clients []*ClientScope `json:"clients"`
+}
+
+// LoadAuthorizer Function
+func LoadAuthorizer(path string) (*Authorizer, error) {
+	data, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	az := &Authorizer{}
+	if err := yaml.Unmarshal(data, az); err != nil {
+		return nil, err
+	}
+
+	for _, client := range az.Clients {
+		if len(client.Tokens) == 0 && len(client.CertificateNames) == 0 {
+			return nil, fmt.Errorf("client %s has neither tokens nor certificate names", client.Name)
+		}
+
+		client.namespaceSet = map[string]bool{}
+		for _, namespace := range client.Namespaces {
+			client.namespaceSet[namespace] = true
+		}
+	}
+
+	return az, nil
+}
+
+// getBearerToken Function
+func getBearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	for _, value := range md.Get("authorization") {
+		if strings.HasPrefix(value, "Bearer ") {
+			return strings.TrimPrefix(value, "Bearer ")
+		}
+	}
+
+	return ""
+}
+
+// getCertificateNames Function
+func getCertificateNames(ctx context.Context) []string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+
+	cert := tlsInfo.State.VerifiedChains[0][0]
+
+	names := []string{}
+	if cert.Subject.CommonName != "" {
+		names = append(names, cert.Subject.CommonName)
+	}
+	names = append(names, cert.DNSNames...)
+
+	return names
+}
+
+// Authenticate Function
+func (az *Authorizer) Authenticate(ctx context.Context) (*ClientScope, error) {
+	if az == nil {
+		return nil, nil
+	}
+
+	if token := getBearerToken(ctx); token != "" {
+		for _, client := range az.Clients {
+			for _, clientToken := range client.Tokens {
+				if subtle.ConstantTimeCompare([]byte(token), []byte(clientToken)) == 1 {
+					return client, nil
+				}
+			}
+		}
+	}
+
+	for _, name := range getCertificateNames(ctx) {
+		for _, client := range az.Clients {
+			for _, certName := range client.CertificateNames {
+				if name == certName {
+					return client, nil
+				}
+			}
+		}
+	}
+
+	return nil, errors.New("no matched client identity")
+}
+
+// Allows Function
+func (scope *ClientScope) Allows(namespace string) bool {
+	if scope == nil {
+		return true
+	}
+
+	if namespace == "" {
+		return scope.HostLogs
+	}
+
+	return scope.namespaceSet[AllNamespaces] || scope.namespaceSet[namespace]
+}
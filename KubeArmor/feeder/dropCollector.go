@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of KubeArmor
+
+package feeder
+
+import (
+	"sync/atomic"
+
+	"github.com/kubearmor/KubeArmor/KubeArmor/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ==================== //
+// == Drop Collector == //
+// ==================== //
+
+// DropCollector Structure (reports the drop counters of subscribers and exporters)
+type DropCollector struct {
+	Feeder *Feeder
+
+	SubscriberDrops *prometheus.Desc
+	ExporterDrops   *prometheus.Desc
+	EventStoreDrops *prometheus.Desc
+}
+
+// NewDropCollector Function
+func NewDropCollector(fd *Feeder) *DropCollector {
+	dc := &DropCollector{}
+
+	dc.Feeder = fd
+
+	dc.SubscriberDrops = prometheus.NewDesc(
+		prometheus.BuildFQName(metrics.Namespace, "grpc", "dropped_events_total"),
+		"Number of events dropped for a gRPC subscriber",
+		[]string{"stream", "client"}, nil)
+
+	dc.ExporterDrops = prometheus.NewDesc(
+		prometheus.BuildFQName(metrics.Namespace, "exporter", "dropped_events_total"),
+		"Number of events dropped for an exporter",
+		[]string{"exporter"}, nil)
+
+	dc.EventStoreDrops = prometheus.NewDesc(
+		prometheus.BuildFQName(metrics.Namespace, "event_store", "dropped_events_total"),
+		"Number of events dropped for the local event store",
+		nil, nil)
+
+	return dc
+}
+
+// Describe Function
+func (dc *DropCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- dc.SubscriberDrops
+	ch <- dc.ExporterDrops
+	ch <- dc.EventStoreDrops
+}
+
+// Collect Function
+func (dc *DropCollector) Collect(ch chan<- prometheus.Metric) {
+	AlertLock.RLock()
+	for uid, alertStruct := range AlertStructs {
+		ch <- prometheus.MustNewConstMetric(dc.SubscriberDrops, prometheus.CounterValue,
+			float64(atomic.LoadUint64(alertStruct.Dropped)), "alerts", uid)
+	}
+	AlertLock.RUnlock()
+
+	LogLock.RLock()
+	for uid, logStruct := range LogStructs {
+		ch <- prometheus.MustNewConstMetric(dc.SubscriberDrops, prometheus.CounterValue,
+			float64(atomic.LoadUint64(logStruct.Dropped)), "logs", uid)
+	}
+	LogLock.RUnlock()
+
+	dc.Feeder.ExportersLock.RLock()
+	for _, exporter := range dc.Feeder.Exporters {
+		ch <- prometheus.MustNewConstMetric(dc.ExporterDrops, prometheus.CounterValue,
+			float64(atomic.LoadUint64(&exporter.Dropped)), exporter.Name)
+	}
+	dc.Feeder.ExportersLock.RUnlock()
+
+	if dc.Feeder.EventStore != nil {
+		ch <- prometheus.MustNewConstMetric(dc.EventStoreDrops, prometheus.CounterValue,
+			float64(atomic.LoadUint64(&dc.Feeder.EventStore.Dropped)))
+	}
+}
@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of KubeArmor
+
+package feeder
+
+import (
+	"strconv"
+	"strings"
+
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+	pb "github.com/kubearmor/KubeArmor/protobuf"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ================== //
+// == Event Filter == //
+// ================== //
+
+// EventFilter Structure
+type EventFilter struct {
+	NamespaceNames map[string]bool
+	PodNames       map[string]bool
+	LabelSelector  labels.Selector
+	ContainerNames map[string]bool
+
+	Operations map[string]bool
+
+	MinSeverity int
+	MaxSeverity int
+
+	Results     map[string]bool
+	PolicyNames map[string]bool
+}
+
+// toStringSet Function
+func toStringSet(elements []string) map[string]bool {
+	if len(elements) == 0 {
+		return nil
+	}
+
+	set := map[string]bool{}
+	for _, element := range elements {
+		set[element] = true
+	}
+
+	return set
+}
+
+// NewEventFilter Function
+func NewEventFilter(filter *pb.EventFilter) (*EventFilter, error) {
+	if filter == nil {
+		return nil, nil
+	}
+
+	ef := &EventFilter{}
+
+	ef.NamespaceNames = toStringSet(filter.NamespaceNames)
+	ef.PodNames = toStringSet(filter.PodNames)
+
+	if len(filter.LabelSelector) > 0 {
+		selector, err := labels.Parse(filter.LabelSelector)
+		if err != nil {
+			return nil, err
+		}
+		ef.LabelSelector = selector
+	}
+
+	ef.ContainerNames = toStringSet(filter.ContainerNames)
+
+	ef.Operations = toStringSet(filter.Operations)
+
+	ef.MinSeverity = int(filter.MinSeverity)
+	ef.MaxSeverity = int(filter.MaxSeverity)
+
+	ef.Results = toStringSet(filter.Results)
+	ef.PolicyNames = toStringSet(filter.PolicyNames)
+
+	return ef, nil
+}
+
+// getLabelSet Function
+func getLabelSet(str string) labels.Set {
+	set := labels.Set{}
+
+	for _, label := range strings.Split(str, ",") {
+		if kv := strings.SplitN(label, "=", 2); len(kv) == 2 {
+			set[kv[0]] = kv[1]
+		}
+	}
+
+	return set
+}
+
+// Match Function
+func (ef *EventFilter) Match(log tp.Log) bool {
+	if ef == nil {
+		return true
+	}
+
+	if ef.NamespaceNames != nil && !ef.NamespaceNames[log.NamespaceName] {
+		return false
+	}
+
+	if ef.PodNames != nil && !ef.PodNames[log.PodName] {
+		return false
+	}
+
+	if ef.LabelSelector != nil && !ef.LabelSelector.Matches(getLabelSet(log.Labels)) {
+		return false
+	}
+
+	if ef.ContainerNames != nil && !ef.ContainerNames[log.ContainerName] {
+		return false
+	}
+
+	if ef.Operations != nil && !ef.Operations[log.Operation] {
+		return false
+	}
+
+	if ef.Results != nil && !ef.Results[log.Result] {
+		return false
+	}
+
+	if log.Type == "MatchedPolicy" || log.Type == "MatchedHostPolicy" {
+		if ef.PolicyNames != nil && !ef.PolicyNames[log.PolicyName] {
+			return false
+		}
+
+		if ef.MinSeverity > 0 || ef.MaxSeverity > 0 {
+			severity, err := strconv.Atoi(log.Severity)
+			if err != nil {
+				return false
+			}
+
+			if ef.MinSeverity > 0 && severity < ef.MinSeverity {
+				return false
+			}
+
+			if ef.MaxSeverity > 0 && severity > ef.MaxSeverity {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of KubeArmor
+
+package feeder
+
+import (
+	"hash/fnv"
+	"sync"
+
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+)
+
+// ==================== //
+// == Event Pipeline == //
+// ==================== //
+
+// PipelineBatchSize Maximum number of events processed before publishing them
+const PipelineBatchSize = 64
+
+// EventPipeline Structure
+type EventPipeline struct {
+	// one bounded queue per worker
+	Queues []chan tp.Log
+
+	// stage 1: policy matching (returns false if the event should not be published)
+	Process func(log tp.Log) (tp.Log, bool)
+
+	// stage 2: batched fan-out
+	Publish func(logs []tp.Log)
+
+	Closed     bool
+	ClosedLock *sync.RWMutex
+
+	WgPipeline sync.WaitGroup
+}
+
+// NewEventPipeline Function
+func NewEventPipeline(workers, queueSize int, process func(log tp.Log) (tp.Log, bool), publish func(logs []tp.Log)) *EventPipeline {
+	ep := &EventPipeline{}
+
+	ep.Process = process
+	ep.Publish = publish
+
+	ep.ClosedLock = new(sync.RWMutex)
+
+	for i := 0; i < workers; i++ {
+		queue := make(chan tp.Log, queueSize)
+		ep.Queues = append(ep.Queues, queue)
+
+		ep.WgPipeline.Add(1)
+		go ep.run(queue)
+	}
+
+	return ep
+}
+
+// getQueue Function (events of a container always go to the same worker to keep their order)
+func (ep *EventPipeline) getQueue(containerID string) chan tp.Log {
+	if len(ep.Queues) == 1 {
+		return ep.Queues[0]
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(containerID))
+
+	return ep.Queues[h.Sum32()%uint32(len(ep.Queues))]
+}
+
+// Push Function (never blocks the caller, false if the pipeline has stopped)
+func (ep *EventPipeline) Push(log tp.Log) bool {
+	ep.ClosedLock.RLock()
+
+	if ep.Closed {
+		ep.ClosedLock.RUnlock()
+		return false
+	}
+
+	queue := ep.getQueue(log.ContainerID)
+
+	select {
+	case queue <- log:
+		ep.ClosedLock.RUnlock()
+		return true
+	default:
+	}
+
+	// the queue is full, so wait for it in the background instead of stalling the caller (e.g., the perf buffer reader)
+	// the read lock is held until the event is queued, so Stop still publishes it
+	go func() {
+		defer ep.ClosedLock.RUnlock()
+		queue <- log
+	}()
+
+	return true
+}
+
+// run Function
+func (ep *EventPipeline) run(queue chan tp.Log) {
+	defer ep.WgPipeline.Done()
+
+	batch := make([]tp.Log, 0, PipelineBatchSize)
+
+	for log := range queue {
+		batch = batch[:0]
+
+		if log, ok := ep.Process(log); ok {
+			batch = append(batch, log)
+		}
+
+		// take what is already queued, up to the batch size
+	collect:
+		for processed := 1; processed < PipelineBatchSize; processed++ {
+			select {
+			case next, ok := <-queue:
+				if !ok {
+					break collect
+				}
+				if next, ok := ep.Process(next); ok {
+					batch = append(batch, next)
+				}
+			default:
+				break collect
+			}
+		}
+
+		if len(batch) > 0 {
+			ep.Publish(batch)
+		}
+	}
+}
+
+// Stop Function (publishes the queued events)
+func (ep *EventPipeline) Stop() {
+	ep.ClosedLock.Lock()
+	ep.Closed = true
+	for _, queue := range ep.Queues {
+		close(queue)
+	}
+	ep.ClosedLock.Unlock()
+
+	ep.WgPipeline.Wait()
+}
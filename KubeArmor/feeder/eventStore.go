@@ -0,0 +1,489 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of KubeArmor
+
+package feeder
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	kg "github.com/kubearmor/KubeArmor/KubeArmor/log"
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+	pb "github.com/kubearmor/KubeArmor/protobuf"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+)
+
+// ================= //
+// == Event Store == //
+// ================= //
+
+const (
+	// EventStoreQueueSize Number of events waiting to be written
+	EventStoreQueueSize = 4096
+
+	// EventStoreBatchSize Maximum number of events written in a transaction
+	EventStoreBatchSize = 256
+
+	// EventStoreFlushInterval Maximum delay before queued events are written
+	EventStoreFlushInterval = 1 * time.Second
+
+	// EventStoreRetentionInterval Interval to enforce the retention policy
+	EventStoreRetentionInterval = 1 * time.Minute
+
+	// EventStorePruneSize Number of the oldest events removed at once when the store is too large
+	EventStorePruneSize = 1024
+
+	// DefaultQueryLimit Number of records returned if a query has no limit
+	DefaultQueryLimit = 1000
+
+	// MaxQueryLimit Maximum number of records returned by a query
+	MaxQueryLimit = 10000
+
+	// QueryPageSize Maximum number of records read in a transaction before they are sent
+	QueryPageSize = 256
+)
+
+var (
+	alertBucket = []byte("alerts")
+	logBucket   = []byte("logs")
+)
+
+// StoredEvent Structure
+type StoredEvent struct {
+	Bucket []byte
+	Key    []byte
+	Value  []byte
+}
+
+// EventStore Structure
+type EventStore struct {
+	DB *bolt.DB
+
+	// retention
+	Retention time.Duration
+	MaxSize   int64
+
+	// pending events
+	Queue   chan StoredEvent
+	Dropped uint64
+
+	StopChan chan struct{}
+	WgStore  sync.WaitGroup
+}
+
+// NewEventStore Function
+func NewEventStore(path string, retention time.Duration, maxSize int64) (*EventStore, error) {
+	es := &EventStore{}
+
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+	es.DB = db
+
+	if err := es.DB.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{alertBucket, logBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		_ = es.DB.Close()
+		return nil, err
+	}
+
+	es.Retention = retention
+	es.MaxSize = maxSize
+
+	es.Queue = make(chan StoredEvent, EventStoreQueueSize)
+
+	es.StopChan = make(chan struct{})
+
+	es.WgStore.Add(2)
+	go es.writeEvents()
+	go es.enforceRetentionPeriodically()
+
+	return es, nil
+}
+
+// getEventKey Function (time in big endian, then sequence, to keep keys in time order)
+func getEventKey(t time.Time, sequence uint64) []byte {
+	key := make([]byte, 16)
+	binary.BigEndian.PutUint64(key[0:8], uint64(t.UnixNano()))
+	binary.BigEndian.PutUint64(key[8:16], sequence)
+	return key
+}
+
+// push Function
+func (es *EventStore) push(bucket []byte, log tp.Log, sequence uint64, event proto.Message) {
+	value, err := proto.Marshal(event)
+	if err != nil {
+		kg.Warnf("Failed to encode an event for the event store (%s)", err.Error())
+		return
+	}
+
+	select {
+	case es.Queue <- StoredEvent{Bucket: bucket, Key: getEventKey(getLogTime(log), sequence), Value: value}:
+	default:
+		atomic.AddUint64(&es.Dropped, 1)
+	}
+}
+
+// AddAlert Function
+func (es *EventStore) AddAlert(log tp.Log, alert *pb.Alert) {
+	es.push(alertBucket, log, alert.Sequence, alert)
+}
+
+// AddLog Function
+func (es *EventStore) AddLog(log tp.Log, pbLog *pb.Log) {
+	es.push(logBucket, log, pbLog.Sequence, pbLog)
+}
+
+// write Function
+func (es *EventStore) write(events []StoredEvent) {
+	if err := es.DB.Update(func(tx *bolt.Tx) error {
+		for _, event := range events {
+			if err := tx.Bucket(event.Bucket).Put(event.Key, event.Value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		atomic.AddUint64(&es.Dropped, uint64(len(events)))
+		kg.Warnf("Failed to write %d events to the event store (%s)", len(events), err.Error())
+	}
+}
+
+// writeEvents Function
+func (es *EventStore) writeEvents() {
+	defer es.WgStore.Done()
+
+	ticker := time.NewTicker(EventStoreFlushInterval)
+	defer ticker.Stop()
+
+	events := []StoredEvent{}
+
+	for {
+		select {
+		case <-es.StopChan:
+			// write the remaining events
+			for {
+				select {
+				case event := <-es.Queue:
+					events = append(events, event)
+				default:
+					if len(events) > 0 {
+						es.write(events)
+					}
+					return
+				}
+			}
+		case event := <-es.Queue:
+			events = append(events, event)
+			if len(events) >= EventStoreBatchSize {
+				es.write(events)
+				events = []StoredEvent{}
+			}
+		case <-ticker.C:
+			if len(events) > 0 {
+				es.write(events)
+				events = []StoredEvent{}
+			}
+		}
+	}
+}
+
+// getUsedSize Function
+func (es *EventStore) getUsedSize() int64 {
+	stats := es.DB.Stats()
+	pageSize := int64(es.DB.Info().PageSize)
+
+	size := int64(0)
+	_ = es.DB.View(func(tx *bolt.Tx) error {
+		size = tx.Size()
+		return nil
+	})
+
+	return size - int64(stats.FreePageN+stats.PendingPageN)*pageSize
+}
+
+// EnforceRetention Function
+func (es *EventStore) EnforceRetention() error {
+	// remove expired events
+	if es.Retention > 0 {
+		expired := getEventKey(time.Now().Add(-es.Retention), 0)
+
+		if err := es.DB.Update(func(tx *bolt.Tx) error {
+			for _, name := range [][]byte{alertBucket, logBucket} {
+				c := tx.Bucket(name).Cursor()
+				for k, _ := c.First(); k != nil && bytes.Compare(k, expired) < 0; k, _ = c.Next() {
+					if err := c.Delete(); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	// remove the oldest events until the store fits in its size
+	for es.MaxSize > 0 && es.getUsedSize() > es.MaxSize {
+		removed := 0
+
+		if err := es.DB.Update(func(tx *bolt.Tx) error {
+			for _, name := range [][]byte{alertBucket, logBucket} {
+				c := tx.Bucket(name).Cursor()
+				for k, _ := c.First(); k != nil && removed < EventStorePruneSize; k, _ = c.Next() {
+					if err := c.Delete(); err != nil {
+						return err
+					}
+					removed++
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		if removed == 0 {
+			break
+		}
+	}
+
+	return nil
+}
+
+// enforceRetentionPeriodically Function
+func (es *EventStore) enforceRetentionPeriodically() {
+	defer es.WgStore.Done()
+
+	ticker := time.NewTicker(EventStoreRetentionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-es.StopChan:
+			return
+		case <-ticker.C:
+			if err := es.EnforceRetention(); err != nil {
+				kg.Warnf("Failed to enforce the retention of the event store (%s)", err.Error())
+			}
+		}
+	}
+}
+
+// Close Function
+func (es *EventStore) Close() error {
+	close(es.StopChan)
+	es.WgStore.Wait()
+
+	return es.DB.Close()
+}
+
+// =========== //
+// == Query == //
+// =========== //
+
+// EventQuery Structure
+type EventQuery struct {
+	Since time.Time
+	Until time.Time
+
+	Scope  *ClientScope
+	Filter *EventFilter
+
+	Limit int
+}
+
+// parseQueryTime Function
+func parseQueryTime(str string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, str); err == nil {
+		return t, nil
+	}
+
+	if duration, err := time.ParseDuration(str); err == nil {
+		return now.Add(-duration), nil
+	}
+
+	return time.Time{}, fmt.Errorf("%s is neither an RFC3339 timestamp nor a duration", str)
+}
+
+// NewEventQuery Function
+func NewEventQuery(req *pb.QueryRequest, scope *ClientScope) (*EventQuery, error) {
+	query := &EventQuery{}
+
+	now := time.Now()
+
+	if req.Until != "" {
+		until, err := parseQueryTime(req.Until, now)
+		if err != nil {
+			return nil, err
+		}
+		query.Until = until
+	} else {
+		query.Until = now
+	}
+
+	if req.Since != "" {
+		since, err := parseQueryTime(req.Since, now)
+		if err != nil {
+			return nil, err
+		}
+		query.Since = since
+	} else {
+		query.Since = time.Unix(0, 0)
+	}
+
+	if query.Since.After(query.Until) {
+		return nil, errors.New("the start of the time range is after its end")
+	}
+
+	filter, err := NewEventFilter(req.EventFilter)
+	if err != nil {
+		return nil, err
+	}
+	query.Filter = filter
+
+	query.Scope = scope
+
+	switch {
+	case req.Limit <= 0:
+		query.Limit = DefaultQueryLimit
+	case req.Limit > MaxQueryLimit:
+		query.Limit = MaxQueryLimit
+	default:
+		query.Limit = int(req.Limit)
+	}
+
+	return query, nil
+}
+
+// scan Function (reads a page of records per transaction, and flushes the matched ones after each page)
+func (es *EventStore) scan(bucket []byte, query *EventQuery, match func(value []byte) (bool, error), flush func() error) error {
+	from := getEventKey(query.Since, 0)
+	until := getEventKey(query.Until, ^uint64(0))
+
+	count := 0
+
+	for from != nil && count < query.Limit {
+		if err := es.DB.View(func(tx *bolt.Tx) error {
+			c := tx.Bucket(bucket).Cursor()
+
+			read := 0
+
+			k, v := c.Seek(from)
+			for ; k != nil && bytes.Compare(k, until) <= 0 && count < query.Limit && read < QueryPageSize; k, v = c.Next() {
+				matched, err := match(v)
+				if err != nil {
+					return err
+				}
+				if matched {
+					count++
+				}
+				read++
+			}
+
+			// keys are only valid in the transaction
+			if k != nil && bytes.Compare(k, until) <= 0 {
+				from = append([]byte{}, k...)
+			} else {
+				from = nil
+			}
+
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		if err := flush(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// QueryAlerts Function (sends the matched alerts in pages)
+func (es *EventStore) QueryAlerts(query *EventQuery, send func(alert *pb.Alert) error) error {
+	alerts := []*pb.Alert{}
+
+	return es.scan(alertBucket, query, func(value []byte) (bool, error) {
+		alert := &pb.Alert{}
+		if err := proto.Unmarshal(value, alert); err != nil {
+			return false, err
+		}
+
+		log := tp.Log{
+			NamespaceName: alert.NamespaceName,
+			PodName:       alert.PodName,
+			Labels:        alert.Labels,
+			ContainerName: alert.ContainerName,
+			PolicyName:    alert.PolicyName,
+			Severity:      alert.Severity,
+			Type:          alert.Type,
+			Operation:     alert.Operation,
+			Result:        alert.Result,
+		}
+
+		if !query.Scope.Allows(log.NamespaceName) || !query.Filter.Match(log) {
+			return false, nil
+		}
+
+		alerts = append(alerts, alert)
+		return true, nil
+	}, func() error {
+		for _, alert := range alerts {
+			if err := send(alert); err != nil {
+				return err
+			}
+		}
+		alerts = alerts[:0]
+		return nil
+	})
+}
+
+// QueryLogs Function (sends the matched logs in pages)
+func (es *EventStore) QueryLogs(query *EventQuery, send func(log *pb.Log) error) error {
+	logs := []*pb.Log{}
+
+	return es.scan(logBucket, query, func(value []byte) (bool, error) {
+		pbLog := &pb.Log{}
+		if err := proto.Unmarshal(value, pbLog); err != nil {
+			return false, err
+		}
+
+		log := tp.Log{
+			NamespaceName: pbLog.NamespaceName,
+			PodName:       pbLog.PodName,
+			Labels:        pbLog.Labels,
+			ContainerName: pbLog.ContainerName,
+			Type:          pbLog.Type,
+			Operation:     pbLog.Operation,
+			Result:        pbLog.Result,
+		}
+
+		if !query.Scope.Allows(log.NamespaceName) || !query.Filter.Match(log) {
+			return false, nil
+		}
+
+		logs = append(logs, pbLog)
+		return true, nil
+	}, func() error {
+		for _, pbLog := range logs {
+			if err := send(pbLog); err != nil {
+				return err
+			}
+		}
+		logs = logs[:0]
+		return nil
+	})
+}
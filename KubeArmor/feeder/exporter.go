@@ -0,0 +1,386 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of KubeArmor
+
+package feeder
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	kg "github.com/kubearmor/KubeArmor/KubeArmor/log"
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+	pb "github.com/kubearmor/KubeArmor/protobuf"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// ============== //
+// == Exporter == //
+// ============== //
+
+// DefaultExporterQueueSize Default queue size of an exporter
+const DefaultExporterQueueSize = 4096
+
+// DefaultExporterBatchSize Default number of events exported at once
+const DefaultExporterBatchSize = 1
+
+// DefaultExporterFlushInterval Default interval to export a partial batch
+const DefaultExporterFlushInterval = 1 * time.Second
+
+// Exporter Interface
+type Exporter interface {
+	// Export sends a batch of events to the sink
+	Export(logs []tp.Log) error
+
+	// Close releases the resources of the sink
+	Close() error
+}
+
+// ExporterSelector Interface (optional, for exporters interested in a few kinds of events)
+type ExporterSelector interface {
+	// Select returns true if the event should be queued for the exporter
+	Select(log tp.Log) bool
+}
+
+// ExporterStopper Interface (optional, for exporters that wait, e.g., retries with backoff)
+type ExporterStopper interface {
+	// Stop interrupts the waits of Export, called on shutdown before the queued events are exported
+	Stop()
+}
+
+// ExporterFlusher Interface (optional, for exporters that hold events back, e.g., under a rate limit)
+type ExporterFlusher interface {
+	// Flush retries the held events, called on every flush interval even if no event has been queued
+	Flush() error
+}
+
+// PartialExportError Structure (returned by Export if the events before the last Unsent ones have been exported)
+type PartialExportError struct {
+	Unsent int
+	Err    error
+}
+
+// Error Function
+func (e *PartialExportError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap Function
+func (e *PartialExportError) Unwrap() error {
+	return e.Err
+}
+
+// ExporterFilter Structure
+type ExporterFilter struct {
+	NamespaceNames []string `json:"namespaceNames,omitempty"`
+	PodNames       []string `json:"podNames,omitempty"`
+	LabelSelector  string   `json:"labelSelector,omitempty"`
+	ContainerNames []string `json:"containerNames,omitempty"`
+	Operations     []string `json:"operations,omitempty"`
+	MinSeverity    int32    `json:"minSeverity,omitempty"`
+	MaxSeverity    int32    `json:"maxSeverity,omitempty"`
+	Results        []string `json:"results,omitempty"`
+	PolicyNames    []string `json:"policyNames,omitempty"`
+}
+
+// ExporterSpec Structure
+type ExporterSpec struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+
+	Filter *ExporterFilter `json:"filter,omitempty"`
+
+	QueueSize     int             `json:"queueSize,omitempty"`
+	BatchSize     int             `json:"batchSize,omitempty"`
+	FlushInterval metav1.Duration `json:"flushInterval,omitempty"`
+
+	Syslog  *SyslogSpec  `json:"syslog,omitempty"`
+	Webhook *WebhookSpec `json:"webhook,omitempty"`
+	OTLP    *OTLPSpec    `json:"otlp,omitempty"`
+
+	K8sEvents *K8sEventsSpec `json:"k8sEvents,omitempty"`
+}
+
+// K8sEventsSpec Structure
+type K8sEventsSpec struct {
+	AggregationWindow metav1.Duration `json:"aggregationWindow,omitempty"` // identical alerts in the window update the same Event
+	RateLimit         int             `json:"rateLimit,omitempty"`         // maximum API requests per second
+}
+
+// ExporterConfig Structure
+type ExporterConfig struct {
+	Exporters []ExporterSpec `json:"exporters"`
+}
+
+// ExporterFactory Function Type
+type ExporterFactory func(spec ExporterSpec) (Exporter, error)
+
+// ExporterFactories Map (exporter type -> factory)
+var ExporterFactories map[string]ExporterFactory
+
+// ExporterFactoriesLock Lock
+var ExporterFactoriesLock *sync.RWMutex
+
+func init() {
+	ExporterFactories = map[string]ExporterFactory{}
+	ExporterFactoriesLock = new(sync.RWMutex)
+}
+
+// RegisterExporter Function
+func RegisterExporter(exporterType string, factory ExporterFactory) {
+	ExporterFactoriesLock.Lock()
+	defer ExporterFactoriesLock.Unlock()
+
+	ExporterFactories[exporterType] = factory
+}
+
+// ExporterWorker Structure
+type ExporterWorker struct {
+	Name     string
+	Exporter Exporter
+	Filter   *EventFilter
+	Selector ExporterSelector
+	Flusher  ExporterFlusher
+
+	Queue         chan tp.Log
+	BatchSize     int
+	FlushInterval time.Duration
+
+	Dropped  uint64
+	StopChan chan struct{}
+	WgWorker sync.WaitGroup
+}
+
+// NewExporterWorker Function
+func NewExporterWorker(spec ExporterSpec) (*ExporterWorker, error) {
+	if spec.Name == "" {
+		return nil, errors.New("exporter name is required")
+	}
+
+	ExporterFactoriesLock.RLock()
+	factory, ok := ExporterFactories[spec.Type]
+	ExporterFactoriesLock.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown exporter type (%s)", spec.Type)
+	}
+
+	ew := &ExporterWorker{}
+
+	ew.Name = spec.Name
+
+	if spec.Filter != nil {
+		filter, err := NewEventFilter(&pb.EventFilter{
+			NamespaceNames: spec.Filter.NamespaceNames,
+			PodNames:       spec.Filter.PodNames,
+			LabelSelector:  spec.Filter.LabelSelector,
+			ContainerNames: spec.Filter.ContainerNames,
+			Operations:     spec.Filter.Operations,
+			MinSeverity:    spec.Filter.MinSeverity,
+			MaxSeverity:    spec.Filter.MaxSeverity,
+			Results:        spec.Filter.Results,
+			PolicyNames:    spec.Filter.PolicyNames,
+		})
+		if err != nil {
+			return nil, err
+		}
+		ew.Filter = filter
+	}
+
+	queueSize := spec.QueueSize
+	if queueSize <= 0 {
+		queueSize = DefaultExporterQueueSize
+	}
+	ew.Queue = make(chan tp.Log, queueSize)
+	ew.StopChan = make(chan struct{})
+
+	ew.BatchSize = spec.BatchSize
+	if ew.BatchSize <= 0 {
+		ew.BatchSize = DefaultExporterBatchSize
+	}
+
+	ew.FlushInterval = spec.FlushInterval.Duration
+	if ew.FlushInterval <= 0 {
+		ew.FlushInterval = DefaultExporterFlushInterval
+	}
+
+	exporter, err := factory(spec)
+	if err != nil {
+		return nil, err
+	}
+	ew.Exporter = exporter
+
+	if selector, ok := exporter.(ExporterSelector); ok {
+		ew.Selector = selector
+	}
+
+	if flusher, ok := exporter.(ExporterFlusher); ok {
+		ew.Flusher = flusher
+	}
+
+	ew.WgWorker.Add(1)
+	go ew.run()
+
+	return ew, nil
+}
+
+// Push Function
+func (ew *ExporterWorker) Push(log tp.Log) {
+	if ew.Selector != nil && !ew.Selector.Select(log) {
+		return
+	}
+
+	if !ew.Filter.Match(log) {
+		return
+	}
+
+	select {
+	case ew.Queue <- log:
+	default:
+		atomic.AddUint64(&ew.Dropped, 1)
+	}
+}
+
+// export Function (false if the export failed)
+func (ew *ExporterWorker) export(batch []tp.Log) bool {
+	if len(batch) == 0 {
+		return true
+	}
+
+	if err := ew.Exporter.Export(batch); err != nil {
+		unsent := len(batch)
+
+		var partial *PartialExportError
+		if errors.As(err, &partial) && partial.Unsent < unsent {
+			unsent = partial.Unsent
+		}
+
+		kg.Warnf("Failed to export %d events to %s (%s)", unsent, ew.Name, err.Error())
+		atomic.AddUint64(&ew.Dropped, uint64(unsent))
+		return false
+	}
+
+	return true
+}
+
+// stopping Function
+func (ew *ExporterWorker) stopping() bool {
+	select {
+	case <-ew.StopChan:
+		return true
+	default:
+		return false
+	}
+}
+
+// run Function
+func (ew *ExporterWorker) run() {
+	defer ew.WgWorker.Done()
+
+	ticker := time.NewTicker(ew.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]tp.Log, 0, ew.BatchSize)
+
+	// once an export fails on shutdown, the sink is assumed to be unreachable and the rest is dropped
+	discard := false
+
+	flush := func() {
+		if discard {
+			atomic.AddUint64(&ew.Dropped, uint64(len(batch)))
+		} else if !ew.export(batch) && ew.stopping() {
+			discard = true
+		}
+		batch = make([]tp.Log, 0, ew.BatchSize)
+	}
+
+	for {
+		select {
+		case log, ok := <-ew.Queue:
+			if !ok {
+				flush()
+				if discard {
+					kg.Warnf("Dropped the remaining events of %s on shutdown", ew.Name)
+				}
+				return
+			}
+
+			batch = append(batch, log)
+			if len(batch) >= ew.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+
+			if ew.Flusher != nil && !ew.stopping() {
+				if err := ew.Flusher.Flush(); err != nil {
+					kg.Warnf("Failed to flush the held events of %s (%s)", ew.Name, err.Error())
+				}
+			}
+		}
+	}
+}
+
+// Close Function
+func (ew *ExporterWorker) Close() error {
+	// interrupt retries before the remaining events are exported
+	close(ew.StopChan)
+	if stopper, ok := ew.Exporter.(ExporterStopper); ok {
+		stopper.Stop()
+	}
+
+	close(ew.Queue)
+
+	// wait for the remaining events
+	ew.WgWorker.Wait()
+
+	return ew.Exporter.Close()
+}
+
+// LoadExporters Function
+func LoadExporters(path string) ([]*ExporterWorker, error) {
+	data, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	config := ExporterConfig{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	workers := []*ExporterWorker{}
+	names := map[string]bool{}
+
+	for _, spec := range config.Exporters {
+		if names[spec.Name] {
+			closeExporters(workers)
+			return nil, fmt.Errorf("duplicated exporter name (%s)", spec.Name)
+		}
+
+		worker, err := NewExporterWorker(spec)
+		if err != nil {
+			closeExporters(workers)
+			return nil, fmt.Errorf("failed to create exporter %s (%s)", spec.Name, err.Error())
+		}
+
+		workers = append(workers, worker)
+		names[spec.Name] = true
+	}
+
+	return workers, nil
+}
+
+// closeExporters Function
+func closeExporters(workers []*ExporterWorker) {
+	for _, worker := range workers {
+		if err := worker.Close(); err != nil {
+			kg.Warnf("Failed to close exporter %s (%s)", worker.Name, err.Error())
+		}
+	}
+}
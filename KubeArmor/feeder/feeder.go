@@ -4,9 +4,7 @@
 package feeder
 
 import (
-	"bufio"
 	"context"
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -14,18 +12,24 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	kl "github.com/kubearmor/KubeArmor/KubeArmor/common"
 	cfg "github.com/kubearmor/KubeArmor/KubeArmor/config"
 	kg "github.com/kubearmor/KubeArmor/KubeArmor/log"
+	"github.com/kubearmor/KubeArmor/KubeArmor/metrics"
 	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
 
 	"github.com/google/uuid"
 	pb "github.com/kubearmor/KubeArmor/protobuf"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 // ============ //
@@ -43,9 +47,16 @@ func init() {
 // == gRPC == //
 // ========== //
 
+// MaxBlockingTimeout Maximum time to wait for a blocking client
+const MaxBlockingTimeout = 5 * time.Second
+
+// DropReportInterval Interval to report dropped events
+const DropReportInterval = 10 * time.Second
+
 // MsgStruct Structure
 type MsgStruct struct {
 	Filter    string
+	Scope     *ClientScope
 	Broadcast chan *pb.Message
 }
 
@@ -57,31 +68,70 @@ var MsgLock *sync.RWMutex
 
 // AlertStruct Structure
 type AlertStruct struct {
-	Filter    string
-	Broadcast chan *pb.Alert
+	Filter      string
+	Scope       *ClientScope
+	EventFilter *EventFilter
+	Broadcast   chan *pb.Alert
+
+	BlockingTimeout time.Duration
+	Dropped         *uint64
+
+	// blocking clients only: alerts waiting for the sender to put them in Broadcast
+	Pending    chan *pb.Alert
+	StopSender chan struct{}
+	WgSender   *sync.WaitGroup
 }
 
 // AlertStructs Map
 var AlertStructs map[string]AlertStruct
 
+// AlertSequence Last sequence number of alerts (protected by AlertLock)
+var AlertSequence uint64
+
+// AlertReplay Buffer (protected by AlertLock)
+var AlertReplay *ReplayBuffer
+
 // AlertLock Lock
 var AlertLock *sync.RWMutex
 
 // LogStruct Structure
 type LogStruct struct {
-	Filter    string
-	Broadcast chan *pb.Log
+	Filter      string
+	Scope       *ClientScope
+	EventFilter *EventFilter
+	Broadcast   chan *pb.Log
+
+	BlockingTimeout time.Duration
+	Dropped         *uint64
+
+	// blocking clients only: logs waiting for the sender to put them in Broadcast
+	Pending    chan *pb.Log
+	StopSender chan struct{}
+	WgSender   *sync.WaitGroup
 }
 
 // LogStructs Map
 var LogStructs map[string]LogStruct
 
+// LogSequence Last sequence number of logs (protected by LogLock)
+var LogSequence uint64
+
+// LogReplay Buffer (protected by LogLock)
+var LogReplay *ReplayBuffer
+
 // LogLock Lock
 var LogLock *sync.RWMutex
 
 // LogService Structure
 type LogService struct {
-	//
+	// client scopes (nil if authorization is disabled)
+	Authorizer *Authorizer
+
+	// local event store (nil if disabled)
+	EventStore *EventStore
+
+	// policy learner (pods in learning mode)
+	Learner *PolicyLearner
 }
 
 // HealthCheck Function
@@ -91,12 +141,13 @@ func (ls *LogService) HealthCheck(ctx context.Context, nonce *pb.NonceMessage) (
 }
 
 // addMsgStruct Function
-func (ls *LogService) addMsgStruct(uid string, conn chan *pb.Message, filter string) {
+func (ls *LogService) addMsgStruct(uid string, conn chan *pb.Message, filter string, scope *ClientScope) {
 	MsgLock.Lock()
 	defer MsgLock.Unlock()
 
 	msgStruct := MsgStruct{}
 	msgStruct.Filter = filter
+	msgStruct.Scope = scope
 	msgStruct.Broadcast = conn
 	MsgStructs[uid] = msgStruct
 
@@ -113,12 +164,42 @@ func (ls *LogService) removeMsgStruct(uid string) {
 	kg.Printf("Deleted the client (%s) for WatchMessages", uid)
 }
 
+// authenticate Function
+func (ls *LogService) authenticate(ctx context.Context) (*ClientScope, error) {
+	scope, err := ls.Authorizer.Authenticate(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "failed to authenticate the client (%s)", err.Error())
+	}
+	return scope, nil
+}
+
+// getBlockingTimeout Function
+func getBlockingTimeout(timeout int32) time.Duration {
+	if timeout <= 0 {
+		return 0
+	}
+
+	blockingTimeout := time.Duration(timeout) * time.Millisecond
+	if blockingTimeout > MaxBlockingTimeout {
+		return MaxBlockingTimeout
+	}
+
+	return blockingTimeout
+}
+
 // WatchMessages Function
 func (ls *LogService) WatchMessages(req *pb.RequestMessage, svr pb.LogService_WatchMessagesServer) error {
 	uid := uuid.Must(uuid.NewRandom()).String()
-	conn := make(chan *pb.Message)
+
+	scope, err := ls.authenticate(svr.Context())
+	if err != nil {
+		return err
+	}
+
+	// buffered so that messages (e.g., drop reports) are not lost while the previous one is being sent
+	conn := make(chan *pb.Message, cfg.GlobalCfg.GRPCQueueSize)
 	defer close(conn)
-	ls.addMsgStruct(uid, conn, req.Filter)
+	ls.addMsgStruct(uid, conn, req.Filter, scope)
 	defer ls.removeMsgStruct(uid)
 
 	for Running {
@@ -144,26 +225,159 @@ func (ls *LogService) WatchMessages(req *pb.RequestMessage, svr pb.LogService_Wa
 }
 
 // addAlertStruct Function
-func (ls *LogService) addAlertStruct(uid string, conn chan *pb.Alert, filter string) {
+func (ls *LogService) addAlertStruct(uid string, conn chan *pb.Alert, filter string, scope *ClientScope, eventFilter *EventFilter, blockingTimeout time.Duration, dropped *uint64, resumeAfter uint64) ([]*pb.Alert, uint64) {
 	AlertLock.Lock()
 	defer AlertLock.Unlock()
 
 	alertStruct := AlertStruct{}
 	alertStruct.Filter = filter
+	alertStruct.Scope = scope
+	alertStruct.EventFilter = eventFilter
 	alertStruct.Broadcast = conn
+	alertStruct.BlockingTimeout = blockingTimeout
+	alertStruct.Dropped = dropped
+
+	if blockingTimeout > 0 {
+		alertStruct.Pending = make(chan *pb.Alert, cap(conn))
+		alertStruct.StopSender = make(chan struct{})
+		alertStruct.WgSender = new(sync.WaitGroup)
+
+		alertStruct.WgSender.Add(1)
+		go alertStruct.sendAlerts()
+	}
+
 	AlertStructs[uid] = alertStruct
 
 	kg.Printf("Added a new client (%s, %s) for WatchAlerts", uid, filter)
+
+	// collect missed alerts
+	replay := []*pb.Alert{}
+
+	// the oldest sequence available if some alerts cannot be replayed
+	gap := uint64(0)
+
+	if resumeAfter > 0 {
+		if resumeAfter > AlertSequence {
+			// the sequence has restarted since the client received the alert (e.g., KubeArmor restarted)
+			kg.Warnf("Failed to resume alerts after %d for the client (%s), the sequence restarted", resumeAfter, uid)
+			resumeAfter = 0
+
+			gap = AlertSequence + 1
+			if oldest := AlertReplay.Oldest(); oldest > 0 {
+				gap = oldest
+			}
+		} else if oldest := AlertReplay.Oldest(); oldest > resumeAfter+1 {
+			kg.Warnf("Failed to replay alerts from %d for the client (%s), the oldest one is %d", resumeAfter+1, uid, oldest)
+			gap = oldest
+		}
+
+		for _, event := range AlertReplay.After(resumeAfter) {
+			if scope.Allows(event.Log.NamespaceName) && eventFilter.Match(event.Log) {
+				replay = append(replay, event.Event.(*pb.Alert))
+			}
+		}
+	}
+
+	return replay, gap
 }
 
 // removeAlertStruct Function
 func (ls *LogService) removeAlertStruct(uid string) {
 	AlertLock.Lock()
-	defer AlertLock.Unlock()
-
+	alertStruct := AlertStructs[uid]
 	delete(AlertStructs, uid)
+	AlertLock.Unlock()
+
+	// no more alerts are pending once the client is deleted
+	if alertStruct.StopSender != nil {
+		close(alertStruct.StopSender)
+		alertStruct.WgSender.Wait()
+	}
+
+	kg.Printf("Deleted the client (%s, %d dropped) for WatchAlerts", uid, atomic.LoadUint64(alertStruct.Dropped))
+}
+
+// pushAlert Function (never blocks, called with AlertLock held)
+func (alertStruct AlertStruct) pushAlert(alert *pb.Alert) {
+	// blocking clients wait in their own sender
+	if alertStruct.Pending != nil {
+		select {
+		case alertStruct.Pending <- alert:
+			return
+		default:
+		}
+
+		atomic.AddUint64(alertStruct.Dropped, 1)
+		return
+	}
+
+	select {
+	case alertStruct.Broadcast <- alert:
+		return
+	default:
+	}
 
-	kg.Printf("Deleted the client (%s) for WatchAlerts", uid)
+	atomic.AddUint64(alertStruct.Dropped, 1)
+}
+
+// sendAlerts Function (waits up to BlockingTimeout for each pending alert)
+func (alertStruct AlertStruct) sendAlerts() {
+	defer alertStruct.WgSender.Done()
+
+	timer := time.NewTimer(alertStruct.BlockingTimeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-alertStruct.StopSender:
+			return
+		case alert := <-alertStruct.Pending:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(alertStruct.BlockingTimeout)
+
+			select {
+			case alertStruct.Broadcast <- alert:
+			case <-timer.C:
+				atomic.AddUint64(alertStruct.Dropped, 1)
+			case <-alertStruct.StopSender:
+				return
+			}
+		}
+	}
+}
+
+// markAlertGap Function (copies the first alert sent after a resume that missed alerts with the oldest sequence available)
+func markAlertGap(alert *pb.Alert, gap *uint64) *pb.Alert {
+	if *gap == 0 {
+		return alert
+	}
+
+	// the same alert is shared by all clients
+	marked := proto.Clone(alert).(*pb.Alert)
+	marked.OldestSequence = *gap
+	*gap = 0
+
+	return marked
+}
+
+// reportAlertDrops Function (copies the alert with the number of alerts dropped for the client since the last report)
+func reportAlertDrops(alert *pb.Alert, dropped *uint64, reported *uint64) *pb.Alert {
+	total := atomic.LoadUint64(dropped)
+	if total == *reported {
+		return alert
+	}
+
+	// the same alert is shared by all clients
+	report := proto.Clone(alert).(*pb.Alert)
+	report.Dropped = total - *reported
+	*reported = total
+
+	return report
 }
 
 // WatchAlerts Function
@@ -173,16 +387,37 @@ func (ls *LogService) WatchAlerts(req *pb.RequestMessage, svr pb.LogService_Watc
 	if req.Filter != "all" && req.Filter != "policy" {
 		return nil
 	}
-	conn := make(chan *pb.Alert)
+
+	scope, err := ls.authenticate(svr.Context())
+	if err != nil {
+		return err
+	}
+
+	eventFilter, err := NewEventFilter(req.EventFilter)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid event filter (%s)", err.Error())
+	}
+
+	conn := make(chan *pb.Alert, cfg.GlobalCfg.GRPCQueueSize)
 	defer close(conn)
-	ls.addAlertStruct(uid, conn, req.Filter)
+	dropped, reported := new(uint64), uint64(0)
+	replay, gap := ls.addAlertStruct(uid, conn, req.Filter, scope, eventFilter, getBlockingTimeout(req.BlockingTimeout), dropped, req.ResumeAfter)
 	defer ls.removeAlertStruct(uid)
 
+	for _, resp := range replay {
+		resp = markAlertGap(resp, &gap)
+		if err := svr.Send(resp); err != nil {
+			kg.Warnf("Failed to replay an alert=[%+v] err=[%s]", resp, err.Error())
+			return err
+		}
+	}
+
 	for Running {
 		select {
 		case <-svr.Context().Done():
 			return nil
 		case resp := <-conn:
+			resp = reportAlertDrops(markAlertGap(resp, &gap), dropped, &reported)
 			if status, ok := status.FromError(svr.Send(resp)); ok {
 				switch status.Code() {
 				case codes.OK:
@@ -201,26 +436,159 @@ func (ls *LogService) WatchAlerts(req *pb.RequestMessage, svr pb.LogService_Watc
 }
 
 // addLogStruct Function
-func (ls *LogService) addLogStruct(uid string, conn chan *pb.Log, filter string) {
+func (ls *LogService) addLogStruct(uid string, conn chan *pb.Log, filter string, scope *ClientScope, eventFilter *EventFilter, blockingTimeout time.Duration, dropped *uint64, resumeAfter uint64) ([]*pb.Log, uint64) {
 	LogLock.Lock()
 	defer LogLock.Unlock()
 
 	logStruct := LogStruct{}
 	logStruct.Filter = filter
+	logStruct.Scope = scope
+	logStruct.EventFilter = eventFilter
 	logStruct.Broadcast = conn
+	logStruct.BlockingTimeout = blockingTimeout
+	logStruct.Dropped = dropped
+
+	if blockingTimeout > 0 {
+		logStruct.Pending = make(chan *pb.Log, cap(conn))
+		logStruct.StopSender = make(chan struct{})
+		logStruct.WgSender = new(sync.WaitGroup)
+
+		logStruct.WgSender.Add(1)
+		go logStruct.sendLogs()
+	}
+
 	LogStructs[uid] = logStruct
 
 	kg.Printf("Added a new client (%s, %s) for WatchLogs", uid, filter)
+
+	// collect missed logs
+	replay := []*pb.Log{}
+
+	// the oldest sequence available if some logs cannot be replayed
+	gap := uint64(0)
+
+	if resumeAfter > 0 {
+		if resumeAfter > LogSequence {
+			// the sequence has restarted since the client received the log (e.g., KubeArmor restarted)
+			kg.Warnf("Failed to resume logs after %d for the client (%s), the sequence restarted", resumeAfter, uid)
+			resumeAfter = 0
+
+			gap = LogSequence + 1
+			if oldest := LogReplay.Oldest(); oldest > 0 {
+				gap = oldest
+			}
+		} else if oldest := LogReplay.Oldest(); oldest > resumeAfter+1 {
+			kg.Warnf("Failed to replay logs from %d for the client (%s), the oldest one is %d", resumeAfter+1, uid, oldest)
+			gap = oldest
+		}
+
+		for _, event := range LogReplay.After(resumeAfter) {
+			if scope.Allows(event.Log.NamespaceName) && eventFilter.Match(event.Log) {
+				replay = append(replay, event.Event.(*pb.Log))
+			}
+		}
+	}
+
+	return replay, gap
 }
 
 // removeLogStruct Function
 func (ls *LogService) removeLogStruct(uid string) {
 	LogLock.Lock()
-	defer LogLock.Unlock()
-
+	logStruct := LogStructs[uid]
 	delete(LogStructs, uid)
+	LogLock.Unlock()
+
+	// no more logs are pending once the client is deleted
+	if logStruct.StopSender != nil {
+		close(logStruct.StopSender)
+		logStruct.WgSender.Wait()
+	}
 
-	kg.Printf("Deleted the client (%s) for WatchLogs", uid)
+	kg.Printf("Deleted the client (%s, %d dropped) for WatchLogs", uid, atomic.LoadUint64(logStruct.Dropped))
+}
+
+// pushLog Function (never blocks, called with LogLock held)
+func (logStruct LogStruct) pushLog(log *pb.Log) {
+	// blocking clients wait in their own sender
+	if logStruct.Pending != nil {
+		select {
+		case logStruct.Pending <- log:
+			return
+		default:
+		}
+
+		atomic.AddUint64(logStruct.Dropped, 1)
+		return
+	}
+
+	select {
+	case logStruct.Broadcast <- log:
+		return
+	default:
+	}
+
+	atomic.AddUint64(logStruct.Dropped, 1)
+}
+
+// sendLogs Function (waits up to BlockingTimeout for each pending log)
+func (logStruct LogStruct) sendLogs() {
+	defer logStruct.WgSender.Done()
+
+	timer := time.NewTimer(logStruct.BlockingTimeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-logStruct.StopSender:
+			return
+		case log := <-logStruct.Pending:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(logStruct.BlockingTimeout)
+
+			select {
+			case logStruct.Broadcast <- log:
+			case <-timer.C:
+				atomic.AddUint64(logStruct.Dropped, 1)
+			case <-logStruct.StopSender:
+				return
+			}
+		}
+	}
+}
+
+// markLogGap Function (copies the first log sent after a resume that missed logs with the oldest sequence available)
+func markLogGap(log *pb.Log, gap *uint64) *pb.Log {
+	if *gap == 0 {
+		return log
+	}
+
+	// the same log is shared by all clients
+	marked := proto.Clone(log).(*pb.Log)
+	marked.OldestSequence = *gap
+	*gap = 0
+
+	return marked
+}
+
+// reportLogDrops Function (copies the log with the number of logs dropped for the client since the last report)
+func reportLogDrops(log *pb.Log, dropped *uint64, reported *uint64) *pb.Log {
+	total := atomic.LoadUint64(dropped)
+	if total == *reported {
+		return log
+	}
+
+	// the same log is shared by all clients
+	report := proto.Clone(log).(*pb.Log)
+	report.Dropped = total - *reported
+	*reported = total
+
+	return report
 }
 
 // WatchLogs Function
@@ -230,16 +598,37 @@ func (ls *LogService) WatchLogs(req *pb.RequestMessage, svr pb.LogService_WatchL
 	if req.Filter != "all" && req.Filter != "system" {
 		return nil
 	}
-	conn := make(chan *pb.Log)
+
+	scope, err := ls.authenticate(svr.Context())
+	if err != nil {
+		return err
+	}
+
+	eventFilter, err := NewEventFilter(req.EventFilter)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid event filter (%s)", err.Error())
+	}
+
+	conn := make(chan *pb.Log, cfg.GlobalCfg.GRPCQueueSize)
 	defer close(conn)
-	ls.addLogStruct(uid, conn, req.Filter)
+	dropped, reported := new(uint64), uint64(0)
+	replay, gap := ls.addLogStruct(uid, conn, req.Filter, scope, eventFilter, getBlockingTimeout(req.BlockingTimeout), dropped, req.ResumeAfter)
 	defer ls.removeLogStruct(uid)
 
+	for _, resp := range replay {
+		resp = markLogGap(resp, &gap)
+		if err := svr.Send(resp); err != nil {
+			kg.Warnf("Failed to replay a log=[%+v] err=[%s]", resp, err.Error())
+			return err
+		}
+	}
+
 	for Running {
 		select {
 		case <-svr.Context().Done():
 			return nil
 		case resp := <-conn:
+			resp = reportLogDrops(markLogGap(resp, &gap), dropped, &reported)
 			if status, ok := status.FromError(svr.Send(resp)); ok {
 				switch status.Code() {
 				case codes.OK:
@@ -257,6 +646,103 @@ func (ls *LogService) WatchLogs(req *pb.RequestMessage, svr pb.LogService_WatchL
 	return nil
 }
 
+// QueryAlerts Function
+func (ls *LogService) QueryAlerts(req *pb.QueryRequest, svr pb.LogService_QueryAlertsServer) error {
+	if ls.EventStore == nil {
+		return status.Error(codes.Unavailable, "the event store is disabled")
+	}
+
+	scope, err := ls.authenticate(svr.Context())
+	if err != nil {
+		return err
+	}
+
+	query, err := NewEventQuery(req, scope)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid query (%s)", err.Error())
+	}
+
+	// records are sent page by page, not collected first
+	var sendErr error
+
+	if err := ls.EventStore.QueryAlerts(query, func(resp *pb.Alert) error {
+		if sendErr = svr.Send(resp); sendErr != nil {
+			kg.Warnf("Failed to send a queried alert=[%+v] err=[%s]", resp, sendErr.Error())
+		}
+		return sendErr
+	}); err != nil {
+		if sendErr != nil {
+			return sendErr
+		}
+		return status.Errorf(codes.Internal, "failed to query alerts (%s)", err.Error())
+	}
+
+	return nil
+}
+
+// QueryLogs Function
+func (ls *LogService) QueryLogs(req *pb.QueryRequest, svr pb.LogService_QueryLogsServer) error {
+	if ls.EventStore == nil {
+		return status.Error(codes.Unavailable, "the event store is disabled")
+	}
+
+	scope, err := ls.authenticate(svr.Context())
+	if err != nil {
+		return err
+	}
+
+	query, err := NewEventQuery(req, scope)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid query (%s)", err.Error())
+	}
+
+	// records are sent page by page, not collected first
+	var sendErr error
+
+	if err := ls.EventStore.QueryLogs(query, func(resp *pb.Log) error {
+		if sendErr = svr.Send(resp); sendErr != nil {
+			kg.Warnf("Failed to send a queried log=[%+v] err=[%s]", resp, sendErr.Error())
+		}
+		return sendErr
+	}); err != nil {
+		if sendErr != nil {
+			return sendErr
+		}
+		return status.Errorf(codes.Internal, "failed to query logs (%s)", err.Error())
+	}
+
+	return nil
+}
+
+// GetLearnedPolicy Function
+func (ls *LogService) GetLearnedPolicy(ctx context.Context, req *pb.LearnedPolicyRequest) (*pb.LearnedPolicyReply, error) {
+	if ls.Learner == nil {
+		return nil, status.Error(codes.Unavailable, "policy learning is disabled")
+	}
+
+	scope, err := ls.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.NamespaceName == "" || req.PodName == "" {
+		return nil, status.Error(codes.InvalidArgument, "namespace and pod names are required")
+	}
+
+	if !scope.Allows(req.NamespaceName) {
+		return nil, status.Errorf(codes.PermissionDenied, "namespace %s is not allowed", req.NamespaceName)
+	}
+
+	policy, err := ls.Learner.GeneratePolicy(req.NamespaceName, req.PodName, int(req.CollapseThreshold))
+	if err == ErrNoLearnedProfile {
+		return nil, status.Errorf(codes.NotFound, "no behavior learned for %s/%s", req.NamespaceName, req.PodName)
+	} else if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to generate a policy (%s)", err.Error())
+	}
+
+	return &pb.LearnedPolicyReply{Policy: policy}, nil
+}
+
 // ============ //
 // == Feeder == //
 // ============ //
@@ -271,7 +757,32 @@ type Feeder struct {
 
 	// output
 	Output  string
-	LogFile *os.File
+	LogFile *RotatingFile
+
+	// exporters
+	Exporters     []*ExporterWorker
+	ExportersLock *sync.RWMutex
+
+	// redactor (nil if disabled)
+	Redactor *Redactor
+
+	// alert aggregator
+	AlertAggregator *AlertAggregator
+
+	// rate limiter
+	RateLimiter *RateLimiter
+
+	// event pipeline (nil if events are processed in the caller)
+	Pipeline *EventPipeline
+
+	// metrics
+	DropCollector *DropCollector
+
+	// local event store
+	EventStore *EventStore
+
+	// policy learner
+	Learner *PolicyLearner
 
 	// gRPC listener
 	Listener net.Listener
@@ -279,6 +790,9 @@ type Feeder struct {
 	// log server
 	LogServer *grpc.Server
 
+	// health server (grpc.health.v1)
+	HealthServer *health.Server
+
 	// wait group
 	WgServer sync.WaitGroup
 
@@ -295,6 +809,9 @@ type Feeder struct {
 
 	// Activated Enforcer
 	Enforcer string
+
+	// treat all allow rules as one allow list (policy simulation only)
+	CombineAllowRules bool
 }
 
 // NewFeeder Function
@@ -310,17 +827,93 @@ func NewFeeder(node *tp.Node) *Feeder {
 	// output
 	fd.Output = cfg.GlobalCfg.LogPath
 
+	// redaction
+	if cfg.GlobalCfg.RedactionConfigPath != "" {
+		redactor, err := LoadRedactor(cfg.GlobalCfg.RedactionConfigPath)
+		if err != nil {
+			kg.Errf("Failed to load redaction rules from %s (%s)", cfg.GlobalCfg.RedactionConfigPath, err.Error())
+			return nil
+		}
+		fd.Redactor = redactor
+
+		kg.Printf("Loaded %d redaction rules", len(fd.Redactor.Rules))
+	}
+
+	// policy learning
+	fd.Learner = NewPolicyLearner(cfg.GlobalCfg.LearningCollapseThreshold, cfg.GlobalCfg.LearningMaxPaths)
+
 	// output mode
 	if fd.Output != "stdout" && fd.Output != "none" {
-		// #nosec
-		logFile, err := os.OpenFile(filepath.Clean(fd.Output), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+		maxSize := int64(cfg.GlobalCfg.LogFileMaxSize) * 1024 * 1024
+		logFile, err := NewRotatingFile(fd.Output, maxSize, cfg.GlobalCfg.LogFileRotationInterval, cfg.GlobalCfg.LogFileMaxBackups, cfg.GlobalCfg.LogFileCompress)
 		if err != nil {
 			kg.Errf("Failed to open %s", fd.Output)
 			return nil
 		}
+
+		// hash chain
+		if cfg.GlobalCfg.LogSigningKeyPath != "" {
+			privateKey, err := LoadSigningKey(cfg.GlobalCfg.LogSigningKeyPath)
+			if err != nil {
+				kg.Errf("Failed to load the log signing key (%s)", err.Error())
+				_ = logFile.Close()
+				return nil
+			}
+
+			if err := logFile.EnableHashChain(NewHashChain(privateKey, cfg.GlobalCfg.LogCheckpointInterval)); err != nil {
+				kg.Errf("Failed to enable the hash chain of %s (%s)", fd.Output, err.Error())
+				_ = logFile.Close()
+				return nil
+			}
+
+			kg.Printf("Enabled the hash chain of %s", fd.Output)
+		}
+
 		fd.LogFile = logFile
 	}
 
+	// exporters
+	fd.ExportersLock = new(sync.RWMutex)
+
+	if cfg.GlobalCfg.ExporterConfigPath != "" {
+		exporters, err := LoadExporters(cfg.GlobalCfg.ExporterConfigPath)
+		if err != nil {
+			kg.Errf("Failed to load exporters from %s (%s)", cfg.GlobalCfg.ExporterConfigPath, err.Error())
+			return nil
+		}
+		fd.Exporters = exporters
+
+		for _, exporter := range fd.Exporters {
+			kg.Printf("Started exporter %s", exporter.Name)
+		}
+	}
+
+	// alert aggregator
+	if cfg.GlobalCfg.AlertAggregationWindow > 0 {
+		fd.AlertAggregator = NewAlertAggregator(cfg.GlobalCfg.AlertAggregationWindow, fd.publishLog)
+	}
+
+	// local event store
+	if cfg.GlobalCfg.EventStorePath != "" {
+		maxSize := int64(cfg.GlobalCfg.EventStoreMaxSize) * 1024 * 1024
+		eventStore, err := NewEventStore(cfg.GlobalCfg.EventStorePath, cfg.GlobalCfg.EventStoreRetention, maxSize)
+		if err != nil {
+			kg.Errf("Failed to open the event store %s (%s)", cfg.GlobalCfg.EventStorePath, err.Error())
+			return nil
+		}
+		fd.EventStore = eventStore
+
+		kg.Printf("Started to keep events in %s", cfg.GlobalCfg.EventStorePath)
+	}
+
+	// rate limiter (always created, since pods can set their own limits)
+	fd.RateLimiter = NewRateLimiter(cfg.GlobalCfg.ContainerEventRateLimit, cfg.GlobalCfg.HostEventRateLimit, fd.publishLog)
+
+	// event pipeline
+	if cfg.GlobalCfg.EventPipelineWorkers > 0 {
+		fd.Pipeline = NewEventPipeline(cfg.GlobalCfg.EventPipelineWorkers, cfg.GlobalCfg.EventPipelineQueueSize, fd.processLog, fd.publishLogs)
+	}
+
 	// listen to gRPC port
 	listener, err := net.Listen("tcp", fd.Port)
 	if err != nil {
@@ -354,12 +947,49 @@ func NewFeeder(node *tp.Node) *Feeder {
 	}
 
 	// create a log server
-	fd.LogServer = grpc.NewServer()
+	if cfg.GlobalCfg.TLSCertPath != "" {
+		creds, err := NewTLSCredentials(cfg.GlobalCfg.TLSCertPath, cfg.GlobalCfg.TLSKeyPath, cfg.GlobalCfg.TLSClientCAPath)
+		if err != nil {
+			kg.Errf("Failed to load TLS credentials (%s)", err.Error())
+			return nil
+		}
+		fd.LogServer = grpc.NewServer(grpc.Creds(creds))
+
+		if cfg.GlobalCfg.TLSClientCAPath != "" {
+			kg.Print("Enabled mutual TLS for the gRPC server")
+		} else {
+			kg.Print("Enabled TLS for the gRPC server")
+		}
+	} else {
+		fd.LogServer = grpc.NewServer()
+	}
 
 	// register a log service
-	logService := &LogService{}
+	logService := &LogService{EventStore: fd.EventStore, Learner: fd.Learner}
+
+	if cfg.GlobalCfg.GRPCAuthPath != "" {
+		authorizer, err := LoadAuthorizer(cfg.GlobalCfg.GRPCAuthPath)
+		if err != nil {
+			kg.Errf("Failed to load gRPC client scopes from %s (%s)", cfg.GlobalCfg.GRPCAuthPath, err.Error())
+			return nil
+		}
+		logService.Authorizer = authorizer
+
+		if cfg.GlobalCfg.TLSCertPath == "" {
+			kg.Warn("gRPC client tokens are sent in plain text since TLS is disabled")
+		}
+	}
 	pb.RegisterLogServiceServer(fd.LogServer, logService)
 
+	// register standard health checking (not serving until the daemon is initialized)
+	fd.HealthServer = health.NewServer()
+	fd.HealthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	fd.HealthServer.SetServingStatus(pb.LogService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(fd.LogServer, fd.HealthServer)
+
+	// register server reflection
+	reflection.Register(fd.LogServer)
+
 	// initialize msg structs
 	MsgStructs = make(map[string]MsgStruct)
 	MsgLock = &sync.RWMutex{}
@@ -367,10 +997,12 @@ func NewFeeder(node *tp.Node) *Feeder {
 	// initialize alert structs
 	AlertStructs = make(map[string]AlertStruct)
 	AlertLock = &sync.RWMutex{}
+	AlertReplay = NewReplayBuffer(cfg.GlobalCfg.GRPCReplayBufferSize)
 
 	// initialize log structs
 	LogStructs = make(map[string]LogStruct)
 	LogLock = &sync.RWMutex{}
+	LogReplay = NewReplayBuffer(cfg.GlobalCfg.GRPCReplayBufferSize)
 
 	// set wait group
 	fd.WgServer = sync.WaitGroup{}
@@ -396,11 +1028,23 @@ func NewFeeder(node *tp.Node) *Feeder {
 	// default enforcer
 	fd.Enforcer = "eBPF Monitor"
 
+	// report drop counters as metrics
+	fd.DropCollector = NewDropCollector(fd)
+	if err := metrics.Registry.Register(fd.DropCollector); err != nil {
+		kg.Warnf("Failed to register drop metrics (%s)", err.Error())
+		fd.DropCollector = nil
+	}
+
 	return fd
 }
 
 // DestroyFeeder Function
 func (fd *Feeder) DestroyFeeder() error {
+	// report all services as not serving
+	if fd.HealthServer != nil {
+		fd.HealthServer.Shutdown()
+	}
+
 	// stop gRPC service
 	Running = false
 
@@ -415,6 +1059,43 @@ func (fd *Feeder) DestroyFeeder() error {
 		fd.Listener = nil
 	}
 
+	// unregister drop metrics
+	if fd.DropCollector != nil {
+		metrics.Registry.Unregister(fd.DropCollector)
+		fd.DropCollector = nil
+	}
+
+	// stop the event pipeline (publishing the queued events)
+	if fd.Pipeline != nil {
+		fd.Pipeline.Stop()
+	}
+
+	// stop the rate limiter
+	if fd.RateLimiter != nil {
+		fd.RateLimiter.Stop()
+		fd.RateLimiter = nil
+	}
+
+	// stop the alert aggregator
+	if fd.AlertAggregator != nil {
+		fd.AlertAggregator.Stop()
+		fd.AlertAggregator = nil
+	}
+
+	// close exporters
+	fd.ExportersLock.Lock()
+	closeExporters(fd.Exporters)
+	fd.Exporters = nil
+	fd.ExportersLock.Unlock()
+
+	// close the event store
+	if fd.EventStore != nil {
+		if err := fd.EventStore.Close(); err != nil {
+			kg.Err(err.Error())
+		}
+		fd.EventStore = nil
+	}
+
 	// close LogFile
 	if fd.LogFile != nil {
 		if err := fd.LogFile.Close(); err != nil {
@@ -429,20 +1110,47 @@ func (fd *Feeder) DestroyFeeder() error {
 	return nil
 }
 
+// AddExporter Function (starts an exporter that is not in the exporter configuration file)
+func (fd *Feeder) AddExporter(spec ExporterSpec) error {
+	fd.ExportersLock.Lock()
+	defer fd.ExportersLock.Unlock()
+
+	for _, exporter := range fd.Exporters {
+		if exporter.Name == spec.Name {
+			return fmt.Errorf("duplicated exporter name (%s)", spec.Name)
+		}
+	}
+
+	worker, err := NewExporterWorker(spec)
+	if err != nil {
+		return err
+	}
+	fd.Exporters = append(fd.Exporters, worker)
+
+	return nil
+}
+
+// SetServingStatus Function
+func (fd *Feeder) SetServingStatus(service string, serving bool) {
+	if fd.HealthServer == nil {
+		return
+	}
+
+	if serving {
+		fd.HealthServer.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+	} else {
+		fd.HealthServer.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+}
+
 // StrToFile Function
 func (fd *Feeder) StrToFile(str string) {
 	if fd.LogFile != nil {
 		// add the newline at the end of the string
 		str = str + "\n"
 
-		// write the string into the file
-		w := bufio.NewWriter(fd.LogFile)
-		if _, err := w.WriteString(str); err != nil {
-			kg.Err(err.Error())
-		}
-
-		// flush the file buffer
-		if err := w.Flush(); err != nil {
+		// write the string into the file (flushed periodically)
+		if err := fd.LogFile.WriteString(str); err != nil {
 			kg.Err(err.Error())
 		}
 	}
@@ -522,14 +1230,101 @@ func (fd *Feeder) ServeLogFeeds() {
 	fd.WgServer.Add(1)
 	defer fd.WgServer.Done()
 
+	// report dropped events
+	go fd.ReportDroppedEvents()
+
 	// feed logs
 	if err := fd.LogServer.Serve(fd.Listener); err != nil {
 		kg.Print("Terminated the gRPC service")
 	}
 }
 
-// PushMessage Function
-func (fd *Feeder) PushMessage(level, message string) {
+// clientReport Structure
+type clientReport struct {
+	Scope   *ClientScope
+	Message string
+}
+
+// ReportDroppedEvents Function
+func (fd *Feeder) ReportDroppedEvents() {
+	reported := map[string]uint64{}
+
+	for Running {
+		time.Sleep(DropReportInterval)
+
+		// client drops are reported to the clients themselves, on their WatchMessages streams
+		// and in the next event sent to them (see reportAlertDrops)
+		clientReports := []clientReport{}
+
+		reports := []string{}
+		clients := map[string]bool{}
+
+		AlertLock.RLock()
+		for uid, alertStruct := range AlertStructs {
+			dropped := atomic.LoadUint64(alertStruct.Dropped)
+			if dropped > reported[uid] {
+				clientReports = append(clientReports, clientReport{Scope: alertStruct.Scope,
+					Message: fmt.Sprintf("Dropped %d alerts for the client (%s, %d in total)", dropped-reported[uid], uid, dropped)})
+			}
+			reported[uid] = dropped
+			clients[uid] = true
+		}
+		AlertLock.RUnlock()
+
+		LogLock.RLock()
+		for uid, logStruct := range LogStructs {
+			dropped := atomic.LoadUint64(logStruct.Dropped)
+			if dropped > reported[uid] {
+				clientReports = append(clientReports, clientReport{Scope: logStruct.Scope,
+					Message: fmt.Sprintf("Dropped %d logs for the client (%s, %d in total)", dropped-reported[uid], uid, dropped)})
+			}
+			reported[uid] = dropped
+			clients[uid] = true
+		}
+		LogLock.RUnlock()
+
+		fd.ExportersLock.RLock()
+		for _, exporter := range fd.Exporters {
+			uid := "exporter/" + exporter.Name
+			dropped := atomic.LoadUint64(&exporter.Dropped)
+			if dropped > reported[uid] {
+				reports = append(reports, fmt.Sprintf("Dropped %d events for the exporter (%s, %d in total)", dropped-reported[uid], exporter.Name, dropped))
+			}
+			reported[uid] = dropped
+			clients[uid] = true
+		}
+		fd.ExportersLock.RUnlock()
+
+		if fd.EventStore != nil {
+			uid := "eventStore"
+			dropped := atomic.LoadUint64(&fd.EventStore.Dropped)
+			if dropped > reported[uid] {
+				reports = append(reports, fmt.Sprintf("Dropped %d events for the event store (%d in total)", dropped-reported[uid], dropped))
+			}
+			reported[uid] = dropped
+			clients[uid] = true
+		}
+
+		// forget the clients that are gone
+		for uid := range reported {
+			if !clients[uid] {
+				delete(reported, uid)
+			}
+		}
+
+		for _, report := range clientReports {
+			fd.PushClientMessage(report.Scope, "WARN", report.Message)
+			kg.Warn(report.Message)
+		}
+
+		for _, report := range reports {
+			fd.Warn(report)
+		}
+	}
+}
+
+// newMessage Function
+func (fd *Feeder) newMessage(level, message string) *pb.Message {
 	pbMsg := pb.Message{}
 
 	timestamp, updatedTime := kl.GetDateTimeNow()
@@ -547,12 +1342,51 @@ func (fd *Feeder) PushMessage(level, message string) {
 	pbMsg.Level = level
 	pbMsg.Message = message
 
+	return &pbMsg
+}
+
+// PushMessage Function
+func (fd *Feeder) PushMessage(level, message string) {
+	// no log server to stream messages to (e.g., policy simulation)
+	if MsgLock == nil {
+		return
+	}
+
+	pbMsg := fd.newMessage(level, message)
+
 	MsgLock.Lock()
 	defer MsgLock.Unlock()
 
 	for uid := range MsgStructs {
+		if !MsgStructs[uid].Scope.Allows("") {
+			continue
+		}
+
 		select {
-		case MsgStructs[uid].Broadcast <- &pbMsg:
+		case MsgStructs[uid].Broadcast <- pbMsg:
+		default:
+		}
+	}
+}
+
+// PushClientMessage Function (sends a message only to the WatchMessages streams of a client identity)
+func (fd *Feeder) PushClientMessage(scope *ClientScope, level, message string) {
+	if MsgLock == nil {
+		return
+	}
+
+	pbMsg := fd.newMessage(level, message)
+
+	MsgLock.Lock()
+	defer MsgLock.Unlock()
+
+	for uid := range MsgStructs {
+		if MsgStructs[uid].Scope != scope {
+			continue
+		}
+
+		select {
+		case MsgStructs[uid].Broadcast <- pbMsg:
 		default:
 		}
 	}
@@ -560,10 +1394,38 @@ func (fd *Feeder) PushMessage(level, message string) {
 
 // PushLog Function
 func (fd *Feeder) PushLog(log tp.Log) {
+	// hand over the event to the pipeline (processed in the caller once the pipeline has stopped)
+	if fd.Pipeline != nil && fd.Pipeline.Push(log) {
+		return
+	}
+
+	if log, ok := fd.processLog(log); ok {
+		fd.publishLog(log)
+	}
+}
+
+// processLog Function (returns false if the event should not be published)
+func (fd *Feeder) processLog(log tp.Log) (tp.Log, bool) {
+	// suppress events exceeding the rate limit
+	if fd.RateLimiter != nil && !fd.RateLimiter.Allow(log) {
+		return log, false
+	}
+
 	log = fd.UpdateMatchedPolicy(log)
 
 	if log.Source == "" {
-		return
+		return log, false
+	}
+
+	// record the behavior of pods in learning mode
+	if fd.Learner != nil && log.PolicyEnabled == tp.KubeArmorPolicyLearning && log.Type == "ContainerLog" {
+		fd.Learner.Record(log)
+	}
+
+	// count events and alerts
+	metrics.EventsTotal.WithLabelValues(log.Type, log.Operation, log.Result).Inc()
+	if log.Type == "MatchedPolicy" || log.Type == "MatchedHostPolicy" {
+		metrics.AlertsTotal.WithLabelValues(log.PolicyName, log.Action).Inc()
 	}
 
 	// set hostname
@@ -578,134 +1440,286 @@ func (fd *Feeder) PushLog(log tp.Log) {
 	log.FileVisibilityEnabled = false
 	log.NetworkVisibilityEnabled = false
 	log.CapabilitiesVisibilityEnabled = false
+	log.EventRateLimit = 0
 
-	// standard output / file output
-	if fd.Output == "stdout" {
-		arr, _ := json.Marshal(log)
-		fmt.Println(string(arr))
-	} else if fd.Output != "none" {
-		arr, _ := json.Marshal(log)
-		fd.StrToFile(string(arr))
+	// redact sensitive data before any output
+	if fd.Redactor != nil {
+		log = fd.Redactor.Redact(log)
 	}
 
-	// gRPC output
-	if log.Type == "MatchedPolicy" || log.Type == "MatchedHostPolicy" {
-		pbAlert := pb.Alert{}
+	// collapse identical alerts
+	if fd.AlertAggregator != nil && (log.Type == "MatchedPolicy" || log.Type == "MatchedHostPolicy") {
+		if !fd.AlertAggregator.Add(log) {
+			return log, false
+		}
+	}
 
-		pbAlert.Timestamp = log.Timestamp
-		pbAlert.UpdatedTime = log.UpdatedTime
+	return log, true
+}
 
-		pbAlert.ClusterName = fd.Node.ClusterName
-		pbAlert.HostName = fd.Node.NodeName
+// getPbAncestors Function
+func getPbAncestors(ancestors []tp.Ancestor) []*pb.Ancestor {
+	pbAncestors := make([]*pb.Ancestor, 0, len(ancestors))
+
+	for _, ancestor := range ancestors {
+		pbAncestors = append(pbAncestors, &pb.Ancestor{
+			HostPID:  ancestor.HostPID,
+			PID:      ancestor.PID,
+			ExecPath: ancestor.ExecPath,
+			Args:     ancestor.Args,
+		})
+	}
 
-		pbAlert.NamespaceName = log.NamespaceName
-		pbAlert.PodName = log.PodName
-		pbAlert.Labels = log.Labels
+	return pbAncestors
+}
 
-		pbAlert.ContainerID = log.ContainerID
-		pbAlert.ContainerName = log.ContainerName
-		pbAlert.ContainerImage = log.ContainerImage
+// getPbAlert Function
+func (fd *Feeder) getPbAlert(log tp.Log) *pb.Alert {
+	pbAlert := pb.Alert{}
 
-		pbAlert.HostPPID = log.HostPPID
-		pbAlert.HostPID = log.HostPID
+	pbAlert.Timestamp = log.Timestamp
+	pbAlert.UpdatedTime = log.UpdatedTime
 
-		pbAlert.PPID = log.PPID
-		pbAlert.PID = log.PID
-		pbAlert.UID = log.UID
+	pbAlert.ClusterName = fd.Node.ClusterName
+	pbAlert.HostName = fd.Node.NodeName
 
-		pbAlert.ParentProcessName = log.ParentProcessName
-		pbAlert.ProcessName = log.ProcessName
+	pbAlert.NamespaceName = log.NamespaceName
+	pbAlert.PodName = log.PodName
+	pbAlert.Labels = log.Labels
 
-		if len(log.Enforcer) > 0 {
-			pbAlert.Enforcer = log.Enforcer
-		}
+	pbAlert.ContainerID = log.ContainerID
+	pbAlert.ContainerName = log.ContainerName
+	pbAlert.ContainerImage = log.ContainerImage
 
-		if len(log.PolicyName) > 0 {
-			pbAlert.PolicyName = log.PolicyName
-		}
+	pbAlert.HostPPID = log.HostPPID
+	pbAlert.HostPID = log.HostPID
 
-		if len(log.Severity) > 0 {
-			pbAlert.Severity = log.Severity
-		}
+	pbAlert.PPID = log.PPID
+	pbAlert.PID = log.PID
+	pbAlert.UID = log.UID
 
-		if len(log.Tags) > 0 {
-			pbAlert.Tags = log.Tags
-		}
+	pbAlert.ParentProcessName = log.ParentProcessName
+	pbAlert.ProcessName = log.ProcessName
 
-		if len(log.Message) > 0 {
-			pbAlert.Message = log.Message
-		}
+	if len(log.Ancestors) > 0 {
+		pbAlert.Ancestors = getPbAncestors(log.Ancestors)
+	}
 
-		pbAlert.Type = log.Type
-		pbAlert.Source = log.Source
-		pbAlert.Operation = log.Operation
-		pbAlert.Resource = strings.ToValidUTF8(log.Resource, "")
+	if len(log.Enforcer) > 0 {
+		pbAlert.Enforcer = log.Enforcer
+	}
 
-		if len(log.Data) > 0 {
-			pbAlert.Data = log.Data
-		}
+	if len(log.PolicyName) > 0 {
+		pbAlert.PolicyName = log.PolicyName
+	}
 
-		if len(log.Action) > 0 {
-			pbAlert.Action = log.Action
-		}
+	if len(log.Severity) > 0 {
+		pbAlert.Severity = log.Severity
+	}
 
-		pbAlert.Result = log.Result
+	if len(log.Tags) > 0 {
+		pbAlert.Tags = log.Tags
+	}
 
-		AlertLock.Lock()
-		defer AlertLock.Unlock()
+	if len(log.Message) > 0 {
+		pbAlert.Message = log.Message
+	}
 
-		for uid := range AlertStructs {
-			select {
-			case AlertStructs[uid].Broadcast <- &pbAlert:
-			default:
+	pbAlert.Type = log.Type
+	pbAlert.Source = log.Source
+	pbAlert.Operation = log.Operation
+	pbAlert.Resource = strings.ToValidUTF8(log.Resource, "")
+
+	if len(log.Data) > 0 {
+		pbAlert.Data = log.Data
+	}
+
+	if len(log.Action) > 0 {
+		pbAlert.Action = log.Action
+	}
+
+	pbAlert.Result = log.Result
+
+	pbAlert.ExecArgs = log.ExecArgs
+	pbAlert.OpenFlags = log.OpenFlags
+	pbAlert.SocketFamily = log.SocketFamily
+	pbAlert.SocketType = log.SocketType
+	pbAlert.SocketProtocol = log.SocketProtocol
+	pbAlert.LocalIP = log.LocalIP
+	pbAlert.LocalPort = log.LocalPort
+	pbAlert.RemoteIP = log.RemoteIP
+	pbAlert.RemotePort = log.RemotePort
+	pbAlert.Errno = log.Errno
+	pbAlert.Retval = log.Retval
+
+	if log.Count > 0 {
+		pbAlert.Count = log.Count
+		pbAlert.FirstSeen = log.FirstSeen
+		pbAlert.LastSeen = log.LastSeen
+	}
+
+	return &pbAlert
+}
+
+// getPbLog Function
+func (fd *Feeder) getPbLog(log tp.Log) *pb.Log {
+	pbLog := pb.Log{}
+
+	pbLog.Timestamp = log.Timestamp
+	pbLog.UpdatedTime = log.UpdatedTime
+
+	pbLog.ClusterName = fd.Node.ClusterName
+	pbLog.HostName = fd.Node.NodeName
+
+	pbLog.NamespaceName = log.NamespaceName
+	pbLog.PodName = log.PodName
+	pbLog.Labels = log.Labels
+
+	pbLog.ContainerID = log.ContainerID
+	pbLog.ContainerName = log.ContainerName
+	pbLog.ContainerImage = log.ContainerImage
+
+	pbLog.HostPPID = log.HostPPID
+	pbLog.HostPID = log.HostPID
+
+	pbLog.PPID = log.PPID
+	pbLog.PID = log.PID
+	pbLog.UID = log.UID
+
+	pbLog.ParentProcessName = log.ParentProcessName
+	pbLog.ProcessName = log.ProcessName
+
+	if len(log.Ancestors) > 0 {
+		pbLog.Ancestors = getPbAncestors(log.Ancestors)
+	}
+
+	pbLog.Type = log.Type
+	pbLog.Source = log.Source
+	pbLog.Operation = log.Operation
+	pbLog.Resource = strings.ToValidUTF8(log.Resource, "")
+
+	if len(log.Data) > 0 {
+		pbLog.Data = log.Data
+	}
+
+	pbLog.Result = log.Result
+
+	pbLog.ExecArgs = log.ExecArgs
+	pbLog.OpenFlags = log.OpenFlags
+	pbLog.SocketFamily = log.SocketFamily
+	pbLog.SocketType = log.SocketType
+	pbLog.SocketProtocol = log.SocketProtocol
+	pbLog.LocalIP = log.LocalIP
+	pbLog.LocalPort = log.LocalPort
+	pbLog.RemoteIP = log.RemoteIP
+	pbLog.RemotePort = log.RemotePort
+	pbLog.Errno = log.Errno
+	pbLog.Retval = log.Retval
+
+	return &pbLog
+}
+
+// publishLog Function
+func (fd *Feeder) publishLog(log tp.Log) {
+	fd.publishLogs([]tp.Log{log})
+}
+
+// publishLogs Function
+func (fd *Feeder) publishLogs(logs []tp.Log) {
+	// standard output / file output
+	if fd.Output != "none" {
+		lines := []string{}
+
+		for _, log := range logs {
+			str, err := FormatLog(cfg.GlobalCfg.LogFormat, log)
+			if err != nil {
+				kg.Err(err.Error())
+				continue
 			}
+			lines = append(lines, str)
 		}
-	} else { // ContainerLog || HostLog
-		pbLog := pb.Log{}
 
-		pbLog.Timestamp = log.Timestamp
-		pbLog.UpdatedTime = log.UpdatedTime
+		if len(lines) > 0 {
+			if fd.Output == "stdout" {
+				fmt.Println(strings.Join(lines, "\n"))
+			} else {
+				fd.StrToFile(strings.Join(lines, "\n"))
+			}
+		}
+	}
 
-		pbLog.ClusterName = fd.Node.ClusterName
-		pbLog.HostName = fd.Node.NodeName
+	// exporters
+	fd.ExportersLock.RLock()
+	for _, exporter := range fd.Exporters {
+		for _, log := range logs {
+			exporter.Push(log)
+		}
+	}
+	fd.ExportersLock.RUnlock()
 
-		pbLog.NamespaceName = log.NamespaceName
-		pbLog.PodName = log.PodName
-		pbLog.Labels = log.Labels
+	// gRPC output
+	alerts := []ReplayEvent{}
+	pbLogs := []ReplayEvent{}
+
+	for _, log := range logs {
+		if log.Type == "MatchedPolicy" || log.Type == "MatchedHostPolicy" {
+			alerts = append(alerts, ReplayEvent{Log: log, Event: fd.getPbAlert(log)})
+		} else { // ContainerLog || HostLog
+			pbLogs = append(pbLogs, ReplayEvent{Log: log, Event: fd.getPbLog(log)})
+		}
+	}
 
-		pbLog.ContainerID = log.ContainerID
-		pbLog.ContainerName = log.ContainerName
-		pbLog.ContainerImage = log.ContainerImage
+	if len(alerts) > 0 {
+		AlertLock.Lock()
 
-		pbLog.HostPPID = log.HostPPID
-		pbLog.HostPID = log.HostPID
+		for _, event := range alerts {
+			pbAlert := event.Event.(*pb.Alert)
 
-		pbLog.PPID = log.PPID
-		pbLog.PID = log.PID
-		pbLog.UID = log.UID
+			AlertSequence++
+			pbAlert.Sequence = AlertSequence
+			event.Sequence = AlertSequence
+			AlertReplay.Add(event)
 
-		pbLog.ParentProcessName = log.ParentProcessName
-		pbLog.ProcessName = log.ProcessName
+			if fd.EventStore != nil {
+				fd.EventStore.AddAlert(event.Log, pbAlert)
+			}
 
-		pbLog.Type = log.Type
-		pbLog.Source = log.Source
-		pbLog.Operation = log.Operation
-		pbLog.Resource = strings.ToValidUTF8(log.Resource, "")
+			for uid := range AlertStructs {
+				if !AlertStructs[uid].Scope.Allows(event.Log.NamespaceName) || !AlertStructs[uid].EventFilter.Match(event.Log) {
+					continue
+				}
 
-		if len(log.Data) > 0 {
-			pbLog.Data = log.Data
+				AlertStructs[uid].pushAlert(pbAlert)
+			}
 		}
 
-		pbLog.Result = log.Result
+		AlertLock.Unlock()
+	}
 
+	if len(pbLogs) > 0 {
 		LogLock.Lock()
-		defer LogLock.Unlock()
 
-		for uid := range LogStructs {
-			select {
-			case LogStructs[uid].Broadcast <- &pbLog:
-			default:
+		for _, event := range pbLogs {
+			pbLog := event.Event.(*pb.Log)
+
+			LogSequence++
+			pbLog.Sequence = LogSequence
+			event.Sequence = LogSequence
+			LogReplay.Add(event)
+
+			if fd.EventStore != nil {
+				fd.EventStore.AddLog(event.Log, pbLog)
+			}
+
+			for uid := range LogStructs {
+				if !LogStructs[uid].Scope.Allows(event.Log.NamespaceName) || !LogStructs[uid].EventFilter.Match(event.Log) {
+					continue
+				}
+
+				LogStructs[uid].pushLog(pbLog)
 			}
 		}
+
+		LogLock.Unlock()
 	}
 }
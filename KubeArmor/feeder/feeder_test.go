@@ -4,10 +4,51 @@
 package feeder
 
 import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	kl "github.com/kubearmor/KubeArmor/KubeArmor/common"
 	cfg "github.com/kubearmor/KubeArmor/KubeArmor/config"
+	"github.com/kubearmor/KubeArmor/KubeArmor/policy"
 	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+	pb "github.com/kubearmor/KubeArmor/protobuf"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	collogs "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	otlpcommon "go.opentelemetry.io/proto/otlp/common/v1"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 )
 
 func TestFeeder(t *testing.T) {
@@ -28,6 +69,20 @@ func TestFeeder(t *testing.T) {
 	}
 	t.Log("[PASS] Created logger")
 
+	// check health
+	service := pb.LogService_ServiceDesc.ServiceName
+	if resp, err := logger.HealthServer.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service}); err != nil || resp.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("[FAIL] Expected %s to be not serving (%v, %v)", service, resp, err)
+		return
+	}
+
+	logger.SetServingStatus(service, true)
+	if resp, err := logger.HealthServer.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service}); err != nil || resp.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("[FAIL] Expected %s to be serving (%v, %v)", service, resp, err)
+		return
+	}
+	t.Log("[PASS] Reported the health status")
+
 	// destroy logger
 	if err := logger.DestroyFeeder(); err != nil {
 		t.Log("[FAIL] Failed to destroy logger")
@@ -35,3 +90,2358 @@ func TestFeeder(t *testing.T) {
 	}
 	t.Log("[PASS] Destroyed logger")
 }
+
+func TestEventFilter(t *testing.T) {
+	// no filter
+	var ef *EventFilter
+	if !ef.Match(tp.Log{}) {
+		t.Error("[FAIL] Empty filter dropped an event")
+		return
+	}
+
+	// invalid label selector
+	if _, err := NewEventFilter(&pb.EventFilter{LabelSelector: "app in ("}); err == nil {
+		t.Error("[FAIL] Accepted an invalid label selector")
+		return
+	}
+
+	ef, err := NewEventFilter(&pb.EventFilter{
+		NamespaceNames: []string{"multiubuntu"},
+		LabelSelector:  "group=group-1",
+		Operations:     []string{"Process", "File"},
+		MinSeverity:    3,
+		PolicyNames:    []string{"ksp-group-1-proc-path-block"},
+	})
+	if err != nil {
+		t.Errorf("[FAIL] Failed to create an event filter (%s)", err.Error())
+		return
+	}
+
+	alert := tp.Log{
+		NamespaceName: "multiubuntu",
+		Labels:        "container=ubuntu-1,group=group-1",
+		Type:          "MatchedPolicy",
+		Operation:     "Process",
+		PolicyName:    "ksp-group-1-proc-path-block",
+		Severity:      "5",
+		Result:        "Permission denied",
+	}
+	if !ef.Match(alert) {
+		t.Error("[FAIL] Filter dropped a matching alert")
+		return
+	}
+
+	unmatched := []tp.Log{alert, alert, alert, alert, alert}
+	unmatched[0].NamespaceName = "default"
+	unmatched[1].Labels = "container=ubuntu-2,group=group-2"
+	unmatched[2].Operation = "Network"
+	unmatched[3].Severity = "1"
+	unmatched[4].PolicyName = "DefaultPosture"
+
+	for _, log := range unmatched {
+		if ef.Match(log) {
+			t.Errorf("[FAIL] Filter passed an unmatched alert (%+v)", log)
+			return
+		}
+	}
+	t.Log("[PASS] Filtered events")
+}
+
+func TestPushAlert(t *testing.T) {
+	alertStruct := AlertStruct{}
+	alertStruct.Broadcast = make(chan *pb.Alert, 1)
+	alertStruct.Dropped = new(uint64)
+
+	// non-blocking client
+	alertStruct.pushAlert(&pb.Alert{})
+	alertStruct.pushAlert(&pb.Alert{})
+	if *alertStruct.Dropped != 1 {
+		t.Errorf("[FAIL] Expected 1 dropped alert, got %d", *alertStruct.Dropped)
+		return
+	}
+
+	// blocking client
+	AlertStructs = map[string]AlertStruct{}
+	AlertLock = &sync.RWMutex{}
+	AlertReplay = NewReplayBuffer(16)
+
+	ls := &LogService{}
+	conn := make(chan *pb.Alert, 1)
+	ls.addAlertStruct("blocking", conn, "all", nil, nil, getBlockingTimeout(100), new(uint64), 0)
+	alertStruct = AlertStructs["blocking"]
+
+	// wait for a condition (up to a second)
+	waitFor := func(cond func() bool) bool {
+		for deadline := time.Now().Add(time.Second); !cond(); time.Sleep(time.Millisecond) {
+			if time.Now().After(deadline) {
+				return false
+			}
+		}
+		return true
+	}
+
+	// the sender waits for the client, pushing alerts does not (even with AlertLock held)
+	alertStruct.pushAlert(&pb.Alert{Sequence: 1})
+	waitFor(func() bool { return len(conn) == 1 })
+	alertStruct.pushAlert(&pb.Alert{Sequence: 2})
+	waitFor(func() bool { return len(alertStruct.Pending) == 0 })
+
+	start := time.Now()
+	AlertLock.Lock()
+	alertStruct.pushAlert(&pb.Alert{Sequence: 3})
+	AlertLock.Unlock()
+	if time.Since(start) > 50*time.Millisecond {
+		t.Error("[FAIL] Waited for a blocking client while pushing alerts")
+		return
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	for seq := uint64(1); seq <= 3; seq++ {
+		select {
+		case alert := <-conn:
+			if alert.Sequence != seq {
+				t.Errorf("[FAIL] Expected alert %d, got %d", seq, alert.Sequence)
+				return
+			}
+		case <-time.After(time.Second):
+			t.Errorf("[FAIL] Blocking client lost alert %d (%d dropped)", seq, atomic.LoadUint64(alertStruct.Dropped))
+			return
+		}
+	}
+
+	alertStruct.pushAlert(&pb.Alert{Sequence: 4})
+	waitFor(func() bool { return len(conn) == 1 })
+	alertStruct.pushAlert(&pb.Alert{Sequence: 5})
+	if !waitFor(func() bool { return atomic.LoadUint64(alertStruct.Dropped) == 1 }) {
+		t.Errorf("[FAIL] Expected 1 dropped alert after the timeout, got %d", atomic.LoadUint64(alertStruct.Dropped))
+		return
+	}
+
+	ls.removeAlertStruct("blocking")
+	t.Log("[PASS] Counted dropped alerts")
+
+	// the next alert sent to the client reports the drops
+	reported := uint64(0)
+	alert := <-conn
+	if report := reportAlertDrops(alert, alertStruct.Dropped, &reported); report.Dropped != 1 || report.Sequence != 4 || alert.Dropped != 0 {
+		t.Errorf("[FAIL] Expected alert 4 to report 1 dropped alert, got %d (%d)", report.Sequence, report.Dropped)
+		return
+	}
+	if report := reportAlertDrops(alert, alertStruct.Dropped, &reported); report != alert {
+		t.Error("[FAIL] Reported dropped alerts twice")
+		return
+	}
+	t.Log("[PASS] Reported dropped alerts to the client")
+
+	// drop reports go to the WatchMessages streams of the same client only
+	MsgStructs = map[string]MsgStruct{}
+	MsgLock = &sync.RWMutex{}
+
+	affected, other := &ClientScope{Name: "affected"}, &ClientScope{Name: "other"}
+	ls.addMsgStruct("affected", make(chan *pb.Message, 1), "all", affected)
+	ls.addMsgStruct("other", make(chan *pb.Message, 1), "all", other)
+
+	fd := &Feeder{Node: &tp.Node{}}
+	fd.PushClientMessage(affected, "WARN", "Dropped 1 alerts for the client")
+
+	if len(MsgStructs["affected"].Broadcast) != 1 || len(MsgStructs["other"].Broadcast) != 0 {
+		t.Error("[FAIL] Expected the drop report on the stream of the affected client only")
+		return
+	}
+
+	if msg := <-MsgStructs["affected"].Broadcast; msg.Level != "WARN" || msg.Message != "Dropped 1 alerts for the client" {
+		t.Errorf("[FAIL] Unexpected drop report (%+v)", msg)
+		return
+	}
+
+	ls.removeMsgStruct("affected")
+	ls.removeMsgStruct("other")
+	t.Log("[PASS] Sent a drop report to the affected client")
+}
+
+func TestReplayBuffer(t *testing.T) {
+	rb := NewReplayBuffer(3)
+
+	for seq := uint64(1); seq <= 5; seq++ {
+		rb.Add(ReplayEvent{Sequence: seq})
+	}
+
+	if rb.Oldest() != 3 {
+		t.Errorf("[FAIL] Expected the oldest sequence 3, got %d", rb.Oldest())
+		return
+	}
+
+	events := rb.After(3)
+	if len(events) != 2 || events[0].Sequence != 4 || events[1].Sequence != 5 {
+		t.Errorf("[FAIL] Failed to replay events after 3 (%+v)", events)
+		return
+	}
+
+	if len(rb.After(5)) != 0 {
+		t.Error("[FAIL] Replayed events after the latest one")
+		return
+	}
+	t.Log("[PASS] Replayed events")
+
+	// resume from a sequence older than the buffer
+	AlertStructs = map[string]AlertStruct{}
+	AlertLock = &sync.RWMutex{}
+	AlertReplay = NewReplayBuffer(3)
+
+	for seq := uint64(1); seq <= 5; seq++ {
+		AlertReplay.Add(ReplayEvent{Sequence: seq, Event: &pb.Alert{Sequence: seq}})
+	}
+	AlertSequence = 5
+
+	ls := &LogService{}
+	replay, gap := ls.addAlertStruct("resume", make(chan *pb.Alert, 1), "all", nil, nil, 0, new(uint64), 1)
+	ls.removeAlertStruct("resume")
+
+	if len(replay) != 3 || gap != 3 {
+		t.Errorf("[FAIL] Expected 3 alerts and a gap before 3, got %d alerts and %d", len(replay), gap)
+		return
+	}
+
+	if marked := markAlertGap(replay[0], &gap); marked.OldestSequence != 3 || replay[0].OldestSequence != 0 || gap != 0 {
+		t.Errorf("[FAIL] Expected the first alert to mark the gap, got %d", marked.OldestSequence)
+		return
+	}
+
+	if marked := markAlertGap(replay[1], &gap); marked != replay[1] {
+		t.Error("[FAIL] Marked the gap twice")
+		return
+	}
+	t.Log("[PASS] Marked the gap of a resumed stream")
+
+	// resume from a sequence before a restart
+	replay, gap = ls.addAlertStruct("restart", make(chan *pb.Alert, 1), "all", nil, nil, 0, new(uint64), 100)
+	ls.removeAlertStruct("restart")
+
+	if len(replay) != 3 || replay[0].Sequence != 3 || gap != 3 {
+		t.Errorf("[FAIL] Expected 3 alerts from 3 and a gap before 3, got %d alerts and %d", len(replay), gap)
+		return
+	}
+
+	AlertReplay = NewReplayBuffer(3)
+	AlertSequence = 0
+
+	replay, gap = ls.addAlertStruct("restart", make(chan *pb.Alert, 1), "all", nil, nil, 0, new(uint64), 100)
+	ls.removeAlertStruct("restart")
+
+	if len(replay) != 0 || gap != 1 {
+		t.Errorf("[FAIL] Expected no alerts and a gap before 1, got %d alerts and %d", len(replay), gap)
+		return
+	}
+	t.Log("[PASS] Marked the gap of a stream resumed after a restart")
+}
+
+func writeSelfSignedCert(certPath, keyPath string, serial int64, modTime time.Time) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "kubearmor"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		return err
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600); err != nil {
+		return err
+	}
+
+	if err := os.Chtimes(certPath, modTime, modTime); err != nil {
+		return err
+	}
+	return os.Chtimes(keyPath, modTime, modTime)
+}
+
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "tls.crt")
+	keyPath := filepath.Join(dir, "tls.key")
+
+	if err := writeSelfSignedCert(certPath, keyPath, 1, time.Now().Add(-time.Minute)); err != nil {
+		t.Errorf("[FAIL] Failed to create a certificate (%s)", err.Error())
+		return
+	}
+
+	cr, err := NewCertReloader(certPath, keyPath, "")
+	if err != nil {
+		t.Errorf("[FAIL] Failed to load a certificate (%s)", err.Error())
+		return
+	}
+
+	config, _ := cr.GetConfigForClient(nil)
+	oldCert := config.Certificates[0].Certificate[0]
+
+	if err := writeSelfSignedCert(certPath, keyPath, 2, time.Now()); err != nil {
+		t.Errorf("[FAIL] Failed to create a certificate (%s)", err.Error())
+		return
+	}
+	cr.LastCheck = time.Time{}
+
+	config, _ = cr.GetConfigForClient(nil)
+	if bytes.Equal(oldCert, config.Certificates[0].Certificate[0]) {
+		t.Error("[FAIL] Failed to reload the changed certificate")
+		return
+	}
+	t.Log("[PASS] Reloaded the changed certificate")
+}
+
+func TestAuthorizer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "clients.yaml")
+	scopes := `
+clients:
+- name: tenant-a
+  tokens: ["token-a"]
+  namespaces: ["tenant-a"]
+- name: admin
+  tokens: ["token-admin"]
+  namespaces: ["*"]
+  hostLogs: true
+`
+	if err := ioutil.WriteFile(path, []byte(scopes), 0600); err != nil {
+		t.Errorf("[FAIL] Failed to write client scopes (%s)", err.Error())
+		return
+	}
+
+	az, err := LoadAuthorizer(path)
+	if err != nil {
+		t.Errorf("[FAIL] Failed to load client scopes (%s)", err.Error())
+		return
+	}
+
+	// unknown client
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer unknown"))
+	if _, err := az.Authenticate(ctx); err == nil {
+		t.Error("[FAIL] Authenticated an unknown client")
+		return
+	}
+
+	// tenant
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer token-a"))
+	scope, err := az.Authenticate(ctx)
+	if err != nil || scope.Name != "tenant-a" {
+		t.Error("[FAIL] Failed to authenticate a tenant")
+		return
+	}
+	if !scope.Allows("tenant-a") || scope.Allows("tenant-b") || scope.Allows("") {
+		t.Error("[FAIL] Tenant scope is not enforced")
+		return
+	}
+
+	// admin
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer token-admin"))
+	scope, err = az.Authenticate(ctx)
+	if err != nil || !scope.Allows("tenant-b") || !scope.Allows("") {
+		t.Error("[FAIL] Admin scope is not enforced")
+		return
+	}
+	t.Log("[PASS] Enforced client scopes")
+}
+
+func TestRotatingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubearmor-log")
+	if err != nil {
+		t.Errorf("[FAIL] Failed to create a temp directory (%s)", err.Error())
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	rf, err := NewRotatingFile(filepath.Join(dir, "kubearmor.log"), 16, 0, 2, false)
+	if err != nil {
+		t.Errorf("[FAIL] Failed to open a rotating file (%s)", err.Error())
+		return
+	}
+
+	for i := 0; i < 5; i++ {
+		if err := rf.WriteString("0123456789abcdef\n"); err != nil {
+			t.Errorf("[FAIL] Failed to write a line (%s)", err.Error())
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := rf.Close(); err != nil {
+		t.Errorf("[FAIL] Failed to close the rotating file (%s)", err.Error())
+		return
+	}
+
+	if backups := rf.GetBackups(); len(backups) != 2 {
+		t.Errorf("[FAIL] Expected 2 backups, got %d (%v)", len(backups), backups)
+		return
+	}
+	t.Log("[PASS] Rotated the log file")
+
+	// compress and prune many rotations in a row
+	rf, err = NewRotatingFile(filepath.Join(dir, "compressed.log"), 16, 0, 2, true)
+	if err != nil {
+		t.Errorf("[FAIL] Failed to open a rotating file (%s)", err.Error())
+		return
+	}
+
+	for i := 0; i < 20; i++ {
+		if err := rf.WriteString("0123456789abcdef\n"); err != nil {
+			t.Errorf("[FAIL] Failed to write a line (%s)", err.Error())
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := rf.Close(); err != nil {
+		t.Errorf("[FAIL] Failed to close the rotating file (%s)", err.Error())
+		return
+	}
+
+	backups := rf.GetBackups()
+	if len(backups) != 2 {
+		t.Errorf("[FAIL] Expected 2 compressed backups, got %d (%v)", len(backups), backups)
+		return
+	}
+	for _, backup := range backups {
+		if !strings.HasSuffix(backup, ".gz") {
+			t.Errorf("[FAIL] Expected a compressed backup, got %s", backup)
+			return
+		}
+	}
+	t.Log("[PASS] Compressed and pruned the rotated files")
+
+	// the file cannot be opened again after a rotation
+	path := filepath.Join(dir, "reopened.log")
+
+	rf, err = NewRotatingFile(path, 16, 0, 2, false)
+	if err != nil {
+		t.Errorf("[FAIL] Failed to open a rotating file (%s)", err.Error())
+		return
+	}
+
+	// the state after a rotation with a directory in place of the file
+	rf.Lock.Lock()
+	_ = rf.File.Close()
+	rf.File, rf.Writer = nil, nil
+	rf.Lock.Unlock()
+
+	if err := os.Remove(path); err != nil {
+		t.Errorf("[FAIL] Failed to remove the file (%s)", err.Error())
+		return
+	}
+	if err := os.Mkdir(path, 0700); err != nil {
+		t.Errorf("[FAIL] Failed to create a directory (%s)", err.Error())
+		return
+	}
+
+	if err := rf.WriteString("0123456789abcdef\n"); err == nil {
+		t.Error("[FAIL] Wrote a line without an open file")
+		return
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Errorf("[FAIL] Failed to remove the directory (%s)", err.Error())
+		return
+	}
+
+	if err := rf.WriteString("0123456789abcdef\n"); err != nil {
+		t.Errorf("[FAIL] Failed to write a line after reopening the file (%s)", err.Error())
+		return
+	}
+
+	if err := rf.Close(); err != nil {
+		t.Errorf("[FAIL] Failed to close the rotating file (%s)", err.Error())
+		return
+	}
+
+	if data, err := ioutil.ReadFile(path); err != nil || string(data) != "0123456789abcdef\n" {
+		t.Errorf("[FAIL] Unexpected content of the reopened file (%q)", string(data))
+		return
+	}
+
+	if err := rf.WriteString("0123456789abcdef\n"); err == nil {
+		t.Error("[FAIL] Wrote a line after closing the file")
+		return
+	}
+	t.Log("[PASS] Reopened the log file after a failed rotation")
+}
+
+func TestHashChain(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubearmor-log")
+	if err != nil {
+		t.Errorf("[FAIL] Failed to create a temp directory (%s)", err.Error())
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Errorf("[FAIL] Failed to generate a key (%s)", err.Error())
+		return
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Errorf("[FAIL] Failed to encode the key (%s)", err.Error())
+		return
+	}
+
+	keyPath := filepath.Join(dir, "signing.key")
+	if err := ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Errorf("[FAIL] Failed to write the key (%s)", err.Error())
+		return
+	}
+
+	signingKey, err := LoadSigningKey(keyPath)
+	if err != nil {
+		t.Errorf("[FAIL] Failed to load the signing key (%s)", err.Error())
+		return
+	}
+
+	// write chained lines with rotation and a restart in the middle
+	logPath := filepath.Join(dir, "kubearmor.log")
+
+	for run := 0; run < 2; run++ {
+		rf, err := NewRotatingFile(logPath, 512, 0, 0, false)
+		if err != nil {
+			t.Errorf("[FAIL] Failed to open a rotating file (%s)", err.Error())
+			return
+		}
+
+		if err := rf.EnableHashChain(NewHashChain(signingKey, time.Minute)); err != nil {
+			t.Errorf("[FAIL] Failed to enable the hash chain (%s)", err.Error())
+			return
+		}
+
+		for i := 0; i < 5; i++ {
+			if err := rf.WriteString(fmt.Sprintf("{\"run\":%d,\"line\":%d}\n{\"run\":%d,\"line\":%d}\n", run, 2*i, run, 2*i+1)); err != nil {
+				t.Errorf("[FAIL] Failed to write a line (%s)", err.Error())
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+
+		if err := rf.Close(); err != nil {
+			t.Errorf("[FAIL] Failed to close the rotating file (%s)", err.Error())
+			return
+		}
+	}
+
+	backups, _ := filepath.Glob(logPath + ".*")
+	if len(backups) == 0 {
+		t.Error("[FAIL] The hash-chained log file was not rotated")
+		return
+	}
+
+	report, err := VerifyLogFiles(append(backups, logPath), publicKey)
+	if err != nil {
+		t.Errorf("[FAIL] Failed to verify the hash-chained log files (%s)", err.Error())
+		return
+	}
+	if report.Records != 20 || report.FirstSequence != 1 || report.LastSequence != 20 || report.Unanchored != 0 || len(report.Gaps) != 0 {
+		t.Errorf("[FAIL] Unexpected verification report (%+v)", *report)
+		return
+	}
+	t.Log("[PASS] Verified the hash-chained log files across rotation and restart")
+
+	// tamper with the records
+	data, err := ioutil.ReadFile(filepath.Clean(logPath))
+	if err != nil {
+		t.Errorf("[FAIL] Failed to read the log file (%s)", err.Error())
+		return
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) < 4 || !strings.HasPrefix(lines[0], "#checkpoint event=open ") {
+		t.Errorf("[FAIL] Unexpected log file (%s)", string(data))
+		return
+	}
+
+	tampered := map[string]func(lines []string) []string{
+		"modified": func(lines []string) []string {
+			lines[2] = strings.Replace(lines[2], "\"line\"", "\"Line\"", 1)
+			return lines
+		},
+		"deleted": func(lines []string) []string {
+			return append(lines[:2], lines[3:]...)
+		},
+		"reordered": func(lines []string) []string {
+			lines[1], lines[2] = lines[2], lines[1]
+			return lines
+		},
+		"truncated": func(lines []string) []string {
+			return lines[2:]
+		},
+	}
+
+	for name, tamper := range tampered {
+		path := filepath.Join(dir, name+".log")
+
+		copied := append([]string{}, lines...)
+		if err := ioutil.WriteFile(path, []byte(strings.Join(tamper(copied), "\n")+"\n"), 0600); err != nil {
+			t.Errorf("[FAIL] Failed to write the %s log file (%s)", name, err.Error())
+			return
+		}
+
+		if _, err := VerifyLogFiles(append(backups, path), publicKey); err == nil {
+			t.Errorf("[FAIL] Failed to detect %s lines", name)
+			return
+		}
+	}
+	t.Log("[PASS] Detected modified, deleted, reordered and truncated lines")
+
+	// verify with another key
+	otherKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	if _, err := VerifyLogFiles([]string{logPath}, otherKey); err == nil {
+		t.Error("[FAIL] Accepted checkpoints signed with another key")
+		return
+	}
+	t.Log("[PASS] Rejected checkpoints signed with another key")
+
+	// append a forged record chained to the last checkpoint, and restart
+	last, err := parseCheckpoint(lines[len(lines)-1])
+	if err != nil || last.Event != CheckpointClose {
+		t.Errorf("[FAIL] The log file does not end with a close checkpoint (%s)", lines[len(lines)-1])
+		return
+	}
+
+	forged := "{\"forged\":true}"
+	forged = forged + chainSuffix + "21:" + hex.EncodeToString(hashRecord(last.Hash, 21, forged))
+
+	if err := ioutil.WriteFile(logPath, []byte(strings.Join(lines, "\n")+"\n"+forged+"\n"), 0600); err != nil {
+		t.Errorf("[FAIL] Failed to append a forged record (%s)", err.Error())
+		return
+	}
+
+	rf, err := NewRotatingFile(logPath, 0, 0, 0, false)
+	if err != nil {
+		t.Errorf("[FAIL] Failed to open a rotating file (%s)", err.Error())
+		return
+	}
+
+	if err := rf.EnableHashChain(NewHashChain(signingKey, time.Minute)); err != nil {
+		t.Errorf("[FAIL] Failed to enable the hash chain (%s)", err.Error())
+		return
+	}
+
+	if err := rf.WriteString("{\"run\":2}\n"); err != nil {
+		t.Errorf("[FAIL] Failed to write a line (%s)", err.Error())
+		return
+	}
+
+	if err := rf.Close(); err != nil {
+		t.Errorf("[FAIL] Failed to close the rotating file (%s)", err.Error())
+		return
+	}
+
+	report, err = VerifyLogFiles(append(backups, logPath), publicKey)
+	if err != nil {
+		t.Errorf("[FAIL] Failed to verify the recovered log files (%s)", err.Error())
+		return
+	}
+	if len(report.Gaps) != 1 || report.Gaps[0].Anchor != 20 || report.Gaps[0].Sequence != 21 || report.LastSequence != 22 {
+		t.Errorf("[FAIL] Unexpected verification report after recovery (%+v)", *report)
+		return
+	}
+	t.Log("[PASS] Reported records that were not covered by a signed checkpoint on restart")
+}
+
+func TestRedactor(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubearmor-redaction")
+	if err != nil {
+		t.Errorf("[FAIL] Failed to create a temp directory (%s)", err.Error())
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	config := `
+rules:
+  - name: passwords
+    detector: password-flag
+  - name: connection-strings
+    detector: url-credentials
+    action: hash
+  - name: internal-tokens
+    pattern: 'tok_[a-z0-9]{8}'
+    replacement: '<token>'
+dropArgs:
+  namespaceNames: [payments]
+hashSalt: kubearmor
+`
+
+	path := filepath.Join(dir, "redaction.yaml")
+	if err := ioutil.WriteFile(path, []byte(config), 0600); err != nil {
+		t.Errorf("[FAIL] Failed to write the redaction rules (%s)", err.Error())
+		return
+	}
+
+	redactor, err := LoadRedactor(path)
+	if err != nil {
+		t.Errorf("[FAIL] Failed to load the redaction rules (%s)", err.Error())
+		return
+	}
+
+	execArgs := []string{"mysql", "-uroot", "-pSECRET", "mysql://root:hunter2@db/app"}
+
+	log := redactor.Redact(tp.Log{
+		NamespaceName: "default",
+		Operation:     "Process",
+		Source:        "/bin/sh -c curl -H tok_abcd1234",
+		Resource:      "/usr/bin/mysql -uroot -pSECRET mysql://root:hunter2@db/app",
+		ExecArgs:      execArgs,
+		Ancestors:     []tp.Ancestor{{HostPID: 1, ExecPath: "/bin/sh", Args: "-c mysql --password SECRET"}},
+	})
+
+	hashed := "sha256:" + func() string {
+		sum := sha256.Sum256([]byte("kubearmorhunter2"))
+		return hex.EncodeToString(sum[:])[:16]
+	}()
+
+	if log.Resource != "/usr/bin/mysql -uroot -p*** mysql://root:"+hashed+"@db/app" {
+		t.Errorf("[FAIL] Unexpected redacted resource (%s)", log.Resource)
+		return
+	}
+	if log.Source != "/bin/sh -c curl -H <token>" {
+		t.Errorf("[FAIL] Unexpected redacted source (%s)", log.Source)
+		return
+	}
+	if log.ExecArgs[2] != "-p***" || execArgs[2] != "-pSECRET" {
+		t.Errorf("[FAIL] Unexpected redacted arguments (%v, %v)", log.ExecArgs, execArgs)
+		return
+	}
+	if log.Ancestors[0].Args != "-c mysql --password ***" {
+		t.Errorf("[FAIL] Unexpected redacted ancestor (%s)", log.Ancestors[0].Args)
+		return
+	}
+	if redactor.RedactString("mkdir -p /tmp/dir") != "mkdir -p /tmp/dir" {
+		t.Error("[FAIL] Redacted a command without secrets")
+		return
+	}
+	t.Log("[PASS] Redacted secrets in an event")
+
+	// secrets in separate arguments
+	split, err := NewRedactor(RedactionConfig{Rules: []RedactionRule{
+		{Name: "passwords", Detector: "password-flag"},
+		{Name: "tokens", Detector: "bearer-token"},
+	}})
+	if err != nil {
+		t.Errorf("[FAIL] Failed to create a redactor (%s)", err.Error())
+		return
+	}
+
+	log = split.Redact(tp.Log{NamespaceName: "default", ExecArgs: []string{"mysql", "--password", "SECRET", "app"}})
+	if strings.Join(log.ExecArgs, "|") != "mysql|--password|***|app" {
+		t.Errorf("[FAIL] Unexpected redacted arguments (%v)", log.ExecArgs)
+		return
+	}
+
+	log = split.Redact(tp.Log{NamespaceName: "default", ExecArgs: []string{"curl", "-H", "Authorization: Bearer", "TOKEN", "https://example.com"}})
+	if strings.Join(log.ExecArgs, "|") != "curl|-H|Authorization: Bearer|***|https://example.com" {
+		t.Errorf("[FAIL] Unexpected redacted arguments (%v)", log.ExecArgs)
+		return
+	}
+	t.Log("[PASS] Redacted secrets following a flag in a separate argument")
+
+	log = redactor.Redact(tp.Log{
+		NamespaceName: "payments",
+		Operation:     "Process",
+		Source:        "/bin/sh -c run",
+		Resource:      "/usr/bin/app --card 4111111111111111",
+		ExecArgs:      []string{"app", "--card", "4111111111111111"},
+		Ancestors:     []tp.Ancestor{{HostPID: 1, ExecPath: "/bin/sh", Args: "-c run"}},
+	})
+
+	if log.Source != "/bin/sh" || log.Resource != "/usr/bin/app" || log.ExecArgs != nil || log.Ancestors[0].Args != "" {
+		t.Errorf("[FAIL] Failed to drop the arguments (%+v)", log)
+		return
+	}
+	t.Log("[PASS] Dropped the arguments of a namespace")
+
+	if _, err := NewRedactor(RedactionConfig{Rules: []RedactionRule{{Name: "unknown", Detector: "credit-card"}}}); err == nil {
+		t.Error("[FAIL] Accepted an unknown detector")
+		return
+	}
+	t.Log("[PASS] Rejected an unknown detector")
+}
+
+type fakeK8sEventClient struct {
+	PodLookups int
+	Created    []*corev1.Event
+	Updated    map[string]int32
+	Expired    map[string]bool
+}
+
+func (fc *fakeK8sEventClient) GetPodUID(namespaceName, podName string) (k8stypes.UID, error) {
+	fc.PodLookups++
+	return k8stypes.UID("uid-" + podName), nil
+}
+
+func (fc *fakeK8sEventClient) CreateEvent(event *corev1.Event) (*corev1.Event, error) {
+	created := event.DeepCopy()
+	created.Name = event.GenerateName + strconv.Itoa(len(fc.Created))
+	fc.Created = append(fc.Created, created)
+	return created, nil
+}
+
+func (fc *fakeK8sEventClient) UpdateEventCount(namespaceName, eventName string, count int32, lastTimestamp time.Time) error {
+	if fc.Expired[eventName] {
+		return apierrors.NewNotFound(corev1.Resource("events"), eventName)
+	}
+	fc.Updated[eventName] = count
+	return nil
+}
+
+func TestK8sEventExporter(t *testing.T) {
+	client := &fakeK8sEventClient{Updated: map[string]int32{}, Expired: map[string]bool{}}
+
+	ke, err := NewK8sEventExporter(ExporterSpec{Name: "k8s-events", Type: "k8sEvents"}, client, "node-1")
+	if err != nil {
+		t.Errorf("[FAIL] Failed to create a Kubernetes Event exporter (%s)", err.Error())
+		return
+	}
+
+	blocked := tp.Log{Type: "MatchedPolicy", Action: "Block", NamespaceName: "default", PodName: "nginx-1", ContainerName: "nginx",
+		PolicyName: "block-curl", Operation: "Process", Resource: "/usr/bin/curl"}
+	audited := blocked
+	audited.Action = "Audit"
+	host := blocked
+	host.Type = "MatchedHostPolicy"
+	host.NamespaceName, host.PodName = "", ""
+
+	if !ke.Select(blocked) || ke.Select(audited) || ke.Select(host) {
+		t.Error("[FAIL] Failed to select blocked operations in pods")
+		return
+	}
+	t.Log("[PASS] Selected blocked operations in pods")
+
+	other := blocked
+	other.Resource = "/usr/bin/wget"
+
+	if err := ke.Export([]tp.Log{blocked, blocked, other, blocked}); err != nil {
+		t.Errorf("[FAIL] Failed to export alerts (%s)", err.Error())
+		return
+	}
+
+	if len(client.Created) != 2 || client.PodLookups != 1 {
+		t.Errorf("[FAIL] Expected 2 Events with 1 pod lookup, got %d Events with %d lookups", len(client.Created), client.PodLookups)
+		return
+	}
+
+	event := client.Created[0]
+	if event.Message == GetK8sEventMessage(other) {
+		event = client.Created[1]
+	}
+
+	if event.Count != 3 || event.Type != corev1.EventTypeWarning || event.Reason != K8sEventReason ||
+		event.InvolvedObject.Kind != "Pod" || event.InvolvedObject.UID != "uid-nginx-1" || event.InvolvedObject.FieldPath != "spec.containers{nginx}" ||
+		event.Message != "Blocked Process /usr/bin/curl by policy block-curl (container: nginx)" {
+		t.Errorf("[FAIL] Unexpected Event (%+v)", *event)
+		return
+	}
+	t.Log("[PASS] Created aggregated Events")
+
+	if err := ke.Export([]tp.Log{blocked, blocked}); err != nil {
+		t.Errorf("[FAIL] Failed to export alerts (%s)", err.Error())
+		return
+	}
+
+	if len(client.Created) != 2 || client.Updated[event.Name] != 5 {
+		t.Errorf("[FAIL] Expected the count of %s to be updated to 5 (%v)", event.Name, client.Updated)
+		return
+	}
+	t.Log("[PASS] Updated the count of an Event")
+
+	// the Event has been removed by the API server
+	client.Expired[event.Name] = true
+
+	if err := ke.Export([]tp.Log{blocked}); err != nil {
+		t.Errorf("[FAIL] Failed to export alerts (%s)", err.Error())
+		return
+	}
+
+	if len(client.Created) != 3 || client.Created[2].Count != 6 {
+		t.Errorf("[FAIL] Failed to recreate an expired Event (%d Events)", len(client.Created))
+		return
+	}
+	t.Log("[PASS] Recreated an expired Event")
+
+	// rate limit
+	limited := &fakeK8sEventClient{Updated: map[string]int32{}, Expired: map[string]bool{}}
+
+	ke, err = NewK8sEventExporter(ExporterSpec{Name: "k8s-events", Type: "k8sEvents", K8sEvents: &K8sEventsSpec{RateLimit: 1}}, limited, "node-1")
+	if err != nil {
+		t.Errorf("[FAIL] Failed to create a Kubernetes Event exporter (%s)", err.Error())
+		return
+	}
+
+	logs := []tp.Log{}
+	for i := 0; i < 5; i++ {
+		log := blocked
+		log.Resource = "/usr/bin/tool" + strconv.Itoa(i)
+		logs = append(logs, log)
+	}
+
+	if err := ke.Export(logs); err != nil {
+		t.Errorf("[FAIL] Failed to export alerts (%s)", err.Error())
+		return
+	}
+
+	if len(limited.Created) != 1 {
+		t.Errorf("[FAIL] Expected 1 Event under the rate limit, got %d", len(limited.Created))
+		return
+	}
+	t.Log("[PASS] Limited the rate of Kubernetes Event requests")
+
+	// deferred alerts are retried on flush without new alerts
+	for i := 0; i < 4; i++ {
+		ke.Limiter.LastRefill = ke.Limiter.LastRefill.Add(-1 * time.Second)
+		if err := ke.Flush(); err != nil {
+			t.Errorf("[FAIL] Failed to flush deferred alerts (%s)", err.Error())
+			return
+		}
+	}
+
+	if len(limited.Created) != 5 {
+		t.Errorf("[FAIL] Expected 5 Events after retrying deferred alerts, got %d", len(limited.Created))
+		return
+	}
+	t.Log("[PASS] Retried deferred Kubernetes Events")
+}
+
+func TestPolicySimulator(t *testing.T) {
+	policies := `apiVersion: security.kubearmor.com/v1
+kind: KubeArmorPolicy
+metadata:
+  name: block-curl
+spec:
+  selector:
+    matchLabels:
+      app: nginx
+  process:
+    matchPaths:
+    - path: /usr/bin/curl
+  action: Block
+---
+apiVersion: security.kubearmor.com/v1
+kind: KubeArmorPolicy
+metadata:
+  name: allow-config
+  namespace: web
+spec:
+  selector:
+    matchLabels:
+      app: web
+  file:
+    matchDirectories:
+    - dir: /etc/web/
+      recursive: true
+    - dir: /var/web/
+  action: Allow
+`
+
+	secPolicies, err := policy.ParseSecurityPolicies([]byte(policies))
+	if err != nil || len(secPolicies) != 2 {
+		t.Errorf("[FAIL] Failed to parse policies (%v)", err)
+		return
+	}
+	t.Log("[PASS] Parsed policies without a cluster")
+
+	ps := NewPolicySimulator(secPolicies, tp.DefaultPosture{FileAction: "block", NetworkAction: "block", CapabilitiesAction: "block"})
+
+	curl := tp.Log{ContainerID: "c1", NamespaceName: "default", PodName: "nginx-1", Labels: "app=nginx",
+		Operation: "Process", Resource: "/usr/bin/curl http://example.com", ProcessName: "/usr/bin/curl", Result: "Passed"}
+	otherPod := curl
+	otherPod.ContainerID, otherPod.PodName, otherPod.Labels = "c2", "redis-1", "app=redis"
+	config := tp.Log{ContainerID: "c3", NamespaceName: "web", PodName: "web-1", Labels: "app=web",
+		Operation: "File", Resource: "/etc/web/web.conf", ProcessName: "/bin/web", Result: "Passed"}
+	secret := config
+	secret.Resource = "/etc/shadow"
+	host := curl
+	host.ContainerID, host.NamespaceName, host.PodName = "", "", ""
+
+	expected := []struct {
+		Log        tp.Log
+		Decision   string
+		PolicyName string
+	}{
+		{curl, SimulationBlock, "block-curl"},
+		{otherPod, SimulationNone, ""},
+		{config, SimulationAllow, "allow-config"},
+		{secret, SimulationBlock, "DefaultPosture"},
+	}
+
+	for _, exp := range expected {
+		result, ok := ps.Simulate(exp.Log)
+		if !ok || result.Decision != exp.Decision || result.PolicyName != exp.PolicyName {
+			t.Errorf("[FAIL] Expected %s by %q for %s %s, got %s by %q", exp.Decision, exp.PolicyName, exp.Log.PodName, exp.Log.Resource, result.Decision, result.PolicyName)
+			return
+		}
+	}
+
+	if _, ok := ps.Simulate(host); ok {
+		t.Error("[FAIL] Simulated a host event")
+		return
+	}
+	t.Log("[PASS] Simulated recorded events")
+
+	// without combined allow rules, the other allow rules of the policy report the event
+	ps.Feeder.CombineAllowRules = false
+	if result, _ := ps.Simulate(config); result.Decision == SimulationAllow {
+		t.Errorf("[FAIL] Expected %s to violate /var/web/, got %s by %q", config.Resource, result.Decision, result.PolicyName)
+		return
+	}
+	ps.Feeder.CombineAllowRules = true
+	t.Log("[PASS] Combined allow rules only in simulation")
+
+	// replay a log file (hash-chained lines and checkpoints included)
+	lines := []string{"#checkpoint event=open seq=0"}
+	for i, log := range []tp.Log{curl, curl, otherPod, config, secret, host} {
+		arr, _ := json.Marshal(log)
+		lines = append(lines, fmt.Sprintf("%s #chain=%d:%s", string(arr), i+1, strings.Repeat("0", 64)))
+	}
+	lines = append(lines, "not json")
+
+	path := filepath.Join(t.TempDir(), "kubearmor.log")
+	if err := ioutil.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0600); err != nil {
+		t.Errorf("[FAIL] Failed to write a log file (%s)", err.Error())
+		return
+	}
+
+	report, err := NewPolicySimulator(secPolicies, ps.DefaultPosture).SimulateLogFiles([]string{path}, true)
+	if err != nil {
+		t.Errorf("[FAIL] Failed to replay a log file (%s)", err.Error())
+		return
+	}
+
+	if report.Events != 5 || report.Skipped != 2 || report.Decisions[SimulationBlock] != 3 || report.Decisions[SimulationAllow] != 1 ||
+		len(report.Results) != 3 || len(report.Rules) != 3 || report.Rules[0].PolicyName != "block-curl" || report.Rules[0].Count != 2 ||
+		report.Rules[0].Rule != "Process path /usr/bin/curl (Block)" {
+		t.Errorf("[FAIL] Unexpected simulation report (%+v)", *report)
+		return
+	}
+	t.Log("[PASS] Replayed a log file")
+}
+
+func TestPolicyLearner(t *testing.T) {
+	fd := newTestFeeder()
+	fd.Learner = NewPolicyLearner(4, 100)
+
+	base := tp.Log{ContainerID: "c1", NamespaceName: "default", PodName: "web-1", Labels: "app=web,tier=frontend",
+		Source: "/bin/web", ProcessName: "/bin/web", Result: "Passed", PolicyEnabled: tp.KubeArmorPolicyLearning,
+		ProcessVisibilityEnabled: true, FileVisibilityEnabled: true, NetworkVisibilityEnabled: true, CapabilitiesVisibilityEnabled: true}
+
+	logs := []tp.Log{}
+
+	for _, path := range []string{"/bin/web -c /etc/web/web.conf", "/bin/sh -c true"} {
+		log := base
+		log.Operation, log.Resource = "Process", path
+		logs = append(logs, log)
+	}
+
+	// 4 siblings in /usr/share/web/static/ and 1 in /usr/share/web/static/img/ -> collapsed recursively
+	for _, path := range []string{"/usr/share/web/static/a.js", "/usr/share/web/static/b.js", "/usr/share/web/static/c.css", "/usr/share/web/static/img/logo.png", "/etc/web/web.conf"} {
+		log := base
+		log.Operation, log.Resource, log.Data = "File", path, "syscall=SYS_OPENAT fd=-100 flags=O_RDONLY"
+		logs = append(logs, log)
+	}
+
+	log := base
+	log.Operation, log.Resource, log.Data = "File", "/var/log/web.log", "syscall=SYS_OPENAT fd=-100 flags=O_WRONLY|O_APPEND"
+	logs = append(logs, log)
+
+	log = base
+	log.Operation, log.Resource = "Network", "domain=AF_INET type=SOCK_STREAM protocol=TCP"
+	log.SocketType, log.SocketProtocol = "SOCK_STREAM", "TCP"
+	logs = append(logs, log)
+
+	log = base
+	log.Operation, log.Resource = "Capabilities", "CAP_NET_BIND_SERVICE"
+	logs = append(logs, log)
+
+	// not in learning mode
+	log = base
+	log.PodName, log.PolicyEnabled = "web-2", tp.KubeArmorPolicyEnabled
+	log.Operation, log.Resource = "Process", "/bin/ls"
+	logs = append(logs, log)
+
+	for _, log := range logs {
+		fd.processLog(log)
+	}
+
+	if _, ok := fd.Learner.Profiles["default/web-2"]; ok || len(fd.Learner.Profiles) != 1 {
+		t.Error("[FAIL] Recorded a pod not in learning mode")
+		return
+	}
+	t.Log("[PASS] Recorded container logs in learning mode")
+
+	if _, err := fd.Learner.GeneratePolicy("default", "web-2", 0); err != ErrNoLearnedProfile {
+		t.Errorf("[FAIL] Expected no learned profile, got %v", err)
+		return
+	}
+
+	policyYAML, err := fd.Learner.GeneratePolicy("default", "web-1", 0)
+	if err != nil {
+		t.Errorf("[FAIL] Failed to generate a policy (%s)", err.Error())
+		return
+	}
+
+	secPolicies, err := policy.ParseSecurityPolicies([]byte(policyYAML))
+	if err != nil || len(secPolicies) != 1 {
+		t.Errorf("[FAIL] Failed to parse the learned policy (%v)\n%s", err, policyYAML)
+		return
+	}
+
+	spec := secPolicies[0].Spec
+	if secPolicies[0].Metadata["policyName"] != "learned-web-1" || spec.Action != "Allow" || spec.Selector.MatchLabels["tier"] != "frontend" ||
+		len(spec.Process.MatchPaths) != 2 || len(spec.Network.MatchProtocols) != 1 || spec.Network.MatchProtocols[0].Protocol != "tcp" ||
+		len(spec.Capabilities.MatchCapabilities) != 1 || spec.Capabilities.MatchCapabilities[0].Capability != "net_bind_service" ||
+		len(spec.File.MatchDirectories) != 1 || spec.File.MatchDirectories[0].Directory != "/usr/share/web/static/" ||
+		!spec.File.MatchDirectories[0].Recursive || !spec.File.MatchDirectories[0].ReadOnly || len(spec.File.MatchPaths) != 2 {
+		t.Errorf("[FAIL] Unexpected learned policy\n%s", policyYAML)
+		return
+	}
+
+	for _, path := range spec.File.MatchPaths {
+		if path.ReadOnly != (path.Path == "/etc/web/web.conf") {
+			t.Errorf("[FAIL] Unexpected read-only flag of %s", path.Path)
+			return
+		}
+	}
+	t.Log("[PASS] Generated an allow-list policy with collapsed directories")
+
+	// the learned behavior is allowed by the learned policy
+	ps := NewPolicySimulator(secPolicies, tp.DefaultPosture{FileAction: "block", NetworkAction: "block", CapabilitiesAction: "block"})
+	for _, log := range logs[:len(logs)-1] {
+		if log.Operation == "Capabilities" {
+			continue // no capability rules are matched in the feeder
+		}
+		if result, _ := ps.Simulate(log); result.Decision != SimulationAllow {
+			t.Errorf("[FAIL] Expected %s %s to be allowed, got %s by %s", log.Operation, log.Resource, result.Decision, result.PolicyName)
+			return
+		}
+	}
+
+	log = base
+	log.Operation, log.Resource = "Process", "/usr/bin/curl"
+	if result, _ := ps.Simulate(log); result.Decision != SimulationBlock {
+		t.Errorf("[FAIL] Expected unlearned behavior to be blocked, got %s", result.Decision)
+		return
+	}
+	t.Log("[PASS] Simulated the learned policy")
+
+	fd.Learner.Forget("default", "web-1")
+	if len(fd.Learner.Profiles) != 0 {
+		t.Error("[FAIL] Failed to forget a deleted pod")
+		return
+	}
+	t.Log("[PASS] Forgot a deleted pod")
+}
+
+func TestEndpointMatcher(t *testing.T) {
+	policies := `apiVersion: security.kubearmor.com/v1
+kind: KubeArmorPolicy
+metadata:
+  name: block-db
+spec:
+  selector:
+    matchLabels:
+      app: api
+  network:
+    matchEndpoints:
+    - cidr: 10.0.0.0/8
+      ports:
+      - "5432"
+      - 8000-8080
+    - cidr: 192.168.0.0/16, fd00::/8
+      direction: accept
+      fromSource:
+      - path: /bin/api
+      action: Audit
+    - cidr: 10.0.0.300/8
+  action: Block
+---
+apiVersion: security.kubearmor.com/v1
+kind: KubeArmorPolicy
+metadata:
+  name: allow-internal
+  namespace: web
+spec:
+  selector:
+    matchLabels:
+      app: web
+  network:
+    matchEndpoints:
+    - cidr: 10.0.0.0/8
+  action: Allow
+`
+
+	secPolicies, err := policy.ParseSecurityPolicies([]byte(policies))
+	if err != nil || len(secPolicies) != 2 || len(secPolicies[0].Spec.Network.MatchEndpoints) != 4 ||
+		secPolicies[0].Spec.Network.MatchEndpoints[0].Direction != "connect" {
+		t.Errorf("[FAIL] Failed to parse endpoint rules (%v)", err)
+		return
+	}
+	t.Log("[PASS] Parsed endpoint rules")
+
+	ps := NewPolicySimulator(secPolicies, tp.DefaultPosture{FileAction: "block", NetworkAction: "block", CapabilitiesAction: "block"})
+
+	endpoint := func(pod, labels, data, ip string, port int32) tp.Log {
+		return tp.Log{ContainerID: "c-" + pod, NamespaceName: "default", PodName: pod, Labels: labels,
+			Operation: "Network", Data: data, RemoteIP: ip, RemotePort: port, ProcessName: "/bin/api", Result: "Passed"}
+	}
+
+	connect := "syscall=SYS_CONNECT fd=3"
+	accept := "kprobe=tcp_accept domain=AF_INET"
+
+	otherSource := endpoint("api-1", "app=api", accept, "192.168.3.4", 40000)
+	otherSource.ProcessName = "/bin/other"
+
+	socket := endpoint("web-1", "app=web", "syscall=SYS_SOCKET", "", 0)
+	socket.NamespaceName, socket.Resource = "web", "domain=AF_INET type=SOCK_STREAM protocol=TCP"
+
+	internal := endpoint("web-1", "app=web", "kprobe=tcp_connect domain=AF_INET", "10.1.2.3", 443)
+	internal.NamespaceName = "web"
+
+	external := internal
+	external.RemoteIP = "8.8.8.8"
+
+	expected := []struct {
+		Log        tp.Log
+		Decision   string
+		PolicyName string
+	}{
+		{endpoint("api-1", "app=api", connect, "10.1.2.3", 5432), SimulationBlock, "block-db"},
+		{endpoint("api-1", "app=api", connect, "10.1.2.3", 8080), SimulationBlock, "block-db"},
+		{endpoint("api-1", "app=api", connect, "10.1.2.3", 443), SimulationNone, ""},
+		{endpoint("api-1", "app=api", connect, "172.16.0.1", 5432), SimulationNone, ""},
+		{endpoint("api-1", "app=api", accept, "10.1.2.3", 5432), SimulationNone, ""},
+		{endpoint("api-1", "app=api", accept, "192.168.3.4", 40000), SimulationAudit, "block-db"},
+		{endpoint("api-1", "app=api", accept, "fd00::1", 40000), SimulationAudit, "block-db"},
+		{otherSource, SimulationNone, ""},
+		{socket, SimulationNone, ""},
+		{internal, SimulationAllow, "allow-internal"},
+		{external, SimulationBlock, "DefaultPosture"},
+	}
+
+	for _, exp := range expected {
+		result, ok := ps.Simulate(exp.Log)
+		if !ok || result.Decision != exp.Decision || result.PolicyName != exp.PolicyName {
+			t.Errorf("[FAIL] Expected %s by %q for %s %s:%d, got %s by %q", exp.Decision, exp.PolicyName, exp.Log.Data, exp.Log.RemoteIP, exp.Log.RemotePort, result.Decision, result.PolicyName)
+			return
+		}
+	}
+	t.Log("[PASS] Matched remote addresses, ports, directions and sources")
+
+	result, _ := ps.Simulate(expected[0].Log)
+	if result.Rule != "Network endpoint cidr=10.0.0.0/8 ports=5432,8000-8080 direction=connect (Block)" {
+		t.Errorf("[FAIL] Unexpected rule %q", result.Rule)
+		return
+	}
+	t.Log("[PASS] Reported the matched endpoint rule")
+}
+
+func TestListenerMatcher(t *testing.T) {
+	policies := `apiVersion: security.kubearmor.com/v1
+kind: KubeArmorPolicy
+metadata:
+  name: block-backdoor
+spec:
+  selector:
+    matchLabels:
+      app: api
+  network:
+    matchListeners:
+    - ports:
+      - "4444"
+      - "31337"
+  action: Block
+---
+apiVersion: security.kubearmor.com/v1
+kind: KubeArmorPolicy
+metadata:
+  name: allow-http
+  namespace: web
+spec:
+  selector:
+    matchLabels:
+      app: web
+  network:
+    matchProtocols:
+    - protocol: tcp
+    matchListeners:
+    - ports:
+      - "8080"
+    - address: 127.0.0.1, ::1
+      ports:
+      - "9090"
+    - address: 10.0.0.300
+  action: Allow
+`
+
+	secPolicies, err := policy.ParseSecurityPolicies([]byte(policies))
+	if err != nil || len(secPolicies) != 2 || len(secPolicies[1].Spec.Network.MatchListeners) != 4 ||
+		secPolicies[1].Spec.Network.MatchListeners[2].Address != "::1" {
+		t.Errorf("[FAIL] Failed to parse listener rules (%v)", err)
+		return
+	}
+	t.Log("[PASS] Parsed listener rules")
+
+	ps := NewPolicySimulator(secPolicies, tp.DefaultPosture{FileAction: "block", NetworkAction: "block", CapabilitiesAction: "block"})
+
+	listener := func(pod, labels, data, ip string, port int32) tp.Log {
+		namespace := "default"
+		if labels == "app=web" {
+			namespace = "web"
+		}
+		return tp.Log{ContainerID: "c-" + pod, NamespaceName: namespace, PodName: pod, Labels: labels,
+			Operation: "Network", Data: data, LocalIP: ip, LocalPort: port, ProcessName: "/bin/server", Result: "Passed"}
+	}
+
+	bind := "syscall=SYS_BIND fd=3"
+	listen := "syscall=SYS_LISTEN fd=3"
+
+	socket := listener("web-1", "app=web", "syscall=SYS_SOCKET", "", 0)
+	socket.Resource = "domain=AF_INET type=SOCK_STREAM protocol=TCP"
+
+	expected := []struct {
+		Log        tp.Log
+		Decision   string
+		PolicyName string
+	}{
+		{listener("api-1", "app=api", bind, "0.0.0.0", 4444), SimulationBlock, "block-backdoor"},
+		{listener("api-1", "app=api", bind, "::", 31337), SimulationBlock, "block-backdoor"},
+		{listener("api-1", "app=api", bind, "0.0.0.0", 8080), SimulationNone, ""},
+		{listener("web-1", "app=web", bind, "0.0.0.0", 8080), SimulationAllow, "allow-http"},
+		{listener("web-1", "app=web", bind, "127.0.0.1", 9090), SimulationAllow, "allow-http"},
+		{listener("web-1", "app=web", bind, "::1", 9090), SimulationAllow, "allow-http"},
+		{listener("web-1", "app=web", bind, "0.0.0.0", 9090), SimulationBlock, "DefaultPosture"},
+		{listener("web-1", "app=web", bind, "0.0.0.0", 4444), SimulationBlock, "DefaultPosture"},
+		{listener("web-1", "app=web", bind, "0.0.0.0", 0), SimulationNone, ""},
+		{listener("web-1", "app=web", listen, "", 0), SimulationNone, ""},
+		{socket, SimulationAllow, "allow-http"},
+	}
+
+	for _, exp := range expected {
+		result, ok := ps.Simulate(exp.Log)
+		if !ok || result.Decision != exp.Decision || result.PolicyName != exp.PolicyName {
+			t.Errorf("[FAIL] Expected %s by %q for %s %s:%d, got %s by %q", exp.Decision, exp.PolicyName, exp.Log.Data, exp.Log.LocalIP, exp.Log.LocalPort, result.Decision, result.PolicyName)
+			return
+		}
+	}
+	t.Log("[PASS] Matched local addresses and ports of binds")
+
+	result, _ := ps.Simulate(expected[0].Log)
+	if result.Rule != "Network listener ports=4444,31337 (Block)" {
+		t.Errorf("[FAIL] Unexpected rule %q", result.Rule)
+		return
+	}
+	t.Log("[PASS] Reported the matched listener rule")
+}
+
+func TestSyscallMatcher(t *testing.T) {
+	policies := `apiVersion: security.kubearmor.com/v1
+kind: KubeArmorPolicy
+metadata:
+  name: block-escapes
+spec:
+  selector:
+    matchLabels:
+      app: api
+  syscalls:
+    matchSyscalls:
+    - syscall: PTRACE, mount
+    - syscall: bpf
+      fromSource:
+      - path: /usr/bin/bpftool
+      action: Audit
+    - syscall: unshare
+      action: Allow
+  action: Block
+`
+
+	secPolicies, err := policy.ParseSecurityPolicies([]byte(policies))
+	if err != nil || len(secPolicies) != 1 || len(secPolicies[0].Spec.Syscalls.MatchSyscalls) != 4 ||
+		secPolicies[0].Spec.Syscalls.MatchSyscalls[0].Syscall != "ptrace" || secPolicies[0].Spec.Syscalls.MatchSyscalls[1].Action != "Block" {
+		t.Errorf("[FAIL] Failed to parse syscall rules (%v)", err)
+		return
+	}
+	t.Log("[PASS] Parsed syscall rules")
+
+	ps := NewPolicySimulator(secPolicies, tp.DefaultPosture{FileAction: "block", NetworkAction: "block", CapabilitiesAction: "block"})
+
+	syscall := func(labels, resource, process, result string) tp.Log {
+		return tp.Log{ContainerID: "c-api", NamespaceName: "default", PodName: "api", Labels: labels,
+			Operation: "Syscall", Resource: resource, Data: "syscall=SYS_" + strings.ToUpper(resource), ProcessName: process, Result: result}
+	}
+
+	expected := []struct {
+		Log        tp.Log
+		Decision   string
+		PolicyName string
+	}{
+		{syscall("app=api", "ptrace", "/usr/bin/gdb", "Passed"), SimulationBlock, "block-escapes"},
+		{syscall("app=api", "mount", "/bin/mount", "Permission denied"), SimulationBlock, "block-escapes"},
+		{syscall("app=api", "bpf", "/usr/bin/bpftool", "Passed"), SimulationAudit, "block-escapes"},
+		{syscall("app=api", "bpf", "/usr/bin/python3", "Passed"), SimulationNone, ""},
+		{syscall("app=api", "unshare", "/usr/bin/unshare", "Passed"), SimulationNone, ""},
+		{syscall("app=api", "setns", "/usr/bin/nsenter", "Operation not permitted"), SimulationNone, ""},
+	}
+
+	for _, exp := range expected {
+		result, ok := ps.Simulate(exp.Log)
+		if !ok || result.Decision != exp.Decision || result.PolicyName != exp.PolicyName {
+			t.Errorf("[FAIL] Expected %s by %q for %s from %s, got %s by %q", exp.Decision, exp.PolicyName, exp.Log.Resource, exp.Log.ProcessName, result.Decision, result.PolicyName)
+			return
+		}
+	}
+	t.Log("[PASS] Matched syscalls and their sources")
+
+	result, _ := ps.Simulate(expected[0].Log)
+	if result.Rule != "Syscall syscall ptrace (Block)" {
+		t.Errorf("[FAIL] Unexpected rule %q", result.Rule)
+		return
+	}
+	t.Log("[PASS] Reported the matched syscall rule")
+
+	// BPF-LSM has no hooks for unshare and setns
+	fd := &Feeder{Enforcer: "BPFLSM"}
+
+	if match := fd.newMatchPolicy(tp.KubeArmorPolicyEnabled, "p", "", tp.SyscallMatchType{Syscall: "ptrace", Action: "Block"}); match.Action != "Block" {
+		t.Errorf("[FAIL] Unexpected action %q for ptrace", match.Action)
+		return
+	}
+
+	if match := fd.newMatchPolicy(tp.KubeArmorPolicyEnabled, "p", "", tp.SyscallMatchType{Syscall: "setns", Action: "Block"}); match.Action != "Audit (Block)" {
+		t.Errorf("[FAIL] Unexpected action %q for setns", match.Action)
+		return
+	}
+	t.Log("[PASS] Audited syscall rules that cannot be enforced")
+}
+
+func TestExporters(t *testing.T) {
+	// syslog
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Errorf("[FAIL] Failed to listen a UDP port (%s)", err.Error())
+		return
+	}
+	defer conn.Close()
+
+	syslog, err := NewExporterWorker(ExporterSpec{
+		Name:   "syslog",
+		Type:   "syslog",
+		Filter: &ExporterFilter{NamespaceNames: []string{"default"}},
+		Syslog: &SyslogSpec{Network: "udp", Address: conn.LocalAddr().String(), Facility: "local3"},
+	})
+	if err != nil {
+		t.Errorf("[FAIL] Failed to create a syslog exporter (%s)", err.Error())
+		return
+	}
+
+	syslog.Push(tp.Log{NamespaceName: "kube-system", Type: "ContainerLog"})
+	syslog.Push(tp.Log{NamespaceName: "default", Type: "MatchedPolicy", Action: "Block", PolicyName: "block-exec"})
+
+	buf := make([]byte, 65536)
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Errorf("[FAIL] Failed to receive a syslog message (%s)", err.Error())
+		return
+	}
+
+	// local3 (19) * 8 + warning (4)
+	if msg := string(buf[:n]); !strings.HasPrefix(msg, "<156>1 ") || !strings.Contains(msg, " MatchedPolicy - {") || !strings.Contains(msg, "block-exec") {
+		t.Errorf("[FAIL] Unexpected syslog message (%s)", msg)
+		return
+	}
+
+	if err := syslog.Close(); err != nil {
+		t.Errorf("[FAIL] Failed to close the syslog exporter (%s)", err.Error())
+		return
+	}
+	t.Log("[PASS] Exported an alert to syslog")
+
+	// syslog over a unix stream socket
+	dir, err := ioutil.TempDir("", "kubearmor-syslog")
+	if err != nil {
+		t.Errorf("[FAIL] Failed to create a temp directory (%s)", err.Error())
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	listener, err := net.Listen("unix", filepath.Join(dir, "syslog.sock"))
+	if err != nil {
+		t.Errorf("[FAIL] Failed to listen a unix socket (%s)", err.Error())
+		return
+	}
+	defer listener.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		stream, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer stream.Close()
+
+		_ = stream.SetReadDeadline(time.Now().Add(5 * time.Second))
+		data, _ := ioutil.ReadAll(stream)
+		received <- string(data)
+	}()
+
+	stream, err := NewSyslogExporter(ExporterSpec{Name: "stream", Type: "syslog", Syslog: &SyslogSpec{Network: "unix", Address: filepath.Join(dir, "syslog.sock")}})
+	if err != nil {
+		t.Errorf("[FAIL] Failed to create a syslog exporter (%s)", err.Error())
+		return
+	}
+
+	if err := stream.Export([]tp.Log{{Type: "ContainerLog"}, {Type: "ContainerLog"}}); err != nil {
+		t.Errorf("[FAIL] Failed to export logs to a unix stream socket (%s)", err.Error())
+		return
+	}
+	_ = stream.Close()
+
+	data := <-received
+	for i := 0; i < 2; i++ {
+		space := strings.Index(data, " ")
+		if space < 0 {
+			t.Errorf("[FAIL] Expected an octet-counted message (%s)", data)
+			return
+		}
+
+		length, err := strconv.Atoi(data[:space])
+		if err != nil || len(data) < space+1+length || !strings.HasPrefix(data[space+1:], "<134>1 ") {
+			t.Errorf("[FAIL] Expected an octet-counted message (%s)", data)
+			return
+		}
+		data = data[space+1+length:]
+	}
+
+	if data != "" {
+		t.Errorf("[FAIL] Unexpected trailing data (%s)", data)
+		return
+	}
+	t.Log("[PASS] Framed syslog messages over a unix stream socket")
+
+	// webhook
+	requests := make(chan []tp.Log, 10)
+	failed := false
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !failed {
+			failed = true
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		logs := []tp.Log{}
+		if err := json.NewDecoder(r.Body).Decode(&logs); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		requests <- logs
+	}))
+	defer server.Close()
+
+	webhook, err := NewExporterWorker(ExporterSpec{
+		Name:          "webhook",
+		Type:          "webhook",
+		BatchSize:     2,
+		FlushInterval: metav1.Duration{Duration: time.Hour},
+		Webhook:       &WebhookSpec{URL: server.URL, InitialBackoff: metav1.Duration{Duration: 10 * time.Millisecond}},
+	})
+	if err != nil {
+		t.Errorf("[FAIL] Failed to create a webhook exporter (%s)", err.Error())
+		return
+	}
+
+	webhook.Push(tp.Log{Type: "ContainerLog", Resource: "/bin/ls"})
+	webhook.Push(tp.Log{Type: "ContainerLog", Resource: "/bin/ps"})
+
+	select {
+	case logs := <-requests:
+		if len(logs) != 2 || logs[1].Resource != "/bin/ps" {
+			t.Errorf("[FAIL] Unexpected webhook batch (%+v)", logs)
+			return
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("[FAIL] Failed to receive a webhook batch")
+		return
+	}
+
+	if err := webhook.Close(); err != nil {
+		t.Errorf("[FAIL] Failed to close the webhook exporter (%s)", err.Error())
+		return
+	}
+	t.Log("[PASS] Exported a batch to the webhook after a retry")
+
+	// an unreachable webhook does not hold back the shutdown
+	unavailable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unavailable.Close()
+
+	retries := 100
+	webhook, err = NewExporterWorker(ExporterSpec{
+		Name:    "unavailable",
+		Type:    "webhook",
+		Webhook: &WebhookSpec{URL: unavailable.URL, MaxRetries: &retries, InitialBackoff: metav1.Duration{Duration: time.Minute}},
+	})
+	if err != nil {
+		t.Errorf("[FAIL] Failed to create a webhook exporter (%s)", err.Error())
+		return
+	}
+
+	for i := 0; i < 100; i++ {
+		webhook.Push(tp.Log{Type: "ContainerLog", Resource: "/bin/ls"})
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		_ = webhook.Close()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+	case <-time.After(10 * time.Second):
+		t.Error("[FAIL] Failed to close an unreachable webhook exporter")
+		return
+	}
+
+	if dropped := atomic.LoadUint64(&webhook.Dropped); dropped != 100 {
+		t.Errorf("[FAIL] Expected 100 dropped events, got %d", dropped)
+		return
+	}
+	t.Log("[PASS] Closed an unreachable webhook exporter without waiting for retries")
+
+	// flush on the interval without queued events
+	flushes := make(chan struct{}, 10)
+	RegisterExporter("flushCounter", func(spec ExporterSpec) (Exporter, error) {
+		return &flushCounter{flushes: flushes}, nil
+	})
+
+	counter, err := NewExporterWorker(ExporterSpec{Name: "counter", Type: "flushCounter", FlushInterval: metav1.Duration{Duration: 10 * time.Millisecond}})
+	if err != nil {
+		t.Errorf("[FAIL] Failed to create an exporter (%s)", err.Error())
+		return
+	}
+
+	select {
+	case <-flushes:
+	case <-time.After(5 * time.Second):
+		t.Error("[FAIL] Failed to flush an exporter without queued events")
+		return
+	}
+
+	if err := counter.Close(); err != nil {
+		t.Errorf("[FAIL] Failed to close the exporter (%s)", err.Error())
+		return
+	}
+	t.Log("[PASS] Flushed an exporter without queued events")
+
+	// count only the unsent events of a partially exported batch
+	RegisterExporter("partial", func(spec ExporterSpec) (Exporter, error) {
+		return &partialExporter{}, nil
+	})
+
+	partial, err := NewExporterWorker(ExporterSpec{Name: "partial", Type: "partial", BatchSize: 3, FlushInterval: metav1.Duration{Duration: time.Hour}})
+	if err != nil {
+		t.Errorf("[FAIL] Failed to create an exporter (%s)", err.Error())
+		return
+	}
+
+	for i := 0; i < 3; i++ {
+		partial.Push(tp.Log{Type: "ContainerLog"})
+	}
+
+	if err := partial.Close(); err != nil {
+		t.Errorf("[FAIL] Failed to close the exporter (%s)", err.Error())
+		return
+	}
+
+	if dropped := atomic.LoadUint64(&partial.Dropped); dropped != 1 {
+		t.Errorf("[FAIL] Expected 1 dropped event, got %d", dropped)
+		return
+	}
+	t.Log("[PASS] Counted only the unsent events of a partial batch")
+}
+
+// partialExporter Structure (an exporter failing on the last event of a batch)
+type partialExporter struct{}
+
+// Export Function
+func (pe *partialExporter) Export(logs []tp.Log) error {
+	return &PartialExportError{Unsent: 1, Err: errors.New("connection reset")}
+}
+
+// Close Function
+func (pe *partialExporter) Close() error {
+	return nil
+}
+
+// flushCounter Structure (an exporter reporting its flushes)
+type flushCounter struct {
+	flushes chan struct{}
+}
+
+// Export Function
+func (fc *flushCounter) Export(logs []tp.Log) error {
+	return nil
+}
+
+// Flush Function
+func (fc *flushCounter) Flush() error {
+	select {
+	case fc.flushes <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Close Function
+func (fc *flushCounter) Close() error {
+	return nil
+}
+
+// otlpCollector Structure (a stand-in for an OTLP collector)
+type otlpCollector struct {
+	collogs.UnimplementedLogsServiceServer
+	requests chan *collogs.ExportLogsServiceRequest
+}
+
+// Export Function
+func (c *otlpCollector) Export(ctx context.Context, req *collogs.ExportLogsServiceRequest) (*collogs.ExportLogsServiceResponse, error) {
+	c.requests <- req
+	return &collogs.ExportLogsServiceResponse{}, nil
+}
+
+// getOTLPAttribute Function
+func getOTLPAttribute(attrs []*otlpcommon.KeyValue, key string) *otlpcommon.AnyValue {
+	for _, attr := range attrs {
+		if attr.Key == key {
+			return attr.Value
+		}
+	}
+	return nil
+}
+
+// checkOTLPRequest Function
+func checkOTLPRequest(req *collogs.ExportLogsServiceRequest) bool {
+	if len(req.ResourceLogs) != 1 || len(req.ResourceLogs[0].InstrumentationLibraryLogs) != 1 {
+		return false
+	}
+
+	resource := req.ResourceLogs[0].Resource.Attributes
+	if getOTLPAttribute(resource, "k8s.cluster.name").GetStringValue() != "default" ||
+		getOTLPAttribute(resource, "k8s.namespace.name").GetStringValue() != "default" ||
+		getOTLPAttribute(resource, "k8s.pod.name").GetStringValue() != "nginx" ||
+		getOTLPAttribute(resource, "k8s.container.name").GetStringValue() != "nginx" ||
+		getOTLPAttribute(resource, "k8s.node.name").GetStringValue() != "node-1" {
+		return false
+	}
+
+	records := req.ResourceLogs[0].InstrumentationLibraryLogs[0].Logs
+	if len(records) != 1 || records[0].SeverityText != "WARN" {
+		return false
+	}
+
+	attrs := records[0].Attributes
+	return getOTLPAttribute(attrs, "process.pid").GetIntValue() == 42 &&
+		getOTLPAttribute(attrs, "kubearmor.operation").GetStringValue() == "Process" &&
+		getOTLPAttribute(attrs, "kubearmor.policy_name").GetStringValue() == "block-exec" &&
+		getOTLPAttribute(attrs, "k8s.pod.name") == nil
+}
+
+func TestOTLPExporter(t *testing.T) {
+	cfg.GlobalCfg.Cluster = "default"
+
+	alert := tp.Log{
+		UpdatedTime:   "2021-12-17T09:34:40.123456Z",
+		HostName:      "node-1",
+		NamespaceName: "default",
+		PodName:       "nginx",
+		ContainerName: "nginx",
+		PID:           42,
+		ProcessName:   "/bin/bash",
+		PolicyName:    "block-exec",
+		Type:          "MatchedPolicy",
+		Operation:     "Process",
+		Resource:      "/usr/bin/curl",
+		Action:        "Block",
+		Result:        "Permission denied",
+	}
+
+	// gRPC
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Errorf("[FAIL] Failed to listen a port (%s)", err.Error())
+		return
+	}
+
+	collector := &otlpCollector{requests: make(chan *collogs.ExportLogsServiceRequest, 1)}
+	server := grpc.NewServer()
+	collogs.RegisterLogsServiceServer(server, collector)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	exporter, err := NewOTLPExporter(ExporterSpec{OTLP: &OTLPSpec{Endpoint: listener.Addr().String(), Insecure: true}})
+	if err != nil {
+		t.Errorf("[FAIL] Failed to create an OTLP/gRPC exporter (%s)", err.Error())
+		return
+	}
+
+	if err := exporter.Export([]tp.Log{alert}); err != nil {
+		t.Errorf("[FAIL] Failed to export an alert over OTLP/gRPC (%s)", err.Error())
+		return
+	}
+	_ = exporter.Close()
+
+	if req := <-collector.requests; !checkOTLPRequest(req) {
+		t.Errorf("[FAIL] Unexpected OTLP/gRPC request (%v)", req)
+		return
+	}
+	t.Log("[PASS] Exported an alert over OTLP/gRPC")
+
+	// HTTP
+	httpRequests := make(chan *collogs.ExportLogsServiceRequest, 1)
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		req := &collogs.ExportLogsServiceRequest{}
+		if r.URL.Path != "/v1/logs" || proto.Unmarshal(body, req) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		httpRequests <- req
+	}))
+	defer httpServer.Close()
+
+	exporter, err = NewOTLPExporter(ExporterSpec{OTLP: &OTLPSpec{Protocol: "http", Endpoint: httpServer.URL}})
+	if err != nil {
+		t.Errorf("[FAIL] Failed to create an OTLP/HTTP exporter (%s)", err.Error())
+		return
+	}
+
+	if err := exporter.Export([]tp.Log{alert}); err != nil {
+		t.Errorf("[FAIL] Failed to export an alert over OTLP/HTTP (%s)", err.Error())
+		return
+	}
+	_ = exporter.Close()
+
+	if req := <-httpRequests; !checkOTLPRequest(req) {
+		t.Errorf("[FAIL] Unexpected OTLP/HTTP request (%v)", req)
+		return
+	}
+	t.Log("[PASS] Exported an alert over OTLP/HTTP")
+}
+
+func TestFormatters(t *testing.T) {
+	cfg.GlobalCfg.Cluster = "default"
+
+	alert := tp.Log{
+		UpdatedTime:   "2021-12-17T09:34:40.123456Z",
+		HostName:      "node-1",
+		NamespaceName: "default",
+		PodName:       "nginx",
+		PID:           42,
+		ProcessName:   "/bin/bash",
+		PolicyName:    "block|exec",
+		Severity:      "8",
+		Type:          "MatchedHostPolicy",
+		Operation:     "Process",
+		Resource:      "/usr/bin/curl a=b",
+		Action:        "Block",
+		Result:        "Permission denied",
+	}
+
+	cef, err := FormatLog(LogFormatCEF, alert)
+	if err != nil {
+		t.Errorf("[FAIL] Failed to format an alert as CEF (%s)", err.Error())
+		return
+	}
+
+	if !strings.HasPrefix(cef, "CEF:0|KubeArmor|KubeArmor|1.0|block\\|exec|Block Process|8|rt=1639733680123 ") ||
+		!strings.Contains(cef, " cs6=/usr/bin/curl a\\=b cs6Label=resource ") || !strings.Contains(cef, " cat=Process act=Block ") ||
+		!strings.Contains(cef, " cs1=default cs1Label=cluster ") {
+		t.Errorf("[FAIL] Unexpected CEF line (%s)", cef)
+		return
+	}
+	t.Log("[PASS] Formatted an alert as CEF")
+
+	leef, err := FormatLog(LogFormatLEEF, alert)
+	if err != nil {
+		t.Errorf("[FAIL] Failed to format an alert as LEEF (%s)", err.Error())
+		return
+	}
+
+	if !strings.HasPrefix(leef, "LEEF:1.0|KubeArmor|KubeArmor|1.0|block\\|exec|devTime=Dec 17 2021 09:34:40.123 UTC\t") ||
+		!strings.Contains(leef, "\tsev=8\tcat=Process\t") || !strings.Contains(leef, "\tresource=/usr/bin/curl a=b\t") ||
+		!strings.Contains(leef, "\tcluster=default\t") {
+		t.Errorf("[FAIL] Unexpected LEEF line (%s)", leef)
+		return
+	}
+	t.Log("[PASS] Formatted an alert as LEEF")
+
+	if _, err := FormatLog("xml", alert); err == nil {
+		t.Error("[FAIL] Accepted an unknown format")
+		return
+	}
+	t.Log("[PASS] Rejected an unknown format")
+}
+
+func TestAlertAggregator(t *testing.T) {
+	emitted := make(chan tp.Log, 10)
+	aa := NewAlertAggregator(time.Hour, func(log tp.Log) {
+		emitted <- log
+	})
+
+	alert := tp.Log{ContainerID: "abc", Type: "MatchedPolicy", PolicyName: "block-exec", Operation: "Process", Resource: "/bin/sh", Action: "Block"}
+
+	for i, updatedTime := range []string{"t0", "t1", "t2", "t3"} {
+		alert.UpdatedTime = updatedTime
+		if emit := aa.Add(alert); emit != (i == 0) {
+			t.Errorf("[FAIL] Unexpected aggregation result for alert %d (%v)", i, emit)
+			return
+		}
+	}
+
+	other := alert
+	other.Resource = "/bin/bash"
+	if !aa.Add(other) {
+		t.Error("[FAIL] Collapsed a different alert")
+		return
+	}
+
+	aa.Stop()
+
+	if len(emitted) != 1 {
+		t.Errorf("[FAIL] Expected 1 aggregated alert, got %d", len(emitted))
+		return
+	}
+
+	if log := <-emitted; log.Count != 3 || log.FirstSeen != "t1" || log.LastSeen != "t3" {
+		t.Errorf("[FAIL] Unexpected aggregated alert (%+v)", log)
+		return
+	}
+	t.Log("[PASS] Aggregated identical alerts")
+}
+
+func TestRateLimiter(t *testing.T) {
+	emitted := make(chan tp.Log, 10)
+	rl := NewRateLimiter(5, 0, func(log tp.Log) {
+		emitted <- log
+	})
+
+	allowed := 0
+	for i := 0; i < 20; i++ {
+		if rl.Allow(tp.Log{ContainerID: "abc", Type: "ContainerLog", Operation: "File", Result: "Passed"}) {
+			allowed++
+		}
+	}
+
+	if allowed != 5 {
+		t.Errorf("[FAIL] Expected 5 allowed events, got %d", allowed)
+		return
+	}
+
+	if !rl.Allow(tp.Log{ContainerID: "abc", Type: "ContainerLog", Operation: "Process", Result: "Permission denied"}) {
+		t.Error("[FAIL] Suppressed a blocked action")
+		return
+	}
+
+	if !rl.Allow(tp.Log{ContainerID: "abc", EventRateLimit: -1, Type: "ContainerLog", Result: "Passed"}) {
+		t.Error("[FAIL] Suppressed an event of an unlimited container")
+		return
+	}
+
+	if !rl.Allow(tp.Log{Type: "HostLog", Result: "Passed"}) {
+		t.Error("[FAIL] Suppressed a host event without a host limit")
+		return
+	}
+
+	rl.Stop()
+
+	if len(emitted) != 1 {
+		t.Errorf("[FAIL] Expected 1 summary event, got %d", len(emitted))
+		return
+	}
+
+	if log := <-emitted; log.ContainerID != "abc" || log.Operation != "RateLimit" || log.Data != "suppressed=15 rate=5" {
+		t.Errorf("[FAIL] Unexpected summary event (%+v)", log)
+		return
+	}
+	t.Log("[PASS] Rate-limited container events")
+}
+
+func TestDropCollector(t *testing.T) {
+	dropped := uint64(3)
+
+	AlertStructs = map[string]AlertStruct{"client": {Dropped: &dropped}}
+	AlertLock = &sync.RWMutex{}
+
+	LogStructs = map[string]LogStruct{}
+	LogLock = &sync.RWMutex{}
+
+	worker := &ExporterWorker{Name: "siem", Dropped: 5}
+	dc := NewDropCollector(&Feeder{Exporters: []*ExporterWorker{worker}, ExportersLock: new(sync.RWMutex)})
+
+	ch := make(chan prometheus.Metric, 10)
+	dc.Collect(ch)
+	close(ch)
+
+	values := map[string]float64{}
+	for metric := range ch {
+		m := &dto.Metric{}
+		if err := metric.Write(m); err != nil {
+			t.Errorf("[FAIL] Failed to read a metric (%s)", err.Error())
+			return
+		}
+
+		labels := []string{}
+		for _, label := range m.GetLabel() {
+			labels = append(labels, label.GetValue())
+		}
+		values[strings.Join(labels, ",")] = m.GetCounter().GetValue()
+	}
+
+	if len(values) != 2 || values["client,alerts"] != 3 || values["siem"] != 5 {
+		t.Errorf("[FAIL] Unexpected drop counters (%v)", values)
+		return
+	}
+	t.Log("[PASS] Collected drop counters")
+}
+
+// queryAlerts Function (collects the alerts sent by a query)
+func queryAlerts(es *EventStore, query *EventQuery) ([]*pb.Alert, error) {
+	alerts := []*pb.Alert{}
+	err := es.QueryAlerts(query, func(alert *pb.Alert) error {
+		alerts = append(alerts, alert)
+		return nil
+	})
+	return alerts, err
+}
+
+func TestEventStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.db")
+
+	es, err := NewEventStore(path, 0, 0)
+	if err != nil {
+		t.Errorf("[FAIL] Failed to open the event store (%s)", err.Error())
+		return
+	}
+
+	now := time.Now().UTC()
+	for i, pod := range []string{"pod-a", "pod-b", "pod-a"} {
+		log := tp.Log{UpdatedTime: now.Add(time.Duration(-i) * time.Hour).Format(kl.TimeFormUTC), NamespaceName: "default", PodName: pod, Type: "MatchedPolicy"}
+		es.AddAlert(log, &pb.Alert{Sequence: uint64(i + 1), NamespaceName: log.NamespaceName, PodName: log.PodName, Type: log.Type})
+	}
+	es.AddLog(tp.Log{UpdatedTime: now.Format(kl.TimeFormUTC), PodName: "pod-a", Type: "ContainerLog"}, &pb.Log{Sequence: 1, PodName: "pod-a", Type: "ContainerLog"})
+
+	// events are written when the store is closed
+	if err := es.Close(); err != nil {
+		t.Errorf("[FAIL] Failed to close the event store (%s)", err.Error())
+		return
+	}
+
+	es, err = NewEventStore(path, time.Minute, 0)
+	if err != nil {
+		t.Errorf("[FAIL] Failed to reopen the event store (%s)", err.Error())
+		return
+	}
+	defer es.Close()
+
+	query, err := NewEventQuery(&pb.QueryRequest{Since: "90m", EventFilter: &pb.EventFilter{PodNames: []string{"pod-a"}}}, nil)
+	if err != nil {
+		t.Errorf("[FAIL] Failed to create a query (%s)", err.Error())
+		return
+	}
+
+	alerts, err := queryAlerts(es, query)
+	if err != nil || len(alerts) != 1 || alerts[0].Sequence != 1 {
+		t.Errorf("[FAIL] Unexpected alerts in the last 90 minutes (%v, %v)", alerts, err)
+		return
+	}
+
+	query.Since = now.Add(-3 * time.Hour)
+	if alerts, _ := queryAlerts(es, query); len(alerts) != 2 || alerts[0].Sequence != 3 {
+		t.Errorf("[FAIL] Unexpected alerts in the last 3 hours (%v)", alerts)
+		return
+	}
+
+	logs := []*pb.Log{}
+	if err := es.QueryLogs(query, func(log *pb.Log) error {
+		logs = append(logs, log)
+		return nil
+	}); err != nil || len(logs) != 1 {
+		t.Errorf("[FAIL] Unexpected logs in the last 3 hours (%v, %v)", logs, err)
+		return
+	}
+	t.Log("[PASS] Queried stored events")
+
+	// events older than the retention are removed
+	if err := es.EnforceRetention(); err != nil {
+		t.Errorf("[FAIL] Failed to enforce the retention (%s)", err.Error())
+		return
+	}
+
+	query.Filter = nil
+	if alerts, _ := queryAlerts(es, query); len(alerts) != 1 {
+		t.Errorf("[FAIL] Expected 1 alert after the retention, got %d", len(alerts))
+		return
+	}
+	t.Log("[PASS] Removed expired events")
+
+	// queries are read and sent in pages
+	events := []StoredEvent{}
+	for i := 0; i < 2*QueryPageSize; i++ {
+		value, _ := proto.Marshal(&pb.Alert{Sequence: uint64(100 + i), NamespaceName: "default", PodName: "pod-c", Type: "MatchedPolicy"})
+		events = append(events, StoredEvent{Bucket: alertBucket, Key: getEventKey(now, uint64(100+i)), Value: value})
+	}
+	es.write(events)
+
+	query, _ = NewEventQuery(&pb.QueryRequest{Since: "1h", Limit: QueryPageSize + 10, EventFilter: &pb.EventFilter{PodNames: []string{"pod-c"}}}, nil)
+
+	sent := []uint64{}
+	if err := es.QueryAlerts(query, func(alert *pb.Alert) error {
+		sent = append(sent, alert.Sequence)
+		return nil
+	}); err != nil || len(sent) != QueryPageSize+10 || sent[0] != 100 || sent[len(sent)-1] != uint64(100+QueryPageSize+9) {
+		t.Errorf("[FAIL] Expected %d alerts across pages, got %d (%v)", QueryPageSize+10, len(sent), err)
+		return
+	}
+
+	errStop := errors.New("stop")
+	count := 0
+	if err := es.QueryAlerts(query, func(alert *pb.Alert) error {
+		count++
+		return errStop
+	}); err != errStop || count != 1 {
+		t.Errorf("[FAIL] Expected the query to stop at the first failed send, got %d sent (%v)", count, err)
+		return
+	}
+
+	if query, _ := NewEventQuery(&pb.QueryRequest{Limit: 1 << 30}, nil); query.Limit != MaxQueryLimit {
+		t.Errorf("[FAIL] Expected the limit to be capped at %d, got %d", MaxQueryLimit, query.Limit)
+		return
+	}
+	t.Log("[PASS] Sent queried events in pages")
+
+	if _, err := NewEventQuery(&pb.QueryRequest{Since: "yesterday"}, nil); err == nil {
+		t.Error("[FAIL] Accepted an invalid time range")
+		return
+	}
+	t.Log("[PASS] Rejected an invalid time range")
+}
+
+// newTestFeeder Function
+func newTestFeeder() *Feeder {
+	fd := &Feeder{}
+
+	fd.Node = &tp.Node{}
+	fd.Output = "none"
+
+	fd.ExportersLock = new(sync.RWMutex)
+
+	fd.SecurityPolicies = map[string]tp.MatchPolicies{}
+	fd.SecurityPoliciesLock = new(sync.RWMutex)
+
+	fd.DefaultPostures = map[string]tp.DefaultPosture{}
+	fd.DefaultPosturesLock = new(sync.Mutex)
+
+	AlertStructs = map[string]AlertStruct{}
+	AlertLock = &sync.RWMutex{}
+	AlertReplay = NewReplayBuffer(16)
+
+	LogStructs = map[string]LogStruct{}
+	LogLock = &sync.RWMutex{}
+	LogReplay = NewReplayBuffer(16)
+
+	return fd
+}
+
+// getTestLog Function
+func getTestLog(containerID string, seq int) tp.Log {
+	return tp.Log{
+		ContainerID:           containerID,
+		NamespaceName:         "default",
+		PodName:               containerID,
+		Source:                "/bin/cat",
+		Operation:             "File",
+		Resource:              "/etc/passwd",
+		Data:                  strconv.Itoa(seq),
+		Result:                "Passed",
+		FileVisibilityEnabled: true,
+	}
+}
+
+// addTestSubscriber Function
+func addTestSubscriber() chan *pb.Log {
+	conn := make(chan *pb.Log, 1<<16)
+
+	LogLock.Lock()
+	LogStructs["test"] = LogStruct{Broadcast: conn, BlockingTimeout: time.Second, Dropped: new(uint64)}
+	LogLock.Unlock()
+
+	return conn
+}
+
+func TestEventPipeline(t *testing.T) {
+	fd := newTestFeeder()
+	conn := addTestSubscriber()
+
+	fd.Pipeline = NewEventPipeline(4, 1024, fd.processLog, fd.publishLogs)
+
+	for i := 0; i < 100; i++ {
+		for _, containerID := range []string{"a", "b", "c"} {
+			fd.PushLog(getTestLog(containerID, i))
+		}
+	}
+
+	// the queued events are published before the pipeline stops
+	fd.Pipeline.Stop()
+
+	if len(conn) != 300 {
+		t.Errorf("[FAIL] Expected 300 logs, got %d", len(conn))
+		return
+	}
+	t.Log("[PASS] Published all queued events")
+
+	last := map[string]int{}
+	for len(conn) > 0 {
+		log := <-conn
+
+		seq, _ := strconv.Atoi(log.Data)
+		if prev, ok := last[log.ContainerID]; ok && seq != prev+1 {
+			t.Errorf("[FAIL] Reordered events of %s (%d after %d)", log.ContainerID, seq, prev)
+			return
+		}
+		last[log.ContainerID] = seq
+	}
+	t.Log("[PASS] Kept the order of events per container")
+
+	if fd.Pipeline.Push(getTestLog("a", 0)) {
+		t.Error("[FAIL] Accepted an event after the pipeline stopped")
+		return
+	}
+
+	fd.PushLog(getTestLog("a", 100))
+	if len(conn) != 1 {
+		t.Errorf("[FAIL] Expected 1 log after the pipeline stopped, got %d", len(conn))
+		return
+	}
+	t.Log("[PASS] Processed an event in the caller after the pipeline stopped")
+
+	// a full queue neither holds the caller back nor drops events
+	blocked := make(chan struct{})
+	fd.Pipeline = NewEventPipeline(1, 1, func(log tp.Log) (tp.Log, bool) {
+		<-blocked
+		return fd.processLog(log)
+	}, fd.publishLogs)
+
+	pushed := make(chan struct{})
+	go func() {
+		for i := 0; i < 3; i++ {
+			fd.PushLog(getTestLog("a", i))
+		}
+		close(pushed)
+	}()
+
+	select {
+	case <-pushed:
+	case <-time.After(10 * time.Second):
+		t.Error("[FAIL] Held back the caller while the queue is full")
+		return
+	}
+	t.Log("[PASS] Did not hold back the caller while the queue is full")
+
+	close(blocked)
+	fd.Pipeline.Stop()
+
+	if count := receiveLogs(conn, 4); count != 4 {
+		t.Errorf("[FAIL] Expected 4 logs with a full queue, got %d", count)
+		return
+	}
+	t.Log("[PASS] Published all events pushed while the queue was full")
+}
+
+// receiveLogs Function (returns the number of logs received before timing out)
+func receiveLogs(conn chan *pb.Log, count int) int {
+	for i := 0; i < count; i++ {
+		select {
+		case <-conn:
+		case <-time.After(10 * time.Second):
+			return i
+		}
+	}
+	return count
+}
+
+func BenchmarkPushLog(b *testing.B) {
+	containers := []string{}
+	for i := 0; i < 16; i++ {
+		containers = append(containers, fmt.Sprintf("container-%d", i))
+	}
+
+	// previous behavior: a goroutine per event, each taking LogLock
+	b.Run("GoroutinePerEvent", func(b *testing.B) {
+		fd := newTestFeeder()
+		conn := addTestSubscriber()
+
+		received := make(chan int)
+		go func() {
+			received <- receiveLogs(conn, b.N)
+		}()
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			go fd.PushLog(getTestLog(containers[i%len(containers)], i))
+		}
+
+		if count := <-received; count != b.N {
+			b.Fatalf("Received %d of %d logs", count, b.N)
+		}
+	})
+
+	// matching workers with batched fan-out
+	for _, workers := range []int{1, 4} {
+		b.Run(fmt.Sprintf("Pipeline-%d", workers), func(b *testing.B) {
+			fd := newTestFeeder()
+			conn := addTestSubscriber()
+
+			fd.Pipeline = NewEventPipeline(workers, 4096, fd.processLog, fd.publishLogs)
+
+			received := make(chan int)
+			go func() {
+				received <- receiveLogs(conn, b.N)
+			}()
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				fd.PushLog(getTestLog(containers[i%len(containers)], i))
+			}
+			count := <-received
+
+			b.StopTimer()
+			fd.Pipeline.Stop()
+
+			if count != b.N {
+				b.Fatalf("Received %d of %d logs", count, b.N)
+			}
+		})
+	}
+}
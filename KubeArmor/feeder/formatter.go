@@ -0,0 +1,318 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of KubeArmor
+
+package feeder
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	kl "github.com/kubearmor/KubeArmor/KubeArmor/common"
+	cfg "github.com/kubearmor/KubeArmor/KubeArmor/config"
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+)
+
+// =============== //
+// == Formatter == //
+// =============== //
+
+// log formats
+const (
+	LogFormatJSON = "json"
+	LogFormatCEF  = "cef"
+	LogFormatLEEF = "leef"
+)
+
+// SIEMVendor Vendor name in CEF and LEEF headers
+const SIEMVendor = "KubeArmor"
+
+// SIEMProduct Product name in CEF and LEEF headers
+const SIEMProduct = "KubeArmor"
+
+// SIEMVersion Version of the CEF and LEEF field mapping
+const SIEMVersion = "1.0"
+
+// leefTimeFormat Time format of devTime in LEEF
+const leefTimeFormat = "Jan 02 2006 15:04:05.000 MST"
+
+// leefJavaTimeFormat Java style of leefTimeFormat for devTimeFormat in LEEF
+const leefJavaTimeFormat = "MMM dd yyyy HH:mm:ss.SSS z"
+
+// IsValidLogFormat Function
+func IsValidLogFormat(format string) bool {
+	return format == LogFormatJSON || format == LogFormatCEF || format == LogFormatLEEF
+}
+
+// FormatLog Function
+func FormatLog(format string, log tp.Log) (string, error) {
+	switch format {
+	case "", LogFormatJSON:
+		arr, err := json.Marshal(log)
+		if err != nil {
+			return "", err
+		}
+		return string(arr), nil
+	case LogFormatCEF:
+		return FormatCEF(log), nil
+	case LogFormatLEEF:
+		return FormatLEEF(log), nil
+	}
+
+	return "", fmt.Errorf("unknown log format (%s)", format)
+}
+
+// isAlert Function
+func isAlert(log tp.Log) bool {
+	return log.Type == "MatchedPolicy" || log.Type == "MatchedHostPolicy"
+}
+
+// GetAncestryString Function (e.g., "/bin/sh(42) < /usr/bin/bash(7)", parent first)
+func GetAncestryString(ancestors []tp.Ancestor) string {
+	chain := []string{}
+
+	for _, ancestor := range ancestors {
+		chain = append(chain, fmt.Sprintf("%s(%d)", ancestor.ExecPath, ancestor.HostPID))
+	}
+
+	return strings.Join(chain, " < ")
+}
+
+// getLogTime Function
+func getLogTime(log tp.Log) time.Time {
+	if updatedTime, err := time.Parse(kl.TimeFormUTC, log.UpdatedTime); err == nil {
+		return updatedTime
+	}
+	return time.Unix(log.Timestamp, 0).UTC()
+}
+
+// GetSIEMSeverity Function
+func GetSIEMSeverity(log tp.Log) int {
+	// policy severities (1-10) are kept as they are
+	if severity, err := strconv.Atoi(log.Severity); err == nil {
+		if severity < 0 {
+			return 0
+		} else if severity > 10 {
+			return 10
+		}
+		return severity
+	}
+
+	if isAlert(log) {
+		if strings.HasPrefix(log.Action, "Block") {
+			return 7
+		}
+		return 5
+	}
+
+	return 1
+}
+
+// GetSIEMSignatureID Function
+func GetSIEMSignatureID(log tp.Log) string {
+	if isAlert(log) && log.PolicyName != "" {
+		return log.PolicyName
+	}
+	return log.Type
+}
+
+// getSIEMName Function
+func getSIEMName(log tp.Log) string {
+	if log.Message != "" {
+		return log.Message
+	}
+
+	if isAlert(log) {
+		return strings.TrimSpace(log.Action + " " + log.Operation)
+	}
+
+	return strings.TrimSpace(log.Operation + " " + log.Result)
+}
+
+// ========= //
+// == CEF == //
+// ========= //
+
+// escapeCEFHeader Function
+func escapeCEFHeader(value string) string {
+	value = strings.ReplaceAll(value, "\\", "\\\\")
+	value = strings.ReplaceAll(value, "|", "\\|")
+	value = strings.ReplaceAll(value, "\r", " ")
+	return strings.ReplaceAll(value, "\n", " ")
+}
+
+// escapeCEFExtension Function
+func escapeCEFExtension(value string) string {
+	value = strings.ReplaceAll(value, "\\", "\\\\")
+	value = strings.ReplaceAll(value, "=", "\\=")
+	value = strings.ReplaceAll(value, "\r", "\\r")
+	return strings.ReplaceAll(value, "\n", "\\n")
+}
+
+// FormatCEF Function
+//
+// CEF:0|KubeArmor|KubeArmor|1.0|<signature ID>|<name>|<severity>|<extension>
+//
+//	signature ID   PolicyName (alerts), Type (logs)
+//	name           Message, or Action and Operation (alerts), Operation and Result (logs)
+//	severity       Severity (1-10), or 7 (blocked alerts), 5 (other alerts), 1 (logs)
+//
+//	rt             UpdatedTime (milliseconds since epoch)
+//	dvchost        HostName
+//	cs1            the cluster of KubeArmor (cs1Label=cluster)
+//	cs2            NamespaceName (cs2Label=namespace)
+//	cs3            PodName (cs3Label=pod)
+//	cs4            ContainerName (cs4Label=container)
+//	cs5            ContainerImage (cs5Label=image)
+//	cs6            Resource (cs6Label=resource)
+//	cat            Operation
+//	act            Action
+//	outcome        Result
+//	msg            Data
+//	sproc          ProcessName
+//	spid           PID
+//	suid           UID
+//	cn1            HostPID (cn1Label=hostPid)
+//	flexString1    Tags (flexString1Label=tags)
+//	flexString2    ParentProcessName (flexString2Label=parentProcessName)
+func FormatCEF(log tp.Log) string {
+	extensions := []string{}
+
+	add := func(key, value string) {
+		if value != "" {
+			extensions = append(extensions, key+"="+escapeCEFExtension(value))
+		}
+	}
+
+	add("rt", strconv.FormatInt(getLogTime(log).UnixNano()/int64(time.Millisecond), 10))
+	add("dvchost", log.HostName)
+
+	for i, field := range []struct{ label, value string }{
+		{"cluster", cfg.GlobalCfg.Cluster},
+		{"namespace", log.NamespaceName},
+		{"pod", log.PodName},
+		{"container", log.ContainerName},
+		{"image", log.ContainerImage},
+		{"resource", log.Resource},
+	} {
+		if field.value != "" {
+			add(fmt.Sprintf("cs%d", i+1), field.value)
+			add(fmt.Sprintf("cs%dLabel", i+1), field.label)
+		}
+	}
+
+	add("cat", log.Operation)
+	add("act", log.Action)
+	add("outcome", log.Result)
+	add("msg", log.Data)
+
+	add("sproc", log.ProcessName)
+	add("spid", strconv.Itoa(int(log.PID)))
+	add("suid", strconv.Itoa(int(log.UID)))
+	add("cn1", strconv.Itoa(int(log.HostPID)))
+	add("cn1Label", "hostPid")
+
+	if log.Tags != "" {
+		add("flexString1", log.Tags)
+		add("flexString1Label", "tags")
+	}
+	if log.ParentProcessName != "" {
+		add("flexString2", log.ParentProcessName)
+		add("flexString2Label", "parentProcessName")
+	}
+
+	return fmt.Sprintf("CEF:0|%s|%s|%s|%s|%s|%d|%s", SIEMVendor, SIEMProduct, SIEMVersion,
+		escapeCEFHeader(GetSIEMSignatureID(log)), escapeCEFHeader(getSIEMName(log)), GetSIEMSeverity(log),
+		strings.Join(extensions, " "))
+}
+
+// ========== //
+// == LEEF == //
+// ========== //
+
+// escapeLEEFHeader Function
+func escapeLEEFHeader(value string) string {
+	value = strings.ReplaceAll(value, "\\", "\\\\")
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return escapeLEEFAttribute(value)
+}
+
+// escapeLEEFAttribute Function
+func escapeLEEFAttribute(value string) string {
+	value = strings.ReplaceAll(value, "\t", " ")
+	value = strings.ReplaceAll(value, "\r", " ")
+	return strings.ReplaceAll(value, "\n", " ")
+}
+
+// FormatLEEF Function
+//
+// LEEF:1.0|KubeArmor|KubeArmor|1.0|<event ID>|<tab-separated attributes>
+//
+//	event ID           PolicyName (alerts), Type (logs)
+//
+//	devTime            UpdatedTime (devTimeFormat=MMM dd yyyy HH:mm:ss.SSS z)
+//	sev                Severity (1-10), or 7 (blocked alerts), 5 (other alerts), 1 (logs)
+//	cat                Operation
+//	identHostName      HostName
+//	action             Action
+//	result             Result
+//	resource           Resource
+//	data               Data
+//	policyName         PolicyName
+//	tags               Tags
+//	msg                Message
+//	cluster            the cluster of KubeArmor
+//	namespace          NamespaceName
+//	pod                PodName
+//	container          ContainerName
+//	image              ContainerImage
+//	pid, ppid, uid     PID, PPID, UID
+//	hostPid, hostPpid  HostPID, HostPPID
+//	processName        ProcessName
+//	parentProcessName  ParentProcessName
+//	ancestors          Ancestors (execPath(hostPid), parent first)
+//	type               Type
+func FormatLEEF(log tp.Log) string {
+	attributes := []string{}
+
+	add := func(key, value string) {
+		if value != "" {
+			attributes = append(attributes, key+"="+escapeLEEFAttribute(value))
+		}
+	}
+
+	add("devTime", getLogTime(log).Format(leefTimeFormat))
+	add("devTimeFormat", leefJavaTimeFormat)
+	add("sev", strconv.Itoa(GetSIEMSeverity(log)))
+	add("cat", log.Operation)
+	add("identHostName", log.HostName)
+
+	add("action", log.Action)
+	add("result", log.Result)
+	add("resource", log.Resource)
+	add("data", log.Data)
+	add("policyName", log.PolicyName)
+	add("tags", log.Tags)
+	add("msg", log.Message)
+
+	add("cluster", cfg.GlobalCfg.Cluster)
+	add("namespace", log.NamespaceName)
+	add("pod", log.PodName)
+	add("container", log.ContainerName)
+	add("image", log.ContainerImage)
+
+	add("pid", strconv.Itoa(int(log.PID)))
+	add("ppid", strconv.Itoa(int(log.PPID)))
+	add("uid", strconv.Itoa(int(log.UID)))
+	add("hostPid", strconv.Itoa(int(log.HostPID)))
+	add("hostPpid", strconv.Itoa(int(log.HostPPID)))
+	add("processName", log.ProcessName)
+	add("parentProcessName", log.ParentProcessName)
+	add("ancestors", GetAncestryString(log.Ancestors))
+	add("type", log.Type)
+
+	return fmt.Sprintf("LEEF:1.0|%s|%s|%s|%s|%s", SIEMVendor, SIEMProduct, SIEMVersion,
+		escapeLEEFHeader(GetSIEMSignatureID(log)), strings.Join(attributes, "\t"))
+}
@@ -0,0 +1,515 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of KubeArmor
+
+package feeder
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ================ //
+// == Hash Chain == //
+// ================ //
+
+const (
+	// chainSuffix Separator between a record and its sequence number and hash
+	chainSuffix = " #chain="
+
+	// checkpointPrefix Prefix of checkpoint lines
+	checkpointPrefix = "#checkpoint "
+
+	// CheckpointOpen Checkpoint written when a log file is opened
+	CheckpointOpen = "open"
+
+	// CheckpointPeriodic Checkpoint written periodically
+	CheckpointPeriodic = "periodic"
+
+	// CheckpointClose Checkpoint written before a log file is closed or rotated
+	CheckpointClose = "close"
+
+	// CheckpointRecovered Checkpoint written on restart when the end of the chain is not covered by a signed checkpoint
+	CheckpointRecovered = "recovered"
+)
+
+// HashChain Structure
+type HashChain struct {
+	PrivateKey ed25519.PrivateKey
+
+	// the last record
+	Sequence uint64
+	Hash     []byte
+
+	// the last checkpoint
+	CheckpointInterval time.Duration
+	CheckpointTime     time.Time
+	CheckpointSequence uint64
+
+	// the last signed checkpoint before the unverified end of a recovered chain
+	Unverified bool
+	Anchor     uint64
+}
+
+// NewHashChain Function
+func NewHashChain(privateKey ed25519.PrivateKey, checkpointInterval time.Duration) *HashChain {
+	return &HashChain{
+		PrivateKey:         privateKey,
+		Hash:               make([]byte, sha256.Size),
+		CheckpointInterval: checkpointInterval,
+	}
+}
+
+// LoadSigningKey Function (PKCS #8 ed25519 private key in PEM)
+func LoadSigningKey(path string) (ed25519.PrivateKey, error) {
+	data, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s has no PEM data", path)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an ed25519 private key", path)
+	}
+
+	return privateKey, nil
+}
+
+// LoadVerificationKey Function (PKIX ed25519 public key in PEM, or the private key itself)
+func LoadVerificationKey(path string) (ed25519.PublicKey, error) {
+	data, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s has no PEM data", path)
+	}
+
+	if key, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		if publicKey, ok := key.(ed25519.PublicKey); ok {
+			return publicKey, nil
+		}
+		return nil, fmt.Errorf("%s is not an ed25519 public key", path)
+	}
+
+	privateKey, err := LoadSigningKey(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return privateKey.Public().(ed25519.PublicKey), nil
+}
+
+// hashRecord Function (SHA-256 of the previous hash, the sequence number and the record)
+func hashRecord(prev []byte, sequence uint64, record string) []byte {
+	seq := make([]byte, 8)
+	binary.BigEndian.PutUint64(seq, sequence)
+
+	h := sha256.New()
+	_, _ = h.Write(prev)
+	_, _ = h.Write(seq)
+	_, _ = h.Write([]byte(record))
+
+	return h.Sum(nil)
+}
+
+// Chain Function (appends the sequence number and the chained hash to a record)
+func (hc *HashChain) Chain(record string) string {
+	hc.Sequence++
+	hc.Hash = hashRecord(hc.Hash, hc.Sequence, record)
+
+	return record + chainSuffix + strconv.FormatUint(hc.Sequence, 10) + ":" + hex.EncodeToString(hc.Hash)
+}
+
+// Checkpoint Function (returns a checkpoint line signing the last record)
+func (hc *HashChain) Checkpoint(event string) string {
+	now := time.Now().UTC()
+
+	body := fmt.Sprintf("%sevent=%s seq=%d hash=%s", checkpointPrefix, event, hc.Sequence, hex.EncodeToString(hc.Hash))
+	if event == CheckpointRecovered {
+		body = body + fmt.Sprintf(" anchor=%d", hc.Anchor)
+	}
+	body = body + " time=" + now.Format(time.RFC3339Nano)
+	signature := ed25519.Sign(hc.PrivateKey, []byte(body))
+
+	hc.CheckpointTime = now
+	hc.CheckpointSequence = hc.Sequence
+
+	return body + " sig=" + base64.StdEncoding.EncodeToString(signature)
+}
+
+// CheckpointDue Function
+func (hc *HashChain) CheckpointDue() bool {
+	return hc.Sequence != hc.CheckpointSequence && time.Since(hc.CheckpointTime) >= hc.CheckpointInterval
+}
+
+// Recover Function (continues the chain of a log file, Unverified if its end is not covered by a signed checkpoint)
+func (hc *HashChain) Recover(path string) (bool, error) {
+	file, err := openLogFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	publicKey := hc.PrivateKey.Public().(ed25519.PublicKey)
+
+	recovered := false
+
+	// the chain from the last signed checkpoint, broken by any line not linked to it
+	started := false
+	broken := false
+	sequence, hash := uint64(0), []byte{}
+
+	// the last signed checkpoint, and whether nothing follows it
+	anchor := uint64(0)
+	verified := false
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadString('\n')
+		line = strings.TrimSuffix(line, "\n")
+
+		if line != "" {
+			verified = false
+
+			if checkpoint, parseErr := parseCheckpoint(line); parseErr == nil {
+				if ed25519.Verify(publicKey, []byte(checkpoint.Body), checkpoint.Signature) {
+					if !started || checkpoint.Event == CheckpointRecovered {
+						broken = false
+					} else if checkpoint.Sequence != sequence || !bytes.Equal(checkpoint.Hash, hash) {
+						broken = true
+					}
+
+					if !broken {
+						anchor = checkpoint.Sequence
+						verified = true
+					}
+				} else {
+					broken = true
+				}
+
+				started = true
+				sequence, hash = checkpoint.Sequence, checkpoint.Hash
+				recovered = true
+			} else if record, recordSeq, recordHash, parseErr := parseChainedRecord(line); parseErr == nil {
+				if started && (recordSeq != sequence+1 || !bytes.Equal(hashRecord(hash, recordSeq, record), recordHash)) {
+					broken = true
+				}
+
+				started = true
+				sequence, hash = recordSeq, recordHash
+				recovered = true
+			} else {
+				broken = true
+			}
+		}
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return recovered, err
+		}
+	}
+
+	if !recovered {
+		return false, nil
+	}
+
+	// continue the numbering either way, but anchor an unverified end with a recovered checkpoint
+	hc.Sequence, hc.Hash = sequence, hash
+	hc.CheckpointSequence = hc.Sequence
+
+	hc.Unverified = !verified
+	hc.Anchor = anchor
+
+	return true, nil
+}
+
+// openLogFile Function (decompresses gzipped backups)
+func openLogFile(path string) (io.ReadCloser, error) {
+	// #nosec
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasSuffix(path, ".gz") {
+		return file, nil
+	}
+
+	zr, err := gzip.NewReader(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{zr, file}, nil
+}
+
+// parseChainedRecord Function
+func parseChainedRecord(line string) (string, uint64, []byte, error) {
+	idx := strings.LastIndex(line, chainSuffix)
+	if idx < 0 {
+		return "", 0, nil, errors.New("not a chained record")
+	}
+
+	fields := strings.SplitN(line[idx+len(chainSuffix):], ":", 2)
+	if len(fields) != 2 {
+		return "", 0, nil, errors.New("malformed chain suffix")
+	}
+
+	sequence, err := strconv.ParseUint(fields[0], 10, 64)
+	if err != nil {
+		return "", 0, nil, err
+	}
+
+	hash, err := hex.DecodeString(fields[1])
+	if err != nil || len(hash) != sha256.Size {
+		return "", 0, nil, errors.New("malformed chain hash")
+	}
+
+	return line[:idx], sequence, hash, nil
+}
+
+// Checkpoint Structure
+type Checkpoint struct {
+	Event    string
+	Sequence uint64
+	Hash     []byte
+	Anchor   uint64
+	Time     time.Time
+
+	Body      string
+	Signature []byte
+}
+
+// parseCheckpoint Function
+func parseCheckpoint(line string) (*Checkpoint, error) {
+	if !strings.HasPrefix(line, checkpointPrefix) {
+		return nil, errors.New("not a checkpoint")
+	}
+
+	idx := strings.LastIndex(line, " sig=")
+	if idx < 0 {
+		return nil, errors.New("unsigned checkpoint")
+	}
+
+	checkpoint := &Checkpoint{Body: line[:idx]}
+
+	signature, err := base64.StdEncoding.DecodeString(line[idx+len(" sig="):])
+	if err != nil {
+		return nil, err
+	}
+	checkpoint.Signature = signature
+
+	for _, field := range strings.Fields(strings.TrimPrefix(checkpoint.Body, checkpointPrefix)) {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("malformed checkpoint field (%s)", field)
+		}
+
+		switch kv[0] {
+		case "event":
+			checkpoint.Event = kv[1]
+		case "seq":
+			if checkpoint.Sequence, err = strconv.ParseUint(kv[1], 10, 64); err != nil {
+				return nil, err
+			}
+		case "hash":
+			if checkpoint.Hash, err = hex.DecodeString(kv[1]); err != nil {
+				return nil, err
+			}
+		case "anchor":
+			if checkpoint.Anchor, err = strconv.ParseUint(kv[1], 10, 64); err != nil {
+				return nil, err
+			}
+		case "time":
+			if checkpoint.Time, err = time.Parse(time.RFC3339Nano, kv[1]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(checkpoint.Hash) != sha256.Size {
+		return nil, errors.New("malformed checkpoint hash")
+	}
+
+	return checkpoint, nil
+}
+
+// ================== //
+// == Verification == //
+// ================== //
+
+// RecoveredGap Structure (records after Anchor up to Sequence that were not covered by a signed checkpoint on restart)
+type RecoveredGap struct {
+	Anchor   uint64
+	Sequence uint64
+}
+
+// VerifyReport Structure
+type VerifyReport struct {
+	Records     uint64
+	Checkpoints uint64
+
+	FirstSequence uint64
+	LastSequence  uint64
+
+	// records written after the last checkpoint (their removal cannot be detected)
+	Unanchored uint64
+
+	// restarts that found an unverified end of the chain (lines may have been removed or forged)
+	Gaps []RecoveredGap
+}
+
+// VerifyLogFiles Function (the files should be given from the oldest to the newest)
+func VerifyLogFiles(paths []string, publicKey ed25519.PublicKey) (*VerifyReport, error) {
+	report := &VerifyReport{}
+
+	started := false
+
+	sequence := uint64(0)
+	hash := []byte{}
+
+	for _, path := range paths {
+		file, err := openLogFile(path)
+		if err != nil {
+			return report, err
+		}
+
+		lineNum := 0
+
+		reader := bufio.NewReader(file)
+		for {
+			line, readErr := reader.ReadString('\n')
+			line = strings.TrimSuffix(line, "\n")
+
+			if readErr != nil && readErr != io.EOF {
+				_ = file.Close()
+				return report, readErr
+			}
+
+			if line != "" {
+				lineNum++
+
+				if strings.HasPrefix(line, checkpointPrefix) {
+					checkpoint, err := parseCheckpoint(line)
+					if err != nil {
+						_ = file.Close()
+						return report, fmt.Errorf("%s:%d: malformed checkpoint (%s)", path, lineNum, err.Error())
+					}
+
+					if !ed25519.Verify(publicKey, []byte(checkpoint.Body), checkpoint.Signature) {
+						_ = file.Close()
+						return report, fmt.Errorf("%s:%d: invalid checkpoint signature", path, lineNum)
+					}
+
+					if checkpoint.Event == CheckpointRecovered {
+						// the chain restarts from here, whatever came after the anchor is unverified
+						report.Gaps = append(report.Gaps, RecoveredGap{Anchor: checkpoint.Anchor, Sequence: checkpoint.Sequence})
+
+						if !started {
+							report.FirstSequence = checkpoint.Sequence + 1
+						}
+
+						sequence, hash = checkpoint.Sequence, checkpoint.Hash
+						started = true
+					} else if !started {
+						// the first record can only be anchored by an open checkpoint
+						if checkpoint.Event != CheckpointOpen {
+							_ = file.Close()
+							return report, fmt.Errorf("%s:%d: the log does not start with an open checkpoint (lines deleted)", path, lineNum)
+						}
+
+						sequence, hash = checkpoint.Sequence, checkpoint.Hash
+						report.FirstSequence = sequence + 1
+						started = true
+					} else if checkpoint.Sequence != sequence || !bytes.Equal(checkpoint.Hash, hash) {
+						_ = file.Close()
+						return report, fmt.Errorf("%s:%d: the checkpoint at sequence %d does not match the chain at sequence %d (lines deleted, reordered or modified)", path, lineNum, checkpoint.Sequence, sequence)
+					}
+
+					report.Checkpoints++
+					report.Unanchored = 0
+				} else {
+					record, recordSeq, recordHash, err := parseChainedRecord(line)
+					if err != nil {
+						_ = file.Close()
+						return report, fmt.Errorf("%s:%d: %s", path, lineNum, err.Error())
+					}
+
+					if !started {
+						_ = file.Close()
+						return report, fmt.Errorf("%s:%d: the log does not start with an open checkpoint (lines deleted)", path, lineNum)
+					}
+
+					if recordSeq != sequence+1 {
+						_ = file.Close()
+						return report, fmt.Errorf("%s:%d: expected sequence %d, got %d (lines deleted or reordered)", path, lineNum, sequence+1, recordSeq)
+					}
+
+					expected := hashRecord(hash, recordSeq, record)
+					if !bytes.Equal(expected, recordHash) {
+						_ = file.Close()
+						return report, fmt.Errorf("%s:%d: hash mismatch at sequence %d (line modified)", path, lineNum, recordSeq)
+					}
+
+					sequence, hash = recordSeq, recordHash
+
+					report.Records++
+					report.Unanchored++
+				}
+			}
+
+			if readErr == io.EOF {
+				break
+			}
+		}
+
+		_ = file.Close()
+	}
+
+	if !started {
+		return report, errors.New("no checkpoint found")
+	}
+
+	report.LastSequence = sequence
+
+	return report, nil
+}
@@ -0,0 +1,314 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of KubeArmor
+
+package feeder
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	kg "github.com/kubearmor/KubeArmor/KubeArmor/log"
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ======================= //
+// == Kubernetes Events == //
+// ======================= //
+
+const (
+	// DefaultK8sEventAggregationWindow Default window in which identical alerts update the same Event
+	DefaultK8sEventAggregationWindow = 10 * time.Minute
+
+	// DefaultK8sEventRateLimit Default number of API requests per second
+	DefaultK8sEventRateLimit = 10
+
+	// K8sEventReason Reason of the Events on blocked operations
+	K8sEventReason = "PolicyViolation"
+
+	// K8sEventMessageLimit Maximum length of Event messages
+	K8sEventMessageLimit = 1024
+)
+
+// errK8sEventRateLimited is returned if the API request rate is exceeded
+var errK8sEventRateLimited = errors.New("rate limited")
+
+// K8sEventClient Interface (implemented by the Kubernetes handler)
+type K8sEventClient interface {
+	GetPodUID(namespaceName, podName string) (types.UID, error)
+	CreateEvent(event *corev1.Event) (*corev1.Event, error)
+	UpdateEventCount(namespaceName, eventName string, count int32, lastTimestamp time.Time) error
+}
+
+// k8sEventEntry Structure
+type k8sEventEntry struct {
+	Log tp.Log
+
+	// name of the Event ("" if not created yet)
+	Name string
+
+	Count   int32 // count in the API server
+	Pending int32 // alerts not reported yet
+
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// k8sPodUID Structure
+type k8sPodUID struct {
+	UID  types.UID
+	Time time.Time
+}
+
+// K8sEventExporter Structure
+type K8sEventExporter struct {
+	Client K8sEventClient
+	Host   string
+
+	Window  time.Duration
+	Limiter *TokenBucket
+
+	// aggregation key -> event
+	Events map[string]*k8sEventEntry
+
+	// namespace/pod -> uid
+	PodUIDs map[string]k8sPodUID
+}
+
+// NewK8sEventExporter Function
+func NewK8sEventExporter(spec ExporterSpec, client K8sEventClient, host string) (*K8sEventExporter, error) {
+	if client == nil {
+		return nil, errors.New("the Kubernetes client is not initialized")
+	}
+
+	ke := &K8sEventExporter{}
+
+	ke.Client = client
+	ke.Host = host
+
+	ke.Window = DefaultK8sEventAggregationWindow
+	rate := DefaultK8sEventRateLimit
+
+	if spec.K8sEvents != nil {
+		if spec.K8sEvents.AggregationWindow.Duration < 0 || spec.K8sEvents.RateLimit < 0 {
+			return nil, errors.New("aggregation window and rate limit must not be negative")
+		}
+		if spec.K8sEvents.AggregationWindow.Duration > 0 {
+			ke.Window = spec.K8sEvents.AggregationWindow.Duration
+		}
+		if spec.K8sEvents.RateLimit > 0 {
+			rate = spec.K8sEvents.RateLimit
+		}
+	}
+
+	ke.Limiter = &TokenBucket{Rate: rate, Tokens: float64(rate), LastRefill: time.Now()}
+
+	ke.Events = map[string]*k8sEventEntry{}
+	ke.PodUIDs = map[string]k8sPodUID{}
+
+	return ke, nil
+}
+
+// Select Function (only blocked operations in pods)
+func (ke *K8sEventExporter) Select(log tp.Log) bool {
+	return log.Type == "MatchedPolicy" && log.Action == "Block" && log.NamespaceName != "" && log.PodName != ""
+}
+
+// getK8sEventKey Function
+func getK8sEventKey(log tp.Log) string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s/%s", log.NamespaceName, log.PodName, log.ContainerName, log.PolicyName, log.Operation, log.Resource)
+}
+
+// GetK8sEventMessage Function
+func GetK8sEventMessage(log tp.Log) string {
+	msg := fmt.Sprintf("Blocked %s %s by policy %s", log.Operation, log.Resource, log.PolicyName)
+	if log.ContainerName != "" {
+		msg = msg + " (container: " + log.ContainerName + ")"
+	}
+
+	if len(msg) > K8sEventMessageLimit {
+		msg = msg[:K8sEventMessageLimit-3] + "..."
+	}
+
+	return msg
+}
+
+// getPodUID Function
+func (ke *K8sEventExporter) getPodUID(log tp.Log, now time.Time) (types.UID, error) {
+	key := log.NamespaceName + "/" + log.PodName
+
+	if pod, ok := ke.PodUIDs[key]; ok {
+		return pod.UID, nil
+	}
+
+	uid, err := ke.Client.GetPodUID(log.NamespaceName, log.PodName)
+	if err != nil {
+		return "", err
+	}
+	ke.PodUIDs[key] = k8sPodUID{UID: uid, Time: now}
+
+	return uid, nil
+}
+
+// report Function (creates or updates the Event of an entry)
+func (ke *K8sEventExporter) report(entry *k8sEventEntry, now time.Time) error {
+	if !ke.Limiter.Allow(now) {
+		return errK8sEventRateLimited
+	}
+
+	if entry.Name != "" {
+		err := ke.Client.UpdateEventCount(entry.Log.NamespaceName, entry.Name, entry.Count+entry.Pending, now)
+		if err == nil {
+			entry.Count += entry.Pending
+			entry.Pending = 0
+			return nil
+		}
+
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		// the Event has expired in the API server, create a new one
+		entry.Name = ""
+
+		if !ke.Limiter.Allow(now) {
+			return errK8sEventRateLimited
+		}
+	}
+
+	uid, err := ke.getPodUID(entry.Log, now)
+	if err != nil {
+		return err
+	}
+
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: entry.Log.PodName + ".",
+			Namespace:    entry.Log.NamespaceName,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Pod",
+			Namespace:  entry.Log.NamespaceName,
+			Name:       entry.Log.PodName,
+			UID:        uid,
+		},
+		Reason:  K8sEventReason,
+		Message: GetK8sEventMessage(entry.Log),
+		Source: corev1.EventSource{
+			Component: "kubearmor",
+			Host:      ke.Host,
+		},
+		FirstTimestamp: metav1.NewTime(entry.FirstSeen),
+		LastTimestamp:  metav1.NewTime(now),
+		Count:          entry.Count + entry.Pending,
+		Type:           corev1.EventTypeWarning,
+	}
+
+	if entry.Log.ContainerName != "" {
+		event.InvolvedObject.FieldPath = "spec.containers{" + entry.Log.ContainerName + "}"
+	}
+
+	created, err := ke.Client.CreateEvent(event)
+	if err != nil {
+		return err
+	}
+
+	entry.Name = created.Name
+	entry.Count += entry.Pending
+	entry.Pending = 0
+
+	return nil
+}
+
+// expire Function
+func (ke *K8sEventExporter) expire(now time.Time) {
+	for key, entry := range ke.Events {
+		if now.Sub(entry.LastSeen) < ke.Window {
+			continue
+		}
+
+		if entry.Pending > 0 {
+			kg.Warnf("Dropped %d alerts for a Kubernetes Event (%s)", entry.Pending, GetK8sEventMessage(entry.Log))
+		}
+
+		delete(ke.Events, key)
+	}
+
+	for key, pod := range ke.PodUIDs {
+		if now.Sub(pod.Time) >= ke.Window {
+			delete(ke.PodUIDs, key)
+		}
+	}
+}
+
+// flush Function
+func (ke *K8sEventExporter) flush(now time.Time) {
+	limited := 0
+
+	for _, entry := range ke.Events {
+		if entry.Pending == 0 {
+			continue
+		}
+
+		if err := ke.report(entry, now); err == errK8sEventRateLimited {
+			limited++
+		} else if err != nil {
+			kg.Warnf("Failed to report a Kubernetes Event (%s)", err.Error())
+		}
+	}
+
+	if limited > 0 {
+		kg.Warnf("Deferred %d Kubernetes Events (rate limit)", limited)
+	}
+}
+
+// Export Function
+func (ke *K8sEventExporter) Export(logs []tp.Log) error {
+	now := time.Now()
+
+	ke.expire(now)
+
+	for _, log := range logs {
+		count := log.Count
+		if count <= 0 {
+			count = 1
+		}
+
+		key := getK8sEventKey(log)
+
+		entry, ok := ke.Events[key]
+		if !ok {
+			entry = &k8sEventEntry{Log: log, FirstSeen: now}
+			ke.Events[key] = entry
+		}
+
+		entry.Pending += count
+		entry.LastSeen = now
+	}
+
+	ke.flush(now)
+
+	return nil
+}
+
+// Flush Function (retries the alerts deferred by the rate limit)
+func (ke *K8sEventExporter) Flush() error {
+	now := time.Now()
+
+	// report before expiring so that deferred alerts are not dropped while tokens are available
+	ke.flush(now)
+	ke.expire(now)
+
+	return nil
+}
+
+// Close Function
+func (ke *K8sEventExporter) Close() error {
+	ke.flush(time.Now())
+	return nil
+}
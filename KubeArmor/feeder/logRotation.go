@@ -0,0 +1,405 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of KubeArmor
+
+package feeder
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	kg "github.com/kubearmor/KubeArmor/KubeArmor/log"
+)
+
+// =================== //
+// == Rotating File == //
+// =================== //
+
+// LogFlushInterval Interval to flush buffered log lines
+const LogFlushInterval = 1 * time.Second
+
+// LogBufferSize Size of the write buffer for log files
+const LogBufferSize = 64 * 1024
+
+// rotatedTimeFormat Time format used in the names of rotated files
+const rotatedTimeFormat = "20060102-150405.000000"
+
+// RotatingFile Structure
+type RotatingFile struct {
+	Path string
+
+	File   *os.File
+	Writer *bufio.Writer
+
+	Size       int64
+	OpenedTime time.Time
+
+	MaxSize          int64         // rotate when the file gets larger than this (bytes, 0 = no limit)
+	RotationInterval time.Duration // rotate when the file gets older than this (0 = no limit)
+	MaxBackups       int           // number of rotated files to keep (0 = keep all)
+	Compress         bool          // gzip rotated files
+
+	// hash chain (nil if disabled)
+	Chain *HashChain
+
+	// true once the file is closed (File and Writer are also nil if the file could not be reopened after a rotation)
+	Closed bool
+
+	Lock *sync.Mutex
+
+	// serializes the compression and pruning of rotated files
+	BackupLock *sync.Mutex
+
+	StopChan  chan struct{}
+	WgRotator sync.WaitGroup
+}
+
+// NewRotatingFile Function
+func NewRotatingFile(path string, maxSize int64, rotationInterval time.Duration, maxBackups int, compress bool) (*RotatingFile, error) {
+	rf := &RotatingFile{}
+
+	rf.Path = filepath.Clean(path)
+
+	rf.MaxSize = maxSize
+	rf.RotationInterval = rotationInterval
+	rf.MaxBackups = maxBackups
+	rf.Compress = compress
+
+	rf.Lock = new(sync.Mutex)
+	rf.BackupLock = new(sync.Mutex)
+
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+
+	rf.StopChan = make(chan struct{})
+
+	rf.WgRotator.Add(1)
+	go rf.flushPeriodically()
+
+	return rf, nil
+}
+
+// open Function
+func (rf *RotatingFile) open() error {
+	// #nosec
+	file, err := os.OpenFile(rf.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	rf.File = file
+	rf.Writer = bufio.NewWriterSize(file, LogBufferSize)
+	rf.Size = info.Size()
+	rf.OpenedTime = time.Now()
+
+	return nil
+}
+
+// openChained Function (starts a new file with an open checkpoint if the hash chain is enabled)
+func (rf *RotatingFile) openChained() error {
+	if err := rf.open(); err != nil {
+		return err
+	}
+
+	if rf.Chain != nil {
+		return rf.writeCheckpoint(CheckpointOpen)
+	}
+
+	return nil
+}
+
+// flushPeriodically Function
+func (rf *RotatingFile) flushPeriodically() {
+	defer rf.WgRotator.Done()
+
+	ticker := time.NewTicker(LogFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-rf.StopChan:
+			return
+		case <-ticker.C:
+			rf.Lock.Lock()
+
+			if rf.Writer != nil && rf.Chain != nil && rf.Chain.CheckpointDue() {
+				if err := rf.writeCheckpoint(CheckpointPeriodic); err != nil {
+					kg.Err(err.Error())
+				}
+			}
+
+			if rf.Writer != nil {
+				if err := rf.Writer.Flush(); err != nil {
+					kg.Err(err.Error())
+				}
+
+				if rf.RotationInterval > 0 && rf.Size > 0 && time.Since(rf.OpenedTime) >= rf.RotationInterval {
+					if err := rf.rotate(); err != nil {
+						kg.Errf("Failed to rotate %s (%s)", rf.Path, err.Error())
+					}
+				}
+			}
+
+			rf.Lock.Unlock()
+		}
+	}
+}
+
+// WriteString Function
+func (rf *RotatingFile) WriteString(str string) error {
+	rf.Lock.Lock()
+	defer rf.Lock.Unlock()
+
+	if rf.Closed {
+		return fmt.Errorf("%s is already closed", rf.Path)
+	}
+
+	if rf.Writer != nil && rf.MaxSize > 0 && rf.Size > 0 && rf.Size+int64(len(str)) > rf.MaxSize {
+		if err := rf.rotate(); err != nil {
+			kg.Errf("Failed to rotate %s (%s)", rf.Path, err.Error())
+		}
+	}
+
+	// retry opening the file if it could not be opened after a rotation
+	if rf.Writer == nil {
+		if err := rf.openChained(); err != nil {
+			return err
+		}
+	}
+
+	if rf.Chain != nil {
+		lines := strings.Split(strings.TrimSuffix(str, "\n"), "\n")
+		for i, line := range lines {
+			lines[i] = rf.Chain.Chain(line)
+		}
+		str = strings.Join(lines, "\n") + "\n"
+	}
+
+	return rf.write(str)
+}
+
+// write Function (should be called with the lock)
+func (rf *RotatingFile) write(str string) error {
+	n, err := rf.Writer.WriteString(str)
+	rf.Size += int64(n)
+
+	return err
+}
+
+// writeCheckpoint Function (should be called with the lock)
+func (rf *RotatingFile) writeCheckpoint(event string) error {
+	return rf.write(rf.Chain.Checkpoint(event) + "\n")
+}
+
+// EnableHashChain Function (chains every line written from now on)
+func (rf *RotatingFile) EnableHashChain(chain *HashChain) error {
+	rf.Lock.Lock()
+	defer rf.Lock.Unlock()
+
+	if err := rf.Writer.Flush(); err != nil {
+		return err
+	}
+
+	// continue the chain of the current file, or of the last backup if the current file is empty
+	recovered, err := chain.Recover(rf.Path)
+	if err != nil {
+		return err
+	}
+
+	if !recovered && rf.Size > 0 {
+		// move the lines written without the hash chain away
+		if err := rf.rotate(); err != nil {
+			return err
+		}
+	} else if !recovered {
+		if backups := rf.GetBackups(); len(backups) > 0 {
+			if _, err := chain.Recover(backups[len(backups)-1]); err != nil {
+				kg.Warnf("Failed to recover the hash chain from %s (%s)", backups[len(backups)-1], err.Error())
+			}
+		}
+	}
+
+	rf.Chain = chain
+
+	if chain.Unverified {
+		kg.Warnf("The hash chain of %s is not covered by a signed checkpoint after sequence %d, starting a new chain", rf.Path, chain.Anchor)
+		chain.Unverified = false
+		return rf.writeCheckpoint(CheckpointRecovered)
+	}
+
+	return rf.writeCheckpoint(CheckpointOpen)
+}
+
+// rotate Function (should be called with the lock)
+func (rf *RotatingFile) rotate() error {
+	if rf.Chain != nil {
+		if err := rf.writeCheckpoint(CheckpointClose); err != nil {
+			return err
+		}
+	}
+
+	if err := rf.Writer.Flush(); err != nil {
+		return err
+	}
+
+	if err := rf.File.Close(); err != nil {
+		return err
+	}
+
+	// nothing can be written until the file is opened again
+	rf.File = nil
+	rf.Writer = nil
+
+	rotatedPath := rf.Path + "." + time.Now().Format(rotatedTimeFormat)
+	if err := os.Rename(rf.Path, rotatedPath); err != nil {
+		// keep writing to the current file
+		if openErr := rf.openChained(); openErr != nil {
+			return openErr
+		}
+		return err
+	}
+
+	if err := rf.openChained(); err != nil {
+		return err
+	}
+
+	rf.WgRotator.Add(1)
+	go func() {
+		defer rf.WgRotator.Done()
+
+		rf.BackupLock.Lock()
+		defer rf.BackupLock.Unlock()
+
+		if rf.Compress {
+			if err := compressFile(rotatedPath); err != nil {
+				kg.Errf("Failed to compress %s (%s)", rotatedPath, err.Error())
+			}
+		}
+
+		rf.removeOldBackups()
+	}()
+
+	return nil
+}
+
+// compressFile Function
+func compressFile(path string) error {
+	in, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	// #nosec
+	out, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0666)
+	if err != nil {
+		return err
+	}
+
+	zw := gzip.NewWriter(out)
+
+	if _, err := io.Copy(zw, in); err != nil {
+		_ = out.Close()
+		_ = os.Remove(path + ".gz")
+		return err
+	}
+
+	if err := zw.Close(); err != nil {
+		_ = out.Close()
+		_ = os.Remove(path + ".gz")
+		return err
+	}
+
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
+
+// GetBackups Function
+func (rf *RotatingFile) GetBackups() []string {
+	matches, err := filepath.Glob(rf.Path + ".*")
+	if err != nil {
+		return nil
+	}
+
+	backups := []string{}
+
+	for _, match := range matches {
+		suffix := strings.TrimSuffix(strings.TrimPrefix(match, rf.Path+"."), ".gz")
+		if _, err := time.Parse(rotatedTimeFormat, suffix); err == nil {
+			backups = append(backups, match)
+		}
+	}
+
+	// the oldest first
+	sort.Strings(backups)
+
+	return backups
+}
+
+// removeOldBackups Function
+func (rf *RotatingFile) removeOldBackups() {
+	if rf.MaxBackups <= 0 {
+		return
+	}
+
+	backups := rf.GetBackups()
+
+	for len(backups) > rf.MaxBackups {
+		if err := os.Remove(backups[0]); err != nil && !os.IsNotExist(err) {
+			kg.Errf("Failed to remove %s (%s)", backups[0], err.Error())
+		}
+		backups = backups[1:]
+	}
+}
+
+// Close Function
+func (rf *RotatingFile) Close() error {
+	close(rf.StopChan)
+
+	rf.Lock.Lock()
+
+	var err error
+
+	if rf.Writer != nil {
+		if rf.Chain != nil {
+			err = rf.writeCheckpoint(CheckpointClose)
+		}
+		if flushErr := rf.Writer.Flush(); err == nil {
+			err = flushErr
+		}
+		if closeErr := rf.File.Close(); err == nil {
+			err = closeErr
+		}
+
+		rf.Writer = nil
+		rf.File = nil
+	}
+
+	rf.Closed = true
+
+	rf.Lock.Unlock()
+
+	// wait for compression
+	rf.WgRotator.Wait()
+
+	return err
+}
@@ -0,0 +1,351 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of KubeArmor
+
+package feeder
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	cfg "github.com/kubearmor/KubeArmor/KubeArmor/config"
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+	collogs "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	otlpcommon "go.opentelemetry.io/proto/otlp/common/v1"
+	otlplogs "go.opentelemetry.io/proto/otlp/logs/v1"
+	otlpresource "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// =================== //
+// == OTLP Exporter == //
+// =================== //
+
+// DefaultOTLPTimeout Default timeout of an OTLP export request
+const DefaultOTLPTimeout = 10 * time.Second
+
+// OTLPInstrumentationName Name of the instrumentation library in OTLP records
+const OTLPInstrumentationName = "github.com/kubearmor/KubeArmor"
+
+// OTLPSpec Structure
+type OTLPSpec struct {
+	Protocol string            `json:"protocol,omitempty"` // grpc or http
+	Endpoint string            `json:"endpoint"`           // host:port for grpc, URL for http
+	Insecure bool              `json:"insecure,omitempty"` // plain text for grpc
+	Headers  map[string]string `json:"headers,omitempty"`
+
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+}
+
+// OTLPExporter Structure
+type OTLPExporter struct {
+	Protocol string
+	Endpoint string
+	Headers  map[string]string
+	Timeout  time.Duration
+
+	// grpc
+	Conn   *grpc.ClientConn
+	Client collogs.LogsServiceClient
+
+	// http
+	HTTPClient *http.Client
+}
+
+func init() {
+	RegisterExporter("otlp", NewOTLPExporter)
+}
+
+// NewOTLPExporter Function
+func NewOTLPExporter(spec ExporterSpec) (Exporter, error) {
+	if spec.OTLP == nil || spec.OTLP.Endpoint == "" {
+		return nil, errors.New("otlp endpoint is required")
+	}
+
+	oe := &OTLPExporter{}
+
+	oe.Endpoint = spec.OTLP.Endpoint
+	oe.Headers = spec.OTLP.Headers
+
+	oe.Timeout = spec.OTLP.Timeout.Duration
+	if oe.Timeout <= 0 {
+		oe.Timeout = DefaultOTLPTimeout
+	}
+
+	switch spec.OTLP.Protocol {
+	case "", "grpc":
+		oe.Protocol = "grpc"
+
+		// #nosec
+		creds := grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12}))
+		if spec.OTLP.Insecure {
+			creds = grpc.WithInsecure()
+		}
+
+		// connect lazily, so that the collector can start after KubeArmor
+		conn, err := grpc.Dial(oe.Endpoint, creds)
+		if err != nil {
+			return nil, err
+		}
+		oe.Conn = conn
+		oe.Client = collogs.NewLogsServiceClient(conn)
+
+	case "http":
+		oe.Protocol = "http"
+
+		if !strings.HasPrefix(oe.Endpoint, "http://") && !strings.HasPrefix(oe.Endpoint, "https://") {
+			return nil, fmt.Errorf("otlp http endpoint must be a URL (%s is invalid)", oe.Endpoint)
+		}
+		if !strings.HasSuffix(oe.Endpoint, "/v1/logs") {
+			oe.Endpoint = strings.TrimSuffix(oe.Endpoint, "/") + "/v1/logs"
+		}
+
+		oe.HTTPClient = &http.Client{Timeout: oe.Timeout}
+
+	default:
+		return nil, fmt.Errorf("unsupported otlp protocol (%s)", spec.OTLP.Protocol)
+	}
+
+	return oe, nil
+}
+
+// otlpString Function
+func otlpString(key, value string) *otlpcommon.KeyValue {
+	return &otlpcommon.KeyValue{Key: key, Value: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_StringValue{StringValue: value}}}
+}
+
+// otlpInt Function
+func otlpInt(key string, value int64) *otlpcommon.KeyValue {
+	return &otlpcommon.KeyValue{Key: key, Value: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_IntValue{IntValue: value}}}
+}
+
+// appendOTLPString Function
+func appendOTLPString(attrs []*otlpcommon.KeyValue, key, value string) []*otlpcommon.KeyValue {
+	if value == "" {
+		return attrs
+	}
+	return append(attrs, otlpString(key, value))
+}
+
+// appendOTLPStrings Function
+func appendOTLPStrings(attrs []*otlpcommon.KeyValue, key string, values []string) []*otlpcommon.KeyValue {
+	if len(values) == 0 {
+		return attrs
+	}
+
+	array := &otlpcommon.ArrayValue{}
+	for _, value := range values {
+		array.Values = append(array.Values, &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_StringValue{StringValue: value}})
+	}
+
+	return append(attrs, &otlpcommon.KeyValue{Key: key, Value: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_ArrayValue{ArrayValue: array}}})
+}
+
+// appendOTLPInt Function
+func appendOTLPInt(attrs []*otlpcommon.KeyValue, key string, value int64) []*otlpcommon.KeyValue {
+	if value == 0 {
+		return attrs
+	}
+	return append(attrs, otlpInt(key, value))
+}
+
+// GetOTLPResourceAttributes Function
+func GetOTLPResourceAttributes(log tp.Log) []*otlpcommon.KeyValue {
+	attrs := []*otlpcommon.KeyValue{}
+
+	attrs = appendOTLPString(attrs, "k8s.cluster.name", cfg.GlobalCfg.Cluster)
+	attrs = appendOTLPString(attrs, "k8s.node.name", log.HostName)
+	attrs = appendOTLPString(attrs, "host.name", log.HostName)
+
+	attrs = appendOTLPString(attrs, "k8s.namespace.name", log.NamespaceName)
+	attrs = appendOTLPString(attrs, "k8s.pod.name", log.PodName)
+	attrs = appendOTLPString(attrs, "k8s.pod.labels", log.Labels)
+
+	attrs = appendOTLPString(attrs, "container.id", log.ContainerID)
+	attrs = appendOTLPString(attrs, "k8s.container.name", log.ContainerName)
+	attrs = appendOTLPString(attrs, "container.name", log.ContainerName)
+	attrs = appendOTLPString(attrs, "container.image.name", log.ContainerImage)
+
+	return attrs
+}
+
+// GetOTLPLogAttributes Function
+func GetOTLPLogAttributes(log tp.Log) []*otlpcommon.KeyValue {
+	attrs := []*otlpcommon.KeyValue{}
+
+	// process
+	attrs = append(attrs, otlpInt("process.pid", int64(log.PID)))
+	attrs = append(attrs, otlpInt("process.parent_pid", int64(log.PPID)))
+	attrs = append(attrs, otlpInt("kubearmor.host_pid", int64(log.HostPID)))
+	attrs = append(attrs, otlpInt("kubearmor.host_ppid", int64(log.HostPPID)))
+	attrs = append(attrs, otlpInt("kubearmor.uid", int64(log.UID)))
+	attrs = appendOTLPString(attrs, "process.executable.path", log.ProcessName)
+	attrs = appendOTLPString(attrs, "kubearmor.parent_process_name", log.ParentProcessName)
+	attrs = appendOTLPString(attrs, "kubearmor.ancestors", GetAncestryString(log.Ancestors))
+
+	// operation
+	attrs = appendOTLPString(attrs, "kubearmor.type", log.Type)
+	attrs = appendOTLPString(attrs, "kubearmor.source", log.Source)
+	attrs = appendOTLPString(attrs, "kubearmor.operation", log.Operation)
+	attrs = appendOTLPString(attrs, "kubearmor.resource", log.Resource)
+	attrs = appendOTLPString(attrs, "kubearmor.data", log.Data)
+	attrs = appendOTLPString(attrs, "kubearmor.result", log.Result)
+
+	// structured fields
+	attrs = appendOTLPStrings(attrs, "process.command_args", log.ExecArgs)
+	attrs = appendOTLPStrings(attrs, "kubearmor.open_flags", log.OpenFlags)
+	attrs = appendOTLPString(attrs, "kubearmor.socket_family", log.SocketFamily)
+	attrs = appendOTLPString(attrs, "kubearmor.socket_type", log.SocketType)
+	attrs = appendOTLPString(attrs, "net.transport", log.SocketProtocol)
+	attrs = appendOTLPString(attrs, "net.host.ip", log.LocalIP)
+	attrs = appendOTLPInt(attrs, "net.host.port", int64(log.LocalPort))
+	attrs = appendOTLPString(attrs, "net.peer.ip", log.RemoteIP)
+	attrs = appendOTLPInt(attrs, "net.peer.port", int64(log.RemotePort))
+	attrs = appendOTLPInt(attrs, "kubearmor.errno", int64(log.Errno))
+	attrs = appendOTLPInt(attrs, "kubearmor.retval", log.Retval)
+
+	// policy
+	attrs = appendOTLPString(attrs, "kubearmor.policy_name", log.PolicyName)
+	attrs = appendOTLPString(attrs, "kubearmor.severity", log.Severity)
+	attrs = appendOTLPString(attrs, "kubearmor.tags", log.Tags)
+	attrs = appendOTLPString(attrs, "kubearmor.action", log.Action)
+	attrs = appendOTLPString(attrs, "kubearmor.enforcer", log.Enforcer)
+
+	return attrs
+}
+
+// getOTLPSeverity Function
+func getOTLPSeverity(log tp.Log) (otlplogs.SeverityNumber, string) {
+	if isAlert(log) {
+		if strings.HasPrefix(log.Action, "Block") {
+			return otlplogs.SeverityNumber_SEVERITY_NUMBER_WARN, "WARN"
+		}
+		return otlplogs.SeverityNumber_SEVERITY_NUMBER_INFO2, "INFO2"
+	}
+	return otlplogs.SeverityNumber_SEVERITY_NUMBER_INFO, "INFO"
+}
+
+// ConvertToOTLPLogRecord Function
+func ConvertToOTLPLogRecord(log tp.Log) *otlplogs.LogRecord {
+	record := &otlplogs.LogRecord{}
+
+	record.TimeUnixNano = uint64(getLogTime(log).UnixNano())
+
+	record.SeverityNumber, record.SeverityText = getOTLPSeverity(log)
+
+	body := log.Message
+	if body == "" {
+		body = strings.TrimSpace(log.Operation + " " + log.Resource)
+	}
+	record.Body = &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_StringValue{StringValue: body}}
+
+	record.Attributes = GetOTLPLogAttributes(log)
+
+	return record
+}
+
+// ConvertToOTLPRequest Function
+func ConvertToOTLPRequest(logs []tp.Log) *collogs.ExportLogsServiceRequest {
+	req := &collogs.ExportLogsServiceRequest{}
+
+	// group records by the resource that produced them
+	resourceLogs := map[string]*otlplogs.InstrumentationLibraryLogs{}
+
+	for _, log := range logs {
+		key := strings.Join([]string{log.HostName, log.NamespaceName, log.PodName, log.ContainerID}, "/")
+
+		libraryLogs, ok := resourceLogs[key]
+		if !ok {
+			libraryLogs = &otlplogs.InstrumentationLibraryLogs{
+				InstrumentationLibrary: &otlpcommon.InstrumentationLibrary{Name: OTLPInstrumentationName},
+			}
+			resourceLogs[key] = libraryLogs
+
+			req.ResourceLogs = append(req.ResourceLogs, &otlplogs.ResourceLogs{
+				Resource:                   &otlpresource.Resource{Attributes: GetOTLPResourceAttributes(log)},
+				InstrumentationLibraryLogs: []*otlplogs.InstrumentationLibraryLogs{libraryLogs},
+			})
+		}
+
+		libraryLogs.Logs = append(libraryLogs.Logs, ConvertToOTLPLogRecord(log))
+	}
+
+	return req
+}
+
+// exportGRPC Function
+func (oe *OTLPExporter) exportGRPC(req *collogs.ExportLogsServiceRequest) error {
+	ctx, cancel := context.WithTimeout(context.Background(), oe.Timeout)
+	defer cancel()
+
+	if len(oe.Headers) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(oe.Headers))
+	}
+
+	_, err := oe.Client.Export(ctx, req)
+	return err
+}
+
+// exportHTTP Function
+func (oe *OTLPExporter) exportHTTP(req *collogs.ExportLogsServiceRequest) error {
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, oe.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/x-protobuf")
+	for key, value := range oe.Headers {
+		httpReq.Header.Set(key, value)
+	}
+
+	resp, err := oe.HTTPClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	// drain the body to reuse the connection
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("otlp collector returned %s", resp.Status)
+	}
+
+	return nil
+}
+
+// Export Function
+func (oe *OTLPExporter) Export(logs []tp.Log) error {
+	req := ConvertToOTLPRequest(logs)
+
+	if oe.Protocol == "http" {
+		return oe.exportHTTP(req)
+	}
+	return oe.exportGRPC(req)
+}
+
+// Close Function
+func (oe *OTLPExporter) Close() error {
+	if oe.Conn != nil {
+		return oe.Conn.Close()
+	}
+	return nil
+}
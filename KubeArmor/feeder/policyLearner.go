@@ -0,0 +1,434 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of KubeArmor
+
+package feeder
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+	"sigs.k8s.io/yaml"
+)
+
+// ===================== //
+// == Policy Learning == //
+// ===================== //
+
+const (
+	// DefaultLearningCollapseThreshold Default number of sibling paths collapsed into a directory
+	DefaultLearningCollapseThreshold = 8
+
+	// DefaultLearningMaxPaths Default maximum paths recorded for each pod
+	DefaultLearningMaxPaths = 10000
+)
+
+// ErrNoLearnedProfile is returned if nothing has been learned for a pod
+var ErrNoLearnedProfile = errors.New("no behavior learned for the pod")
+
+// LearnedProfile Structure
+type LearnedProfile struct {
+	NamespaceName string
+	PodName       string
+	Labels        string
+
+	// executable -> true
+	Processes map[string]bool
+
+	// file path -> read only
+	Files map[string]bool
+
+	// protocol -> true
+	Protocols map[string]bool
+
+	// capability -> true
+	Capabilities map[string]bool
+
+	// paths not recorded due to the limit
+	Dropped uint64
+
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// PolicyLearner Structure
+type PolicyLearner struct {
+	CollapseThreshold int
+	MaxPaths          int
+
+	// namespace/pod -> profile
+	Profiles     map[string]*LearnedProfile
+	ProfilesLock *sync.RWMutex
+}
+
+// NewPolicyLearner Function
+func NewPolicyLearner(collapseThreshold, maxPaths int) *PolicyLearner {
+	if collapseThreshold < 2 {
+		collapseThreshold = DefaultLearningCollapseThreshold
+	}
+
+	if maxPaths <= 0 {
+		maxPaths = DefaultLearningMaxPaths
+	}
+
+	return &PolicyLearner{
+		CollapseThreshold: collapseThreshold,
+		MaxPaths:          maxPaths,
+		Profiles:          map[string]*LearnedProfile{},
+		ProfilesLock:      new(sync.RWMutex),
+	}
+}
+
+// getLearnedProtocols Function (protocols and capabilities used by a socket)
+func getLearnedProtocols(log tp.Log) ([]string, []string) {
+	protocols := []string{}
+	capabilities := []string{}
+
+	switch log.SocketProtocol {
+	case "TCP":
+		protocols = append(protocols, "tcp")
+	case "UDP":
+		protocols = append(protocols, "udp")
+	case "ICMP", "ICMPv6":
+		protocols = append(protocols, "icmp")
+	}
+
+	if log.SocketType == "SOCK_RAW" {
+		protocols = append(protocols, "raw")
+		capabilities = append(capabilities, "net_raw")
+	}
+
+	return protocols, capabilities
+}
+
+// getLearnedCapability Function (e.g., net_raw for CAP_NET_RAW)
+func getLearnedCapability(resource string) string {
+	capability := strings.ToLower(strings.Split(strings.TrimSpace(resource), " ")[0])
+	return strings.TrimPrefix(capability, "cap_")
+}
+
+// addPath Function
+func (profile *LearnedProfile) addPath(limit int, paths map[string]bool, path string, readOnly bool) {
+	if prev, ok := paths[path]; ok {
+		paths[path] = prev && readOnly
+		return
+	}
+
+	if len(profile.Processes)+len(profile.Files) >= limit {
+		profile.Dropped++
+		return
+	}
+
+	paths[path] = readOnly
+}
+
+// Record Function (records the operations of a container log)
+func (pl *PolicyLearner) Record(log tp.Log) {
+	if log.NamespaceName == "" || log.PodName == "" || log.Result != "Passed" {
+		return
+	}
+
+	pl.ProfilesLock.Lock()
+	defer pl.ProfilesLock.Unlock()
+
+	key := log.NamespaceName + "/" + log.PodName
+
+	profile, ok := pl.Profiles[key]
+	if !ok {
+		profile = &LearnedProfile{
+			NamespaceName: log.NamespaceName,
+			PodName:       log.PodName,
+			Processes:     map[string]bool{},
+			Files:         map[string]bool{},
+			Protocols:     map[string]bool{},
+			Capabilities:  map[string]bool{},
+			FirstSeen:     time.Now().UTC(),
+		}
+		pl.Profiles[key] = profile
+	}
+
+	profile.Labels = log.Labels
+	profile.LastSeen = time.Now().UTC()
+
+	switch log.Operation {
+	case "Process":
+		if path := strings.Split(log.Resource, " ")[0]; strings.HasPrefix(path, "/") {
+			profile.addPath(pl.MaxPaths, profile.Processes, path, true)
+		}
+	case "File":
+		if strings.HasPrefix(log.Resource, "/") {
+			profile.addPath(pl.MaxPaths, profile.Files, log.Resource, strings.Contains(log.Data, "O_RDONLY"))
+		}
+	case "Network":
+		protocols, capabilities := getLearnedProtocols(log)
+		for _, protocol := range protocols {
+			profile.Protocols[protocol] = true
+		}
+		for _, capability := range capabilities {
+			profile.Capabilities[capability] = true
+		}
+	case "Capabilities":
+		if capability := getLearnedCapability(log.Resource); capability != "" {
+			profile.Capabilities[capability] = true
+		}
+	}
+}
+
+// Forget Function (removes the profile of a deleted pod)
+func (pl *PolicyLearner) Forget(namespaceName, podName string) {
+	pl.ProfilesLock.Lock()
+	defer pl.ProfilesLock.Unlock()
+
+	delete(pl.Profiles, namespaceName+"/"+podName)
+}
+
+// parentDirectory Function (e.g., /etc/nginx/ for /etc/nginx/nginx.conf, "" for /)
+func parentDirectory(path string) string {
+	path = strings.TrimSuffix(path, "/")
+
+	idx := strings.LastIndex(path, "/")
+	if idx < 0 {
+		return ""
+	}
+
+	return path[:idx+1]
+}
+
+// learnedDirectory Structure
+type learnedDirectory struct {
+	Path      string
+	Recursive bool
+	ReadOnly  bool
+}
+
+// collapsePaths Function (collapses the highest directories with at least threshold entries, path -> read only)
+func collapsePaths(paths map[string]bool, threshold int) ([]string, []learnedDirectory) {
+	// directory -> direct entries (files and subdirectories)
+	children := map[string]map[string]bool{}
+
+	for path := range paths {
+		for child, dir := path, parentDirectory(path); dir != ""; child, dir = dir, parentDirectory(dir) {
+			if _, ok := children[dir]; !ok {
+				children[dir] = map[string]bool{}
+			}
+			children[dir][child] = true
+		}
+	}
+
+	dirs := []string{}
+	for dir := range children {
+		dirs = append(dirs, dir)
+	}
+
+	// parents first
+	sort.Slice(dirs, func(i, j int) bool {
+		if len(dirs[i]) != len(dirs[j]) {
+			return len(dirs[i]) < len(dirs[j])
+		}
+		return dirs[i] < dirs[j]
+	})
+
+	collapsed := map[string]bool{}
+
+	isCollapsed := func(path string) bool {
+		for dir := parentDirectory(path); dir != ""; dir = parentDirectory(dir) {
+			if collapsed[dir] {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, dir := range dirs {
+		if dir == "/" || isCollapsed(dir) {
+			continue
+		}
+
+		if len(children[dir]) >= threshold {
+			collapsed[dir] = true
+		}
+	}
+
+	learnedPaths := []string{}
+	learnedDirs := []learnedDirectory{}
+
+	for _, dir := range dirs {
+		if !collapsed[dir] {
+			continue
+		}
+
+		learned := learnedDirectory{Path: dir, ReadOnly: true}
+
+		for child := range children[dir] {
+			if strings.HasSuffix(child, "/") {
+				learned.Recursive = true
+				break
+			}
+		}
+
+		for path, readOnly := range paths {
+			if strings.HasPrefix(path, dir) && !readOnly {
+				learned.ReadOnly = false
+				break
+			}
+		}
+
+		learnedDirs = append(learnedDirs, learned)
+	}
+
+	for path := range paths {
+		if !isCollapsed(path) {
+			learnedPaths = append(learnedPaths, path)
+		}
+	}
+
+	sort.Strings(learnedPaths)
+	sort.Slice(learnedDirs, func(i, j int) bool {
+		return learnedDirs[i].Path < learnedDirs[j].Path
+	})
+
+	return learnedPaths, learnedDirs
+}
+
+// learnedPolicyMetadata Structure
+type learnedPolicyMetadata struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// learnedPolicySpec Structure
+type learnedPolicySpec struct {
+	Selector tp.SelectorType `json:"selector"`
+
+	Process      *tp.ProcessType      `json:"process,omitempty"`
+	File         *tp.FileType         `json:"file,omitempty"`
+	Network      *tp.NetworkType      `json:"network,omitempty"`
+	Capabilities *tp.CapabilitiesType `json:"capabilities,omitempty"`
+
+	Action string `json:"action"`
+}
+
+// learnedPolicy Structure
+type learnedPolicy struct {
+	APIVersion string                `json:"apiVersion"`
+	Kind       string                `json:"kind"`
+	Metadata   learnedPolicyMetadata `json:"metadata"`
+	Spec       learnedPolicySpec     `json:"spec"`
+}
+
+// GeneratePolicy Function (returns an allow-list KubeArmorPolicy in YAML for the labels of a pod)
+func (pl *PolicyLearner) GeneratePolicy(namespaceName, podName string, collapseThreshold int) (string, error) {
+	if collapseThreshold < 2 {
+		collapseThreshold = pl.CollapseThreshold
+	}
+
+	pl.ProfilesLock.RLock()
+	defer pl.ProfilesLock.RUnlock()
+
+	profile, ok := pl.Profiles[namespaceName+"/"+podName]
+	if !ok {
+		return "", ErrNoLearnedProfile
+	}
+
+	matchLabels := map[string]string{}
+	for _, label := range strings.Split(profile.Labels, ",") {
+		if kv := strings.SplitN(label, "=", 2); len(kv) == 2 && kv[0] != "" {
+			matchLabels[kv[0]] = kv[1]
+		}
+	}
+
+	// an empty selector would select every pod in the namespace
+	if len(matchLabels) == 0 {
+		return "", fmt.Errorf("the pod %s/%s has no labels to select", namespaceName, podName)
+	}
+
+	policy := learnedPolicy{
+		APIVersion: "security.kubearmor.com/v1",
+		Kind:       "KubeArmorPolicy",
+		Metadata: learnedPolicyMetadata{
+			Name:      "learned-" + podName,
+			Namespace: namespaceName,
+		},
+		Spec: learnedPolicySpec{
+			Selector: tp.SelectorType{MatchLabels: matchLabels},
+			Action:   "Allow",
+		},
+	}
+
+	if len(profile.Processes) > 0 {
+		process := &tp.ProcessType{}
+
+		paths, dirs := collapsePaths(profile.Processes, collapseThreshold)
+		for _, path := range paths {
+			process.MatchPaths = append(process.MatchPaths, tp.ProcessPathType{Path: path})
+		}
+		for _, dir := range dirs {
+			process.MatchDirectories = append(process.MatchDirectories, tp.ProcessDirectoryType{Directory: dir.Path, Recursive: dir.Recursive})
+		}
+
+		policy.Spec.Process = process
+	}
+
+	if len(profile.Files) > 0 {
+		file := &tp.FileType{}
+
+		paths, dirs := collapsePaths(profile.Files, collapseThreshold)
+		for _, path := range paths {
+			file.MatchPaths = append(file.MatchPaths, tp.FilePathType{Path: path, ReadOnly: profile.Files[path]})
+		}
+		for _, dir := range dirs {
+			file.MatchDirectories = append(file.MatchDirectories, tp.FileDirectoryType{Directory: dir.Path, Recursive: dir.Recursive, ReadOnly: dir.ReadOnly})
+		}
+
+		policy.Spec.File = file
+	}
+
+	if len(profile.Protocols) > 0 {
+		network := &tp.NetworkType{}
+
+		for _, protocol := range getSortedKeys(profile.Protocols) {
+			network.MatchProtocols = append(network.MatchProtocols, tp.NetworkProtocolType{Protocol: protocol})
+		}
+
+		policy.Spec.Network = network
+	}
+
+	if len(profile.Capabilities) > 0 {
+		capabilities := &tp.CapabilitiesType{}
+
+		for _, capability := range getSortedKeys(profile.Capabilities) {
+			capabilities.MatchCapabilities = append(capabilities.MatchCapabilities, tp.CapabilitiesCapabilityType{Capability: capability})
+		}
+
+		policy.Spec.Capabilities = capabilities
+	}
+
+	arr, err := yaml.Marshal(policy)
+	if err != nil {
+		return "", err
+	}
+
+	header := fmt.Sprintf("# Learned from %s/%s between %s and %s\n", namespaceName, podName,
+		profile.FirstSeen.Format(time.RFC3339), profile.LastSeen.Format(time.RFC3339))
+	header = header + "# Note: capabilities are learned from capability events and raw sockets only\n"
+	if profile.Dropped > 0 {
+		header = header + fmt.Sprintf("# Warning: %d paths were not recorded (learningMaxPaths)\n", profile.Dropped)
+	}
+
+	return header + string(arr), nil
+}
+
+// getSortedKeys Function
+func getSortedKeys(set map[string]bool) []string {
+	keys := []string{}
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
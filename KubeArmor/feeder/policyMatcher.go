@@ -4,6 +4,7 @@
 package feeder
 
 import (
+	"net"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -11,6 +12,7 @@ import (
 	"strings"
 	"syscall"
 
+	kl "github.com/kubearmor/KubeArmor/KubeArmor/common"
 	cfg "github.com/kubearmor/KubeArmor/KubeArmor/config"
 	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
 )
@@ -35,6 +37,30 @@ func getProtocolFromName(proto string) string {
 	}
 }
 
+// getEndpointResource Function (e.g., cidr=10.0.0.0/8 ports=5432,8000-8080 direction=connect)
+func getEndpointResource(endpoint tp.NetworkEndpointType) string {
+	resource := "cidr=" + endpoint.CIDR
+	if len(endpoint.Ports) > 0 {
+		resource = resource + " ports=" + strings.Join(endpoint.Ports, ",")
+	}
+	return resource + " direction=" + endpoint.Direction
+}
+
+// getListenerResource Function (e.g., address=127.0.0.1 ports=8080)
+func getListenerResource(listener tp.NetworkListenerType) string {
+	resources := []string{}
+	if len(listener.Address) > 0 {
+		resources = append(resources, "address="+listener.Address)
+	}
+	if len(listener.Ports) > 0 {
+		resources = append(resources, "ports="+strings.Join(listener.Ports, ","))
+	}
+	if len(resources) == 0 {
+		return "address=any"
+	}
+	return strings.Join(resources, " ")
+}
+
 func getFileProcessUID(path string) string {
 	info, err := os.Stat(path)
 	if err == nil {
@@ -60,6 +86,19 @@ func getOperationAndCapabilityFromName(capName string) (op, cap string) {
 	return op, cap
 }
 
+// syscall -> enforced by BPF-LSM (no LSM hooks for unshare and setns, so they are audited)
+var restrictedSyscalls = map[string]bool{
+	"ptrace":          true,
+	"mount":           true,
+	"unshare":         false,
+	"setns":           false,
+	"init_module":     true,
+	"finit_module":    true,
+	"kexec_load":      true,
+	"kexec_file_load": true,
+	"bpf":             true,
+}
+
 // newMatchPolicy Function
 func (fd *Feeder) newMatchPolicy(policyEnabled int, policyName, src string, mp interface{}) tp.MatchPolicy {
 	match := tp.MatchPolicy{
@@ -67,6 +106,11 @@ func (fd *Feeder) newMatchPolicy(policyEnabled int, policyName, src string, mp i
 		Source:     src,
 	}
 
+	// policies are audited, not enforced, while learning
+	if policyEnabled == tp.KubeArmorPolicyLearning {
+		policyEnabled = tp.KubeArmorPolicyAudited
+	}
+
 	if ppt, ok := mp.(tp.ProcessPathType); ok {
 		match.Severity = strconv.Itoa(ppt.Severity)
 		match.Tags = ppt.Tags
@@ -197,6 +241,89 @@ func (fd *Feeder) newMatchPolicy(policyEnabled int, policyName, src string, mp i
 		} else {
 			match.Action = npt.Action
 		}
+	} else if ept, ok := mp.(tp.NetworkEndpointType); ok {
+		match.Severity = strconv.Itoa(ept.Severity)
+		match.Tags = ept.Tags
+		match.Message = ept.Message
+
+		ipNet, err := kl.ParseCIDR(ept.CIDR)
+		if err != nil {
+			fd.Debugf("MatchPolicy CIDR parsing error: %s\n", err.Error())
+			return tp.MatchPolicy{}
+		}
+
+		for _, port := range ept.Ports {
+			portRange, err := kl.ParsePortRange(port)
+			if err != nil {
+				fd.Debugf("MatchPolicy port parsing error: %s\n", err.Error())
+				return tp.MatchPolicy{}
+			}
+			match.Ports = append(match.Ports, portRange)
+		}
+
+		if ept.Direction != "connect" && ept.Direction != "accept" {
+			fd.Debugf("MatchPolicy unknown direction: %s\n", ept.Direction)
+			return tp.MatchPolicy{}
+		}
+
+		match.Operation = "Network"
+		match.Resource = getEndpointResource(ept)
+		match.ResourceType = "Endpoint"
+
+		match.IPNet = ipNet
+		match.Direction = ept.Direction
+
+		// only outgoing connections are enforced (by BPF-LSM), the others are audited
+		enforced := fd.Enforcer == "BPFLSM" && ept.Direction == "connect"
+
+		if policyEnabled == tp.KubeArmorPolicyAudited && ept.Action == "Allow" {
+			match.Action = "Audit (" + ept.Action + ")"
+		} else if policyEnabled == tp.KubeArmorPolicyAudited && ept.Action == "Block" {
+			match.Action = "Audit (" + ept.Action + ")"
+		} else if policyEnabled == tp.KubeArmorPolicyEnabled && !enforced && (ept.Action == "Allow" || ept.Action == "Block") {
+			match.Action = "Audit (" + ept.Action + ")"
+		} else {
+			match.Action = ept.Action
+		}
+	} else if nlt, ok := mp.(tp.NetworkListenerType); ok {
+		match.Severity = strconv.Itoa(nlt.Severity)
+		match.Tags = nlt.Tags
+		match.Message = nlt.Message
+
+		if len(nlt.Address) > 0 {
+			ipNet, err := kl.ParseCIDR(nlt.Address)
+			if err != nil {
+				fd.Debugf("MatchPolicy address parsing error: %s\n", err.Error())
+				return tp.MatchPolicy{}
+			}
+			match.IPNet = ipNet
+		}
+
+		for _, port := range nlt.Ports {
+			portRange, err := kl.ParsePortRange(port)
+			if err != nil {
+				fd.Debugf("MatchPolicy port parsing error: %s\n", err.Error())
+				return tp.MatchPolicy{}
+			}
+			match.Ports = append(match.Ports, portRange)
+		}
+
+		match.Operation = "Network"
+		match.Resource = getListenerResource(nlt)
+		match.ResourceType = "Listener"
+
+		// only BPF-LSM can check local addresses and ports, the other enforcers audit them
+		enforced := fd.Enforcer == "BPFLSM"
+
+		if policyEnabled == tp.KubeArmorPolicyAudited && nlt.Action == "Allow" {
+			match.Action = "Audit (" + nlt.Action + ")"
+		} else if policyEnabled == tp.KubeArmorPolicyAudited && nlt.Action == "Block" {
+			match.Action = "Audit (" + nlt.Action + ")"
+		} else if policyEnabled == tp.KubeArmorPolicyEnabled && !enforced && (nlt.Action == "Allow" || nlt.Action == "Block") {
+			match.Action = "Audit (" + nlt.Action + ")"
+		} else {
+			match.Action = nlt.Action
+		}
 	} else if cct, ok := mp.(tp.CapabilitiesCapabilityType); ok {
 		match.Severity = strconv.Itoa(cct.Severity)
 		match.Tags = cct.Tags
@@ -215,6 +342,36 @@ func (fd *Feeder) newMatchPolicy(policyEnabled int, policyName, src string, mp i
 		} else {
 			match.Action = cct.Action
 		}
+	} else if smt, ok := mp.(tp.SyscallMatchType); ok {
+		match.Severity = strconv.Itoa(smt.Severity)
+		match.Tags = smt.Tags
+		match.Message = smt.Message
+
+		enforceable, ok := restrictedSyscalls[smt.Syscall]
+		if !ok {
+			fd.Debugf("MatchPolicy unknown syscall: %s\n", smt.Syscall)
+			return tp.MatchPolicy{}
+		}
+
+		// syscalls can only be blocked or audited
+		if smt.Action != "Block" && smt.Action != "Audit" {
+			fd.Debugf("MatchPolicy unsupported syscall action: %s\n", smt.Action)
+			return tp.MatchPolicy{}
+		}
+
+		match.Operation = "Syscall"
+		match.Resource = smt.Syscall
+		match.ResourceType = "Syscall"
+
+		enforced := fd.Enforcer == "BPFLSM" && enforceable
+
+		if policyEnabled == tp.KubeArmorPolicyAudited && smt.Action == "Block" {
+			match.Action = "Audit (" + smt.Action + ")"
+		} else if policyEnabled == tp.KubeArmorPolicyEnabled && !enforced && smt.Action == "Block" {
+			match.Action = "Audit (" + smt.Action + ")"
+		} else {
+			match.Action = smt.Action
+		}
 	} else {
 		return tp.MatchPolicy{}
 	}
@@ -408,6 +565,66 @@ func (fd *Feeder) UpdateSecurityPolicies(action string, endPoint tp.EndPoint) {
 
 		}
 
+		for _, endpoint := range secPolicy.Spec.Network.MatchEndpoints {
+			if len(endpoint.CIDR) == 0 {
+				continue
+			}
+
+			fromSource := ""
+
+			if len(endpoint.FromSource) == 0 {
+				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, endpoint)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+				continue
+			}
+
+			for _, src := range endpoint.FromSource {
+				if len(src.Path) > 0 {
+					fromSource = src.Path
+				} else {
+					continue
+				}
+
+				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, endpoint)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				match.IsFromSource = len(fromSource) > 0
+				matches.Policies = append(matches.Policies, match)
+			}
+		}
+
+		for _, listener := range secPolicy.Spec.Network.MatchListeners {
+			fromSource := ""
+
+			if len(listener.FromSource) == 0 {
+				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, listener)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+				continue
+			}
+
+			for _, src := range listener.FromSource {
+				if len(src.Path) > 0 {
+					fromSource = src.Path
+				} else {
+					continue
+				}
+
+				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, listener)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				match.IsFromSource = len(fromSource) > 0
+				matches.Policies = append(matches.Policies, match)
+			}
+		}
+
 		for _, cap := range secPolicy.Spec.Capabilities.MatchCapabilities {
 			if len(cap.Capability) == 0 {
 				continue
@@ -439,6 +656,38 @@ func (fd *Feeder) UpdateSecurityPolicies(action string, endPoint tp.EndPoint) {
 				matches.Policies = append(matches.Policies, match)
 			}
 		}
+
+		for _, sc := range secPolicy.Spec.Syscalls.MatchSyscalls {
+			if len(sc.Syscall) == 0 {
+				continue
+			}
+
+			fromSource := ""
+
+			if len(sc.FromSource) == 0 {
+				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, sc)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+				continue
+			}
+
+			for _, src := range sc.FromSource {
+				if len(src.Path) > 0 {
+					fromSource = src.Path
+				} else {
+					continue
+				}
+
+				match := fd.newMatchPolicy(endPoint.PolicyEnabled, policyName, fromSource, sc)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				match.IsFromSource = len(fromSource) > 0
+				matches.Policies = append(matches.Policies, match)
+			}
+		}
 	}
 
 	fd.SecurityPoliciesLock.Lock()
@@ -633,6 +882,66 @@ func (fd *Feeder) UpdateHostSecurityPolicies(action string, secPolicies []tp.Hos
 			}
 		}
 
+		for _, endpoint := range secPolicy.Spec.Network.MatchEndpoints {
+			if len(endpoint.CIDR) == 0 {
+				continue
+			}
+
+			fromSource := ""
+
+			if len(endpoint.FromSource) == 0 {
+				match := fd.newMatchPolicy(fd.Node.PolicyEnabled, policyName, fromSource, endpoint)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+				continue
+			}
+
+			for _, src := range endpoint.FromSource {
+				if len(src.Path) > 0 {
+					fromSource = src.Path
+				} else {
+					continue
+				}
+
+				match := fd.newMatchPolicy(fd.Node.PolicyEnabled, policyName, fromSource, endpoint)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				match.IsFromSource = len(fromSource) > 0
+				matches.Policies = append(matches.Policies, match)
+			}
+		}
+
+		for _, listener := range secPolicy.Spec.Network.MatchListeners {
+			fromSource := ""
+
+			if len(listener.FromSource) == 0 {
+				match := fd.newMatchPolicy(fd.Node.PolicyEnabled, policyName, fromSource, listener)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+				continue
+			}
+
+			for _, src := range listener.FromSource {
+				if len(src.Path) > 0 {
+					fromSource = src.Path
+				} else {
+					continue
+				}
+
+				match := fd.newMatchPolicy(fd.Node.PolicyEnabled, policyName, fromSource, listener)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				match.IsFromSource = len(fromSource) > 0
+				matches.Policies = append(matches.Policies, match)
+			}
+		}
+
 		for _, cap := range secPolicy.Spec.Capabilities.MatchCapabilities {
 			if len(cap.Capability) == 0 {
 				continue
@@ -664,6 +973,38 @@ func (fd *Feeder) UpdateHostSecurityPolicies(action string, secPolicies []tp.Hos
 				matches.Policies = append(matches.Policies, match)
 			}
 		}
+
+		for _, sc := range secPolicy.Spec.Syscalls.MatchSyscalls {
+			if len(sc.Syscall) == 0 {
+				continue
+			}
+
+			fromSource := ""
+
+			if len(sc.FromSource) == 0 {
+				match := fd.newMatchPolicy(fd.Node.PolicyEnabled, policyName, fromSource, sc)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				matches.Policies = append(matches.Policies, match)
+				continue
+			}
+
+			for _, src := range sc.FromSource {
+				if len(src.Path) > 0 {
+					fromSource = src.Path
+				} else {
+					continue
+				}
+
+				match := fd.newMatchPolicy(fd.Node.PolicyEnabled, policyName, fromSource, sc)
+				if len(match.Resource) == 0 {
+					continue
+				}
+				match.IsFromSource = len(fromSource) > 0
+				matches.Policies = append(matches.Policies, match)
+			}
+		}
 	}
 
 	fd.SecurityPoliciesLock.Lock()
@@ -729,12 +1070,104 @@ func getDirectoryPart(path string) string {
 	return "__not_absolute_path__"
 }
 
+// getNetworkDirection Function (connect, accept, bind, or listen, empty for the other network events)
+func getNetworkDirection(log tp.Log) string {
+	if strings.Contains(log.Data, "syscall=SYS_BIND") && log.LocalIP != "" {
+		return "bind"
+	}
+
+	if strings.Contains(log.Data, "syscall=SYS_LISTEN") {
+		return "listen"
+	}
+
+	if log.RemoteIP == "" {
+		return ""
+	}
+
+	if strings.Contains(log.Data, "kprobe=tcp_connect") || strings.Contains(log.Data, "syscall=SYS_CONNECT") {
+		return "connect"
+	}
+
+	if strings.Contains(log.Data, "kprobe=tcp_accept") || strings.Contains(log.Data, "syscall=SYS_ACCEPT") {
+		return "accept"
+	}
+
+	return ""
+}
+
+// matchEndpoint Function (matches the remote address and port of a connection)
+func matchEndpoint(secPolicy tp.MatchPolicy, log tp.Log) bool {
+	ip := net.ParseIP(log.RemoteIP)
+	if ip == nil || secPolicy.IPNet == nil || !secPolicy.IPNet.Contains(ip) {
+		return false
+	}
+
+	if len(secPolicy.Ports) == 0 {
+		return true
+	}
+
+	for _, port := range secPolicy.Ports {
+		if log.RemotePort >= int32(port.Min) && log.RemotePort <= int32(port.Max) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchListener Function (matches the local address and port of a bind)
+func matchListener(secPolicy tp.MatchPolicy, log tp.Log) bool {
+	if secPolicy.IPNet != nil {
+		ip := net.ParseIP(log.LocalIP)
+		if ip == nil || !secPolicy.IPNet.Contains(ip) {
+			return false
+		}
+	}
+
+	if len(secPolicy.Ports) == 0 {
+		return true
+	}
+
+	for _, port := range secPolicy.Ports {
+		if log.LocalPort >= int32(port.Min) && log.LocalPort <= int32(port.Max) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isNetworkRuleApplicable Function (endpoints apply to the connections in their direction, listeners to binds)
+func isNetworkRuleApplicable(secPolicy tp.MatchPolicy, direction string, log tp.Log, listeners bool) bool {
+	switch secPolicy.ResourceType {
+	case "Endpoint":
+		return secPolicy.Direction == direction
+	case "Listener":
+		// binds to ephemeral ports are not listeners, and the port of a listen is only known to the enforcer
+		return direction == "bind" && log.LocalPort != 0
+	default:
+		// binds and listens are decided by the listener rules if there are any
+		return !listeners || (direction != "bind" && direction != "listen")
+	}
+}
+
 // UpdateMatchedPolicy Function
 func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
+	log, _ = fd.matchPolicies(log)
+	return log
+}
+
+// matchPolicies Function (also returns the rule that decided the event, empty for the default posture)
+func (fd *Feeder) matchPolicies(log tp.Log) (tp.Log, tp.MatchPolicy) {
+	matched := tp.MatchPolicy{}
+
 	existFileAllowPolicy := false
 	existNetworkAllowPolicy := false
 	existCapabilitiesAllowPolicy := false
 
+	// an event allowed by a rule is not a violation of the other allow rules (only if allow rules are combined)
+	matchedAllowPolicy := false
+
 	if log.Result == "Passed" || log.Result == "Operation not permitted" || log.Result == "Permission denied" {
 		fd.SecurityPoliciesLock.RLock()
 
@@ -744,12 +1177,23 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 			key = log.NamespaceName + "_" + log.PodName
 		}
 
+		direction := getNetworkDirection(log)
+
 		secPolicies := fd.SecurityPolicies[key].Policies
+
+		listeners := false
+		for _, secPolicy := range secPolicies {
+			if secPolicy.ResourceType == "Listener" {
+				listeners = true
+				break
+			}
+		}
+
 		for _, secPolicy := range secPolicies {
 			if secPolicy.Action == "Allow" || secPolicy.Action == "Audit (Allow)" {
 				if secPolicy.Operation == "Process" || secPolicy.Operation == "File" {
 					existFileAllowPolicy = true
-				} else if secPolicy.Operation == "Network" {
+				} else if secPolicy.Operation == "Network" && isNetworkRuleApplicable(secPolicy, direction, log, listeners) {
 					existNetworkAllowPolicy = true
 				} else if secPolicy.Operation == "Capabilities" {
 					existCapabilitiesAllowPolicy = true
@@ -820,6 +1264,7 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 							log.Type = "MatchedPolicy"
 
 							log.PolicyName = secPolicy.PolicyName
+							matched = secPolicy
 							log.Severity = secPolicy.Severity
 
 							if len(secPolicy.Tags) > 0 {
@@ -830,13 +1275,14 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 								log.Message = secPolicy.Message
 							}
 
-							if log.PolicyEnabled == tp.KubeArmorPolicyAudited {
+							if log.PolicyEnabled == tp.KubeArmorPolicyAudited || log.PolicyEnabled == tp.KubeArmorPolicyLearning {
 								log.Enforcer = "eBPF Monitor"
 							} else {
 								log.Enforcer = fd.Enforcer
 							}
 
 							log.Action = "Allow"
+							matchedAllowPolicy = fd.CombineAllowRules
 
 							continue
 						}
@@ -848,6 +1294,7 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 							log.Type = "MatchedPolicy"
 
 							log.PolicyName = secPolicy.PolicyName
+							matched = secPolicy
 							log.Severity = secPolicy.Severity
 
 							if len(secPolicy.Tags) > 0 {
@@ -873,6 +1320,7 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 							log.Type = "MatchedPolicy"
 
 							log.PolicyName = secPolicy.PolicyName
+							matched = secPolicy
 							log.Severity = secPolicy.Severity
 
 							if len(secPolicy.Tags) > 0 {
@@ -883,7 +1331,7 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 								log.Message = secPolicy.Message
 							}
 
-							if log.PolicyEnabled == tp.KubeArmorPolicyAudited {
+							if log.PolicyEnabled == tp.KubeArmorPolicyAudited || log.PolicyEnabled == tp.KubeArmorPolicyLearning {
 								log.Enforcer = "eBPF Monitor"
 							} else {
 								log.Enforcer = fd.Enforcer
@@ -901,6 +1349,7 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 						log.Type = "MatchedPolicy"
 
 						log.PolicyName = "DefaultPosture"
+						matched = tp.MatchPolicy{}
 
 						log.Severity = ""
 						log.Tags = ""
@@ -912,12 +1361,13 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 						continue
 					}
 
-					if secPolicy.Action == "Audit (Allow)" && log.Result == "Passed" {
+					if !matchedAllowPolicy && secPolicy.Action == "Audit (Allow)" && log.Result == "Passed" {
 						// matched source + !(matched resource) + action = audit (allow) + result = passed -> default posture / allow policy violation (audit mode)
 
 						log.Type = "MatchedPolicy"
 
 						log.PolicyName = "DefaultPosture"
+						matched = tp.MatchPolicy{}
 
 						log.Severity = ""
 						log.Tags = ""
@@ -935,12 +1385,13 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 					}
 				}
 
-				if fd.DefaultPostures[log.NamespaceName].FileAction == "block" && secPolicy.Action == "Audit (Allow)" && log.Result == "Passed" {
+				if !matchedAllowPolicy && fd.DefaultPostures[log.NamespaceName].FileAction == "block" && secPolicy.Action == "Audit (Allow)" && log.Result == "Passed" {
 					// defaultPosture = block + audit mode
 
 					log.Type = "MatchedPolicy"
 
 					log.PolicyName = "DefaultPosture"
+					matched = tp.MatchPolicy{}
 
 					log.Severity = ""
 					log.Tags = ""
@@ -950,12 +1401,13 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 					log.Action = "Audit (Block)"
 				}
 
-				if fd.DefaultPostures[log.NamespaceName].FileAction == "audit" && (secPolicy.Action == "Allow" || secPolicy.Action == "Audit (Allow)") && log.Result == "Passed" {
+				if !matchedAllowPolicy && fd.DefaultPostures[log.NamespaceName].FileAction == "audit" && (secPolicy.Action == "Allow" || secPolicy.Action == "Audit (Allow)") && log.Result == "Passed" {
 					// defaultPosture = audit
 
 					log.Type = "MatchedPolicy"
 
 					log.PolicyName = "DefaultPosture"
+					matched = tp.MatchPolicy{}
 
 					log.Severity = ""
 					log.Tags = ""
@@ -965,22 +1417,95 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 					log.Action = "Audit"
 				}
 
+			case "Syscall":
+				if secPolicy.Operation != log.Operation || secPolicy.Resource != log.Resource {
+					continue
+				}
+
+				// match sources
+				if (!secPolicy.IsFromSource) || (secPolicy.IsFromSource && (secPolicy.Source == log.ParentProcessName || secPolicy.Source == log.ProcessName)) {
+					if secPolicy.Action == "Audit" && log.Result == "Passed" {
+						// audit policy
+						// matched source + matched syscall + matched action + expected result -> alert (audit log)
+
+						log.Type = "MatchedPolicy"
+
+						log.PolicyName = secPolicy.PolicyName
+						matched = secPolicy
+						log.Severity = secPolicy.Severity
+
+						if len(secPolicy.Tags) > 0 {
+							log.Tags = strings.Join(secPolicy.Tags[:], ",")
+						}
+
+						if len(secPolicy.Message) > 0 {
+							log.Message = secPolicy.Message
+						}
+
+						log.Enforcer = "eBPF Monitor"
+						log.Action = secPolicy.Action
+
+						continue
+					}
+
+					if (secPolicy.Action == "Block" && log.Result != "Passed") ||
+						(secPolicy.Action == "Audit (Block)" && log.Result == "Passed") {
+						// block policy or block policy with audit mode
+						// matched source + matched syscall + matched action + expected result -> alert
+
+						log.Type = "MatchedPolicy"
+
+						log.PolicyName = secPolicy.PolicyName
+						matched = secPolicy
+						log.Severity = secPolicy.Severity
+
+						if len(secPolicy.Tags) > 0 {
+							log.Tags = strings.Join(secPolicy.Tags[:], ",")
+						}
+
+						if len(secPolicy.Message) > 0 {
+							log.Message = secPolicy.Message
+						}
+
+						if log.PolicyEnabled == tp.KubeArmorPolicyAudited || log.PolicyEnabled == tp.KubeArmorPolicyLearning {
+							log.Enforcer = "eBPF Monitor"
+						} else {
+							log.Enforcer = fd.Enforcer
+						}
+
+						log.Action = secPolicy.Action
+
+						continue
+					}
+				}
+
 			case "Network":
 				if secPolicy.Operation != log.Operation {
 					continue
 				}
 
+				if !isNetworkRuleApplicable(secPolicy, direction, log, listeners) {
+					continue
+				}
+
 				// match sources
 				if (!secPolicy.IsFromSource) || (secPolicy.IsFromSource && (secPolicy.Source == log.ParentProcessName || secPolicy.Source == log.ProcessName)) {
 					skip := false
 
-					for _, matchProtocol := range strings.Split(secPolicy.Resource, ",") {
+					matchProtocols := strings.Split(secPolicy.Resource, ",")
+					if secPolicy.ResourceType == "Endpoint" || secPolicy.ResourceType == "Listener" {
+						matchProtocols = []string{secPolicy.Resource}
+					}
+
+					for _, matchProtocol := range matchProtocols {
 						if skip {
 							break
 						}
 
 						// match resources
-						if strings.Contains(log.Resource, matchProtocol) {
+						if (secPolicy.ResourceType == "Endpoint" && matchEndpoint(secPolicy, log)) ||
+							(secPolicy.ResourceType == "Listener" && matchListener(secPolicy, log)) ||
+							(secPolicy.ResourceType == "Protocol" && strings.Contains(log.Resource, matchProtocol)) {
 							if (secPolicy.Action == "Allow" || secPolicy.Action == "Audit (Allow)") && log.Result == "Passed" {
 								// allow policy or allow policy with audit mode
 								// matched source + matched resource + matched action + expected result -> going to be skipped
@@ -988,6 +1513,7 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 								log.Type = "MatchedPolicy"
 
 								log.PolicyName = secPolicy.PolicyName
+								matched = secPolicy
 								log.Severity = secPolicy.Severity
 
 								if len(secPolicy.Tags) > 0 {
@@ -998,13 +1524,14 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 									log.Message = secPolicy.Message
 								}
 
-								if log.PolicyEnabled == tp.KubeArmorPolicyAudited {
+								if log.PolicyEnabled == tp.KubeArmorPolicyAudited || log.PolicyEnabled == tp.KubeArmorPolicyLearning {
 									log.Enforcer = "eBPF Monitor"
 								} else {
 									log.Enforcer = fd.Enforcer
 								}
 
 								log.Action = "Allow"
+								matchedAllowPolicy = fd.CombineAllowRules
 
 								skip = true
 								continue
@@ -1017,6 +1544,7 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 								log.Type = "MatchedPolicy"
 
 								log.PolicyName = secPolicy.PolicyName
+								matched = secPolicy
 								log.Severity = secPolicy.Severity
 
 								if len(secPolicy.Tags) > 0 {
@@ -1042,6 +1570,7 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 								log.Type = "MatchedPolicy"
 
 								log.PolicyName = secPolicy.PolicyName
+								matched = secPolicy
 								log.Severity = secPolicy.Severity
 
 								if len(secPolicy.Tags) > 0 {
@@ -1052,7 +1581,7 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 									log.Message = secPolicy.Message
 								}
 
-								if log.PolicyEnabled == tp.KubeArmorPolicyAudited {
+								if log.PolicyEnabled == tp.KubeArmorPolicyAudited || log.PolicyEnabled == tp.KubeArmorPolicyLearning {
 									log.Enforcer = "eBPF Monitor"
 								} else {
 									log.Enforcer = fd.Enforcer
@@ -1076,6 +1605,7 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 						log.Type = "MatchedPolicy"
 
 						log.PolicyName = "DefaultPosture"
+						matched = tp.MatchPolicy{}
 
 						log.Severity = ""
 						log.Tags = ""
@@ -1087,12 +1617,13 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 						continue
 					}
 
-					if secPolicy.Action == "Audit (Allow)" && log.Result == "Passed" {
+					if !matchedAllowPolicy && secPolicy.Action == "Audit (Allow)" && log.Result == "Passed" {
 						// matched source + !(matched resource) + action = audit (allow) + result = passed -> allow policy violation (audit mode)
 
 						log.Type = "MatchedPolicy"
 
 						log.PolicyName = "DefaultPosture"
+						matched = tp.MatchPolicy{}
 
 						log.Severity = ""
 						log.Tags = ""
@@ -1110,12 +1641,13 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 					}
 				}
 
-				if fd.DefaultPostures[log.NamespaceName].NetworkAction == "block" && secPolicy.Action == "Audit (Allow)" && log.Result == "Passed" {
+				if !matchedAllowPolicy && fd.DefaultPostures[log.NamespaceName].NetworkAction == "block" && secPolicy.Action == "Audit (Allow)" && log.Result == "Passed" {
 					// defaultPosture = block + audit mode
 
 					log.Type = "MatchedPolicy"
 
 					log.PolicyName = "DefaultPosture"
+					matched = tp.MatchPolicy{}
 
 					log.Severity = ""
 					log.Tags = ""
@@ -1125,12 +1657,13 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 					log.Action = "Audit (Block)"
 				}
 
-				if fd.DefaultPostures[log.NamespaceName].NetworkAction == "audit" && (secPolicy.Action == "Allow" || secPolicy.Action == "Audit (Allow)") && log.Result == "Passed" {
+				if !matchedAllowPolicy && fd.DefaultPostures[log.NamespaceName].NetworkAction == "audit" && (secPolicy.Action == "Allow" || secPolicy.Action == "Audit (Allow)") && log.Result == "Passed" {
 					// defaultPosture = audit
 
 					log.Type = "MatchedPolicy"
 
 					log.PolicyName = "DefaultPosture"
+					matched = tp.MatchPolicy{}
 
 					log.Severity = ""
 					log.Tags = ""
@@ -1144,13 +1677,15 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 
 		fd.SecurityPoliciesLock.RUnlock()
 
-		if log.PolicyName == "" && log.Result != "Passed" {
+		// there is no default posture for syscalls, so unmatched failures are not caused by KubeArmor
+		if log.PolicyName == "" && log.Result != "Passed" && log.Operation != "Syscall" {
 			// default posture (block) or native policy
 			// no matched policy, but result = blocked -> default posture
 
 			log.Type = "MatchedPolicy"
 
 			log.PolicyName = "DefaultPosture"
+			matched = tp.MatchPolicy{}
 
 			log.Severity = ""
 			log.Tags = ""
@@ -1180,28 +1715,28 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 
 			if log.Operation == "Process" {
 				if setLogFields(&log, existFileAllowPolicy, fd.DefaultPostures[log.NamespaceName].FileAction, log.ProcessVisibilityEnabled, true) {
-					return log
+					return log, matched
 				}
 			} else if log.Operation == "File" {
 				if setLogFields(&log, existFileAllowPolicy, fd.DefaultPostures[log.NamespaceName].FileAction, log.FileVisibilityEnabled, true) {
-					return log
+					return log, matched
 				}
 			} else if log.Operation == "Network" {
 				if setLogFields(&log, existNetworkAllowPolicy, fd.DefaultPostures[log.NamespaceName].NetworkAction, log.NetworkVisibilityEnabled, true) {
-					return log
+					return log, matched
 				}
 			} else if log.Operation == "Capabilities" {
 				if setLogFields(&log, existCapabilitiesAllowPolicy, fd.DefaultPostures[log.NamespaceName].CapabilitiesAction, log.CapabilitiesVisibilityEnabled, true) {
-					return log
+					return log, matched
 				}
 			}
 
 		} else if log.Type == "MatchedPolicy" {
 			if log.Action == "Allow" && log.Result == "Passed" {
-				return tp.Log{}
+				return tp.Log{}, matched
 			}
 
-			return log
+			return log, matched
 		}
 	} else { // host
 		if log.Type == "" {
@@ -1209,19 +1744,19 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 
 			if log.Operation == "Process" {
 				if setLogFields(&log, existFileAllowPolicy, "allow", fd.Node.ProcessVisibilityEnabled, false) {
-					return log
+					return log, matched
 				}
 			} else if log.Operation == "File" {
 				if setLogFields(&log, existFileAllowPolicy, "allow", fd.Node.FileVisibilityEnabled, false) {
-					return log
+					return log, matched
 				}
 			} else if log.Operation == "Network" {
 				if setLogFields(&log, existNetworkAllowPolicy, "allow", fd.Node.NetworkVisibilityEnabled, false) {
-					return log
+					return log, matched
 				}
 			} else if log.Operation == "Capabilities" {
 				if setLogFields(&log, existCapabilitiesAllowPolicy, "allow", fd.Node.CapabilitiesVisibilityEnabled, false) {
-					return log
+					return log, matched
 				}
 			}
 
@@ -1229,12 +1764,12 @@ func (fd *Feeder) UpdateMatchedPolicy(log tp.Log) tp.Log {
 			log.Type = "MatchedHostPolicy"
 
 			if log.Action == "Allow" && log.Result == "Passed" {
-				return tp.Log{}
+				return tp.Log{}, matched
 			}
 
-			return log
+			return log, matched
 		}
 	}
 
-	return tp.Log{}
+	return tp.Log{}, matched
 }
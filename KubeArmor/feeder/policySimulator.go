@@ -0,0 +1,310 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of KubeArmor
+
+package feeder
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	kl "github.com/kubearmor/KubeArmor/KubeArmor/common"
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+)
+
+// ======================= //
+// == Policy Simulation == //
+// ======================= //
+
+const (
+	// SimulationBlock The event would have been blocked
+	SimulationBlock = "Block"
+
+	// SimulationAudit The event would have been audited
+	SimulationAudit = "Audit"
+
+	// SimulationAllow The event would have been allowed by an allow rule
+	SimulationAllow = "Allow"
+
+	// SimulationNone No rule or default posture applies to the event
+	SimulationNone = "None"
+)
+
+// SimulationResult Structure
+type SimulationResult struct {
+	Log tp.Log `json:"log"`
+
+	Decision   string `json:"decision"`
+	PolicyName string `json:"policyName,omitempty"`
+
+	// the rule that decided the event (empty for the default posture)
+	Rule string `json:"rule,omitempty"`
+}
+
+// SimulationRuleCount Structure
+type SimulationRuleCount struct {
+	Decision   string `json:"decision"`
+	PolicyName string `json:"policyName"`
+	Rule       string `json:"rule,omitempty"`
+	Count      int    `json:"count"`
+}
+
+// SimulationReport Structure
+type SimulationReport struct {
+	// events simulated and events skipped (host events, malformed lines)
+	Events  int `json:"events"`
+	Skipped int `json:"skipped"`
+
+	// decision -> count
+	Decisions map[string]int `json:"decisions"`
+
+	// matches per policy and rule (most frequent first)
+	Rules []SimulationRuleCount `json:"rules,omitempty"`
+
+	// individual results (blocked and audited events only)
+	Results []SimulationResult `json:"results,omitempty"`
+}
+
+// PolicySimulator Structure
+type PolicySimulator struct {
+	Feeder *Feeder
+
+	Policies       []tp.SecurityPolicy
+	DefaultPosture tp.DefaultPosture
+
+	// namespace_pod -> true (endpoints built from recorded events)
+	EndPoints map[string]bool
+}
+
+// NewPolicySimulator Function
+func NewPolicySimulator(policies []tp.SecurityPolicy, defaultPosture tp.DefaultPosture) *PolicySimulator {
+	fd := &Feeder{}
+
+	fd.Node = &tp.Node{}
+	fd.Output = "none"
+	fd.Enforcer = "eBPF Monitor"
+
+	// policies are simulated in audit mode, where each allow rule would
+	// otherwise report the events allowed by the other allow rules
+	fd.CombineAllowRules = true
+
+	fd.SecurityPolicies = map[string]tp.MatchPolicies{}
+	fd.SecurityPoliciesLock = new(sync.RWMutex)
+
+	fd.DefaultPostures = map[string]tp.DefaultPosture{}
+	fd.DefaultPosturesLock = new(sync.Mutex)
+
+	return &PolicySimulator{
+		Feeder:         fd,
+		Policies:       policies,
+		DefaultPosture: defaultPosture,
+		EndPoints:      map[string]bool{},
+	}
+}
+
+// getLogIdentities Function
+func getLogIdentities(log tp.Log) []string {
+	identities := []string{"namespaceName=" + log.NamespaceName}
+
+	for _, label := range strings.Split(log.Labels, ",") {
+		if label = strings.TrimSpace(label); strings.Contains(label, "=") {
+			identities = append(identities, label)
+		}
+	}
+
+	sort.Strings(identities)
+
+	return identities
+}
+
+// addEndPoint Function (builds the match policies of the pod of an event)
+func (ps *PolicySimulator) addEndPoint(log tp.Log) {
+	key := log.NamespaceName + "_" + log.PodName
+	if ps.EndPoints[key] {
+		return
+	}
+	ps.EndPoints[key] = true
+
+	endPoint := tp.EndPoint{
+		NamespaceName: log.NamespaceName,
+		EndPointName:  log.PodName,
+		Identities:    getLogIdentities(log),
+
+		// audit mode, so that recorded (passed) events match block rules
+		PolicyEnabled: tp.KubeArmorPolicyAudited,
+	}
+
+	for _, secPolicy := range ps.Policies {
+		if kl.MatchIdentities(secPolicy.Spec.Selector.Identities, endPoint.Identities) {
+			endPoint.SecurityPolicies = append(endPoint.SecurityPolicies, secPolicy)
+		}
+	}
+
+	ps.Feeder.UpdateSecurityPolicies("ADDED", endPoint)
+
+	ps.Feeder.UpdateDefaultPosture("ADDED", log.NamespaceName, ps.DefaultPosture)
+}
+
+// GetRuleString Function
+func GetRuleString(rule tp.MatchPolicy) string {
+	if rule.PolicyName == "" {
+		return ""
+	}
+
+	str := fmt.Sprintf("%s %s %s", rule.Operation, strings.ToLower(rule.ResourceType), rule.Resource)
+	if rule.IsFromSource {
+		str = str + " fromSource " + rule.Source
+	}
+
+	// policies are simulated in audit mode, report the declared action
+	action := rule.Action
+	if strings.HasPrefix(action, "Audit (") {
+		action = strings.TrimSuffix(strings.TrimPrefix(action, "Audit ("), ")")
+	}
+
+	return str + " (" + action + ")"
+}
+
+// Simulate Function (matches a recorded event against the policies, false if the event cannot be simulated)
+func (ps *PolicySimulator) Simulate(log tp.Log) (SimulationResult, bool) {
+	// only container events can be attributed to the endpoints of policies
+	if log.ContainerID == "" || log.NamespaceName == "" || log.PodName == "" {
+		return SimulationResult{}, false
+	}
+
+	ps.addEndPoint(log)
+
+	// forget the decision made when the event was recorded
+	recorded := log
+
+	log.Type = ""
+	log.PolicyName = ""
+	log.Severity = ""
+	log.Tags = ""
+	log.Message = ""
+	log.Enforcer = ""
+	log.Action = ""
+
+	if log.Result == "Permission denied" || log.Result == "Operation not permitted" {
+		log.Result = "Passed"
+	}
+
+	log.PolicyEnabled = tp.KubeArmorPolicyAudited
+
+	log, matched := ps.Feeder.matchPolicies(log)
+
+	result := SimulationResult{Log: recorded, Decision: SimulationNone}
+
+	if log.Type == "MatchedPolicy" {
+		switch log.Action {
+		case "Block", "Audit (Block)":
+			result.Decision = SimulationBlock
+		case "Audit":
+			result.Decision = SimulationAudit
+		}
+		result.PolicyName = log.PolicyName
+	} else if matched.PolicyName != "" {
+		// matched allow rules drop the event
+		result.Decision = SimulationAllow
+		result.PolicyName = matched.PolicyName
+	}
+
+	if result.Decision != SimulationNone {
+		result.Rule = GetRuleString(matched)
+	}
+
+	return result, true
+}
+
+// Add Function (simulates an event and adds it to a report)
+func (report *SimulationReport) Add(ps *PolicySimulator, log tp.Log, details bool) {
+	result, ok := ps.Simulate(log)
+	if !ok {
+		report.Skipped++
+		return
+	}
+
+	report.Events++
+	report.Decisions[result.Decision]++
+
+	if result.Decision == SimulationNone {
+		return
+	}
+
+	found := false
+	for idx, rule := range report.Rules {
+		if rule.Decision == result.Decision && rule.PolicyName == result.PolicyName && rule.Rule == result.Rule {
+			report.Rules[idx].Count++
+			found = true
+			break
+		}
+	}
+	if !found {
+		report.Rules = append(report.Rules, SimulationRuleCount{Decision: result.Decision, PolicyName: result.PolicyName, Rule: result.Rule, Count: 1})
+	}
+
+	if details && (result.Decision == SimulationBlock || result.Decision == SimulationAudit) {
+		report.Results = append(report.Results, result)
+	}
+}
+
+// NewSimulationReport Function
+func NewSimulationReport() *SimulationReport {
+	return &SimulationReport{Decisions: map[string]int{}}
+}
+
+// SimulateLogs Function (replays JSON lines, including hash-chained log files)
+func (ps *PolicySimulator) SimulateLogs(reader io.Reader, report *SimulationReport, details bool) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if record, _, _, err := parseChainedRecord(line); err == nil {
+			line = record
+		}
+
+		log := tp.Log{}
+		if err := json.Unmarshal([]byte(line), &log); err != nil {
+			report.Skipped++
+			continue
+		}
+
+		report.Add(ps, log, details)
+	}
+
+	return scanner.Err()
+}
+
+// SimulateLogFiles Function
+func (ps *PolicySimulator) SimulateLogFiles(paths []string, details bool) (*SimulationReport, error) {
+	report := NewSimulationReport()
+
+	for _, path := range paths {
+		file, err := openLogFile(path)
+		if err != nil {
+			return report, err
+		}
+
+		err = ps.SimulateLogs(file, report, details)
+		_ = file.Close()
+
+		if err != nil {
+			return report, fmt.Errorf("%s: %s", path, err.Error())
+		}
+	}
+
+	sort.SliceStable(report.Rules, func(i, j int) bool {
+		return report.Rules[i].Count > report.Rules[j].Count
+	})
+
+	return report, nil
+}
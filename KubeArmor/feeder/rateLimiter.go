@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of KubeArmor
+
+package feeder
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	kl "github.com/kubearmor/KubeArmor/KubeArmor/common"
+	cfg "github.com/kubearmor/KubeArmor/KubeArmor/config"
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+)
+
+// ================== //
+// == Rate Limiter == //
+// ================== //
+
+// RateLimitReportInterval Interval to report suppressed events
+const RateLimitReportInterval = 1 * time.Second
+
+// RateLimitIdleTimeout Time to keep the bucket of an idle source
+const RateLimitIdleTimeout = 5 * time.Minute
+
+// TokenBucket Structure
+type TokenBucket struct {
+	Rate   int
+	Tokens float64
+
+	LastRefill time.Time
+
+	// suppressed events since the last report
+	Suppressed uint64
+	LastLog    tp.Log
+}
+
+// Allow Function
+func (tb *TokenBucket) Allow(now time.Time) bool {
+	// burst = rate, i.e., one second worth of events
+	tb.Tokens += now.Sub(tb.LastRefill).Seconds() * float64(tb.Rate)
+	if tb.Tokens > float64(tb.Rate) {
+		tb.Tokens = float64(tb.Rate)
+	}
+	tb.LastRefill = now
+
+	if tb.Tokens < 1 {
+		return false
+	}
+
+	tb.Tokens--
+	return true
+}
+
+// RateLimiter Structure
+type RateLimiter struct {
+	ContainerRate int
+	HostRate      int
+
+	// container id ("" for host) -> bucket
+	Buckets     map[string]*TokenBucket
+	BucketsLock *sync.Mutex
+
+	// callback to emit summary events
+	Emit func(log tp.Log)
+
+	StopChan  chan struct{}
+	WgLimiter sync.WaitGroup
+}
+
+// NewRateLimiter Function
+func NewRateLimiter(containerRate, hostRate int, emit func(log tp.Log)) *RateLimiter {
+	rl := &RateLimiter{}
+
+	rl.ContainerRate = containerRate
+	rl.HostRate = hostRate
+
+	rl.Buckets = map[string]*TokenBucket{}
+	rl.BucketsLock = new(sync.Mutex)
+
+	rl.Emit = emit
+
+	rl.StopChan = make(chan struct{})
+
+	rl.WgLimiter.Add(1)
+	go rl.reportPeriodically()
+
+	return rl
+}
+
+// getRate Function
+func (rl *RateLimiter) getRate(log tp.Log) int {
+	if log.ContainerID == "" {
+		return rl.HostRate
+	}
+
+	// pod annotation
+	if log.EventRateLimit != 0 {
+		return log.EventRateLimit
+	}
+
+	return rl.ContainerRate
+}
+
+// Allow Function
+func (rl *RateLimiter) Allow(log tp.Log) bool {
+	// blocked actions are never suppressed
+	if log.Result == "Permission denied" || log.Result == "Operation not permitted" {
+		return true
+	}
+
+	rate := rl.getRate(log)
+	if rate <= 0 {
+		return true
+	}
+
+	now := time.Now()
+
+	rl.BucketsLock.Lock()
+	defer rl.BucketsLock.Unlock()
+
+	bucket, ok := rl.Buckets[log.ContainerID]
+	if !ok {
+		bucket = &TokenBucket{Rate: rate, Tokens: float64(rate), LastRefill: now}
+		rl.Buckets[log.ContainerID] = bucket
+	}
+	bucket.Rate = rate
+
+	if bucket.Allow(now) {
+		return true
+	}
+
+	bucket.Suppressed++
+	bucket.LastLog = log
+
+	return false
+}
+
+// GetSummaryLog Function
+func GetSummaryLog(log tp.Log, suppressed uint64, rate int) tp.Log {
+	summary := tp.Log{}
+
+	summary.Timestamp, summary.UpdatedTime = kl.GetDateTimeNow()
+
+	summary.ClusterName = log.ClusterName
+	summary.HostName = cfg.GlobalCfg.Host
+
+	summary.NamespaceName = log.NamespaceName
+	summary.PodName = log.PodName
+	summary.Labels = log.Labels
+
+	summary.ContainerID = log.ContainerID
+	summary.ContainerName = log.ContainerName
+	summary.ContainerImage = log.ContainerImage
+
+	summary.Type = log.Type
+	summary.Operation = "RateLimit"
+	summary.Data = fmt.Sprintf("suppressed=%d rate=%d", suppressed, rate)
+	summary.Message = fmt.Sprintf("Suppressed %d events exceeding %d events/s", suppressed, rate)
+	summary.Result = "Passed"
+
+	return summary
+}
+
+// report Function
+func (rl *RateLimiter) report() {
+	summaries := []tp.Log{}
+
+	now := time.Now()
+
+	rl.BucketsLock.Lock()
+	for key, bucket := range rl.Buckets {
+		if bucket.Suppressed > 0 {
+			summaries = append(summaries, GetSummaryLog(bucket.LastLog, bucket.Suppressed, bucket.Rate))
+			bucket.Suppressed = 0
+		} else if now.Sub(bucket.LastRefill) > RateLimitIdleTimeout {
+			delete(rl.Buckets, key)
+		}
+	}
+	rl.BucketsLock.Unlock()
+
+	for _, summary := range summaries {
+		rl.Emit(summary)
+	}
+}
+
+// reportPeriodically Function
+func (rl *RateLimiter) reportPeriodically() {
+	defer rl.WgLimiter.Done()
+
+	ticker := time.NewTicker(RateLimitReportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-rl.StopChan:
+			return
+		case <-ticker.C:
+			rl.report()
+		}
+	}
+}
+
+// Stop Function
+func (rl *RateLimiter) Stop() {
+	close(rl.StopChan)
+	rl.WgLimiter.Wait()
+
+	// report the remaining suppressed events
+	rl.report()
+}
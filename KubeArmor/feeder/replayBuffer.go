@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of KubeArmor
+
+package feeder
+
+import (
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+)
+
+// =================== //
+// == Replay Buffer == //
+// =================== //
+
+// ReplayEvent Structure
+type ReplayEvent struct {
+	Sequence uint64
+	Log      tp.Log
+	Event    interface{} // *pb.Alert or *pb.Log
+}
+
+// ReplayBuffer Structure
+type ReplayBuffer struct {
+	Events []ReplayEvent
+	Next   int
+	Full   bool
+}
+
+// NewReplayBuffer Function
+func NewReplayBuffer(size int) *ReplayBuffer {
+	rb := &ReplayBuffer{}
+	rb.Events = make([]ReplayEvent, size)
+	return rb
+}
+
+// Add Function
+func (rb *ReplayBuffer) Add(event ReplayEvent) {
+	if len(rb.Events) == 0 {
+		return
+	}
+
+	rb.Events[rb.Next] = event
+	rb.Next = (rb.Next + 1) % len(rb.Events)
+
+	if rb.Next == 0 {
+		rb.Full = true
+	}
+}
+
+// Oldest Function
+func (rb *ReplayBuffer) Oldest() uint64 {
+	if rb.Full {
+		return rb.Events[rb.Next].Sequence
+	} else if rb.Next > 0 {
+		return rb.Events[0].Sequence
+	}
+	return 0
+}
+
+// After Function
+func (rb *ReplayBuffer) After(sequence uint64) []ReplayEvent {
+	events := []ReplayEvent{}
+
+	start, count := 0, rb.Next
+	if rb.Full {
+		start, count = rb.Next, len(rb.Events)
+	}
+
+	for i := 0; i < count; i++ {
+		event := rb.Events[(start+i)%len(rb.Events)]
+		if event.Sequence > sequence {
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
@@ -0,0 +1,234 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of KubeArmor
+
+package feeder
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	cfg "github.com/kubearmor/KubeArmor/KubeArmor/config"
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+)
+
+// ===================== //
+// == Syslog Exporter == //
+// ===================== //
+
+// SyslogDialTimeout Timeout to connect to a syslog server
+const SyslogDialTimeout = 5 * time.Second
+
+// syslog severities (RFC 5424)
+const (
+	syslogSeverityWarning = 4
+	syslogSeverityNotice  = 5
+	syslogSeverityInfo    = 6
+)
+
+// SyslogFacilities Map (facility name -> code)
+var SyslogFacilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5, "lpr": 6, "news": 7,
+	"uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19,
+	"local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+// SyslogSpec Structure
+type SyslogSpec struct {
+	Network  string `json:"network"` // udp, tcp or unix
+	Address  string `json:"address"` // host:port or socket path
+	AppName  string `json:"appName,omitempty"`
+	Facility string `json:"facility,omitempty"`
+	Format   string `json:"format,omitempty"` // json, cef or leef
+}
+
+// SyslogExporter Structure
+type SyslogExporter struct {
+	Network string
+	Address string
+
+	AppName  string
+	Facility int
+	Format   string
+	Hostname string
+	ProcID   string
+
+	Conn     net.Conn
+	ConnLock *sync.Mutex
+
+	// true if Conn is a stream (tcp or unix stream socket)
+	Stream bool
+}
+
+func init() {
+	RegisterExporter("syslog", NewSyslogExporter)
+}
+
+// NewSyslogExporter Function
+func NewSyslogExporter(spec ExporterSpec) (Exporter, error) {
+	if spec.Syslog == nil || spec.Syslog.Address == "" {
+		return nil, errors.New("syslog address is required")
+	}
+
+	se := &SyslogExporter{}
+
+	switch spec.Syslog.Network {
+	case "", "udp":
+		se.Network = "udp"
+	case "tcp", "unix":
+		se.Network = spec.Syslog.Network
+	default:
+		return nil, fmt.Errorf("unsupported syslog network (%s)", spec.Syslog.Network)
+	}
+	se.Address = spec.Syslog.Address
+
+	se.AppName = spec.Syslog.AppName
+	if se.AppName == "" {
+		se.AppName = "kubearmor"
+	}
+
+	se.Facility = SyslogFacilities["local0"]
+	if spec.Syslog.Facility != "" {
+		facility, ok := SyslogFacilities[spec.Syslog.Facility]
+		if !ok {
+			return nil, fmt.Errorf("unknown syslog facility (%s)", spec.Syslog.Facility)
+		}
+		se.Facility = facility
+	}
+
+	se.Format = spec.Syslog.Format
+	if se.Format == "" {
+		se.Format = LogFormatJSON
+	} else if !IsValidLogFormat(se.Format) {
+		return nil, fmt.Errorf("unknown syslog format (%s)", se.Format)
+	}
+
+	se.Hostname = cfg.GlobalCfg.Host
+	if se.Hostname == "" {
+		se.Hostname = "-"
+	}
+	se.ProcID = fmt.Sprintf("%d", os.Getpid())
+
+	se.ConnLock = new(sync.Mutex)
+
+	return se, nil
+}
+
+// connect Function (should be called with the lock)
+func (se *SyslogExporter) connect() error {
+	if se.Conn != nil {
+		return nil
+	}
+
+	if se.Network == "unix" {
+		// most syslog daemons listen on a datagram socket
+		for _, network := range []string{"unixgram", "unix"} {
+			if conn, err := net.DialTimeout(network, se.Address, SyslogDialTimeout); err == nil {
+				se.Conn = conn
+				se.Stream = network == "unix"
+				return nil
+			}
+		}
+		return fmt.Errorf("failed to connect to %s", se.Address)
+	}
+
+	conn, err := net.DialTimeout(se.Network, se.Address, SyslogDialTimeout)
+	if err != nil {
+		return err
+	}
+	se.Conn = conn
+	se.Stream = se.Network == "tcp"
+
+	return nil
+}
+
+// getSyslogSeverity Function
+func getSyslogSeverity(log tp.Log) int {
+	if isAlert(log) {
+		if strings.HasPrefix(log.Action, "Block") {
+			return syslogSeverityWarning
+		}
+		return syslogSeverityNotice
+	}
+	return syslogSeverityInfo
+}
+
+// FormatSyslogMessage Function
+func (se *SyslogExporter) FormatSyslogMessage(log tp.Log, msg string) string {
+	timestamp := log.UpdatedTime
+	if timestamp == "" {
+		timestamp = time.Now().UTC().Format(time.RFC3339Nano)
+	}
+
+	msgID := log.Type
+	if msgID == "" {
+		msgID = "-"
+	}
+
+	// <PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG
+	return fmt.Sprintf("<%d>1 %s %s %s %s %s - %s", se.Facility*8+getSyslogSeverity(log),
+		timestamp, se.Hostname, se.AppName, se.ProcID, msgID, msg)
+}
+
+// write Function (should be called with the lock)
+func (se *SyslogExporter) write(message string) error {
+	if err := se.connect(); err != nil {
+		return err
+	}
+
+	// octet counting framing for stream transports (RFC 6587)
+	if se.Stream {
+		message = fmt.Sprintf("%d %s", len(message), message)
+	}
+
+	if _, err := se.Conn.Write([]byte(message)); err != nil {
+		_ = se.Conn.Close()
+		se.Conn = nil
+		return err
+	}
+
+	return nil
+}
+
+// Export Function
+func (se *SyslogExporter) Export(logs []tp.Log) error {
+	se.ConnLock.Lock()
+	defer se.ConnLock.Unlock()
+
+	for i, log := range logs {
+		msg, err := FormatLog(se.Format, log)
+		if err != nil {
+			return &PartialExportError{Unsent: len(logs) - i, Err: err}
+		}
+
+		message := se.FormatSyslogMessage(log, msg)
+
+		// reconnect once if the connection is broken
+		if err := se.write(message); err != nil {
+			if err := se.write(message); err != nil {
+				return &PartialExportError{Unsent: len(logs) - i, Err: err}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Close Function
+func (se *SyslogExporter) Close() error {
+	se.ConnLock.Lock()
+	defer se.ConnLock.Unlock()
+
+	if se.Conn != nil {
+		err := se.Conn.Close()
+		se.Conn = nil
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of KubeArmor
+
+package feeder
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	kg "github.com/kubearmor/KubeArmor/KubeArmor/log"
+	"google.golang.org/grpc/credentials"
+)
+
+// ===================== //
+// == TLS Credentials == //
+// ===================== //
+
+// CertCheckInterval Interval to check if certificate files are changed
+const CertCheckInterval = 1 * time.Second
+
+// CertReloader Structure
+type CertReloader struct {
+	CertPath     string
+	KeyPath      string
+	ClientCAPath string
+
+	Config     *tls.Config
+	ModTimes   map[string]time.Time
+	LastCheck  time.Time
+	ConfigLock *sync.RWMutex
+}
+
+// NewCertReloader Function
+func NewCertReloader(certPath, keyPath, clientCAPath string) (*CertReloader, error) {
+	if certPath == "" || keyPath == "" {
+		return nil, errors.New("both certificate and key files are required")
+	}
+
+	cr := &CertReloader{}
+
+	cr.CertPath = filepath.Clean(certPath)
+	cr.KeyPath = filepath.Clean(keyPath)
+	if clientCAPath != "" {
+		cr.ClientCAPath = filepath.Clean(clientCAPath)
+	}
+
+	cr.ModTimes = map[string]time.Time{}
+	cr.ConfigLock = new(sync.RWMutex)
+
+	if err := cr.reload(); err != nil {
+		return nil, err
+	}
+
+	return cr, nil
+}
+
+// getModTimes Function
+func (cr *CertReloader) getModTimes() map[string]time.Time {
+	modTimes := map[string]time.Time{}
+
+	for _, path := range []string{cr.CertPath, cr.KeyPath, cr.ClientCAPath} {
+		if path == "" {
+			continue
+		}
+
+		if info, err := os.Stat(path); err == nil {
+			modTimes[path] = info.ModTime()
+		}
+	}
+
+	return modTimes
+}
+
+// reload Function
+func (cr *CertReloader) reload() error {
+	modTimes := cr.getModTimes()
+
+	cert, err := tls.LoadX509KeyPair(cr.CertPath, cr.KeyPath)
+	if err != nil {
+		return fmt.Errorf("failed to load the key pair (%s)", err.Error())
+	}
+
+	// #nosec
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if cr.ClientCAPath != "" {
+		pem, err := ioutil.ReadFile(cr.ClientCAPath)
+		if err != nil {
+			return fmt.Errorf("failed to read the client CA (%s)", err.Error())
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("failed to parse the client CA (%s)", cr.ClientCAPath)
+		}
+
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	cr.ConfigLock.Lock()
+	defer cr.ConfigLock.Unlock()
+
+	cr.Config = config
+	cr.ModTimes = modTimes
+
+	return nil
+}
+
+// isChanged Function
+func (cr *CertReloader) isChanged() bool {
+	cr.ConfigLock.Lock()
+	defer cr.ConfigLock.Unlock()
+
+	if time.Since(cr.LastCheck) < CertCheckInterval {
+		return false
+	}
+	cr.LastCheck = time.Now()
+
+	modTimes := cr.getModTimes()
+	if len(modTimes) != len(cr.ModTimes) {
+		cr.ModTimes = modTimes
+		return true
+	}
+
+	for path, modTime := range modTimes {
+		if !cr.ModTimes[path].Equal(modTime) {
+			cr.ModTimes = modTimes
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetConfigForClient Function
+func (cr *CertReloader) GetConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	if cr.isChanged() {
+		if err := cr.reload(); err != nil {
+			kg.Warnf("Failed to reload TLS certificates, keeping the previous ones (%s)", err.Error())
+		} else {
+			kg.Print("Reloaded TLS certificates")
+		}
+	}
+
+	cr.ConfigLock.RLock()
+	defer cr.ConfigLock.RUnlock()
+
+	return cr.Config, nil
+}
+
+// NewTLSCredentials Function
+func NewTLSCredentials(certPath, keyPath, clientCAPath string) (credentials.TransportCredentials, error) {
+	cr, err := NewCertReloader(certPath, keyPath, clientCAPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// #nosec
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		GetConfigForClient: cr.GetConfigForClient,
+	}
+
+	return credentials.NewTLS(config), nil
+}
@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of KubeArmor
+
+package feeder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ====================== //
+// == Webhook Exporter == //
+// ====================== //
+
+// DefaultWebhookTimeout Default timeout of a webhook request
+const DefaultWebhookTimeout = 10 * time.Second
+
+// DefaultWebhookMaxRetries Default number of retries for a failed batch
+const DefaultWebhookMaxRetries = 3
+
+// DefaultWebhookInitialBackoff Default delay before the first retry
+const DefaultWebhookInitialBackoff = 1 * time.Second
+
+// DefaultWebhookMaxBackoff Default upper limit of the retry delay
+const DefaultWebhookMaxBackoff = 30 * time.Second
+
+// WebhookSpec Structure
+type WebhookSpec struct {
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+
+	Timeout        metav1.Duration `json:"timeout,omitempty"`
+	MaxRetries     *int            `json:"maxRetries,omitempty"`
+	InitialBackoff metav1.Duration `json:"initialBackoff,omitempty"`
+	MaxBackoff     metav1.Duration `json:"maxBackoff,omitempty"`
+}
+
+// WebhookExporter Structure
+type WebhookExporter struct {
+	URL     string
+	Headers map[string]string
+
+	Client *http.Client
+
+	MaxRetries     int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	StopChan chan struct{}
+	StopOnce sync.Once
+}
+
+func init() {
+	RegisterExporter("webhook", NewWebhookExporter)
+}
+
+// NewWebhookExporter Function
+func NewWebhookExporter(spec ExporterSpec) (Exporter, error) {
+	if spec.Webhook == nil || spec.Webhook.URL == "" {
+		return nil, errors.New("webhook url is required")
+	}
+
+	we := &WebhookExporter{}
+
+	we.URL = spec.Webhook.URL
+	we.Headers = spec.Webhook.Headers
+
+	timeout := spec.Webhook.Timeout.Duration
+	if timeout <= 0 {
+		timeout = DefaultWebhookTimeout
+	}
+	we.Client = &http.Client{Timeout: timeout}
+
+	we.MaxRetries = DefaultWebhookMaxRetries
+	if spec.Webhook.MaxRetries != nil {
+		if *spec.Webhook.MaxRetries < 0 {
+			return nil, fmt.Errorf("webhook max retries must not be negative (%d is invalid)", *spec.Webhook.MaxRetries)
+		}
+		we.MaxRetries = *spec.Webhook.MaxRetries
+	}
+
+	we.InitialBackoff = spec.Webhook.InitialBackoff.Duration
+	if we.InitialBackoff <= 0 {
+		we.InitialBackoff = DefaultWebhookInitialBackoff
+	}
+
+	we.MaxBackoff = spec.Webhook.MaxBackoff.Duration
+	if we.MaxBackoff <= 0 {
+		we.MaxBackoff = DefaultWebhookMaxBackoff
+	}
+
+	we.StopChan = make(chan struct{})
+
+	return we, nil
+}
+
+// post Function
+func (we *WebhookExporter) post(body []byte) (retryable bool, err error) {
+	req, err := http.NewRequest(http.MethodPost, we.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range we.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := we.Client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	// drain the body to reuse the connection
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	err = fmt.Errorf("webhook returned %s", resp.Status)
+
+	// client errors are not going to succeed on retry
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		return false, err
+	}
+
+	return true, err
+}
+
+// Export Function
+func (we *WebhookExporter) Export(logs []tp.Log) error {
+	body, err := json.Marshal(logs)
+	if err != nil {
+		return err
+	}
+
+	backoff := we.InitialBackoff
+
+	for attempt := 0; ; attempt++ {
+		retryable, err := we.post(body)
+		if err == nil {
+			return nil
+		}
+
+		if !retryable || attempt >= we.MaxRetries {
+			return err
+		}
+
+		select {
+		case <-we.StopChan:
+			return err
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > we.MaxBackoff {
+			backoff = we.MaxBackoff
+		}
+	}
+}
+
+// Stop Function (no more retries)
+func (we *WebhookExporter) Stop() {
+	we.StopOnce.Do(func() {
+		close(we.StopChan)
+	})
+}
+
+// Close Function
+func (we *WebhookExporter) Close() error {
+	we.Stop()
+	return nil
+}
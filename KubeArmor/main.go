@@ -4,17 +4,147 @@
 package main
 
 import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
 	cfg "github.com/kubearmor/KubeArmor/KubeArmor/config"
 	"github.com/kubearmor/KubeArmor/KubeArmor/core"
+	"github.com/kubearmor/KubeArmor/KubeArmor/feeder"
 	kg "github.com/kubearmor/KubeArmor/KubeArmor/log"
+	"github.com/kubearmor/KubeArmor/KubeArmor/policy"
+	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
 )
 
+// verifyLog Function (kubearmor verify-log -key <public key> <log files from the oldest>)
+func verifyLog(args []string) int {
+	flags := flag.NewFlagSet("verify-log", flag.ExitOnError)
+	keyPath := flags.String("key", "", "path to the ed25519 public key (PKIX PEM) verifying the checkpoints")
+	_ = flags.Parse(args)
+
+	if *keyPath == "" || flags.NArg() == 0 {
+		fmt.Fprintf(os.Stderr, "Usage: %s verify-log -key <public key> <log file>... (rotated files first, from the oldest)\n", os.Args[0])
+		return 2
+	}
+
+	publicKey, err := feeder.LoadVerificationKey(*keyPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load the verification key (%s)\n", err.Error())
+		return 2
+	}
+
+	report, err := feeder.VerifyLogFiles(flags.Args(), publicKey)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Verification failed after %d records: %s\n", report.Records, err.Error())
+		return 1
+	}
+
+	fmt.Printf("Verified %d records (sequence %d-%d) and %d checkpoints\n", report.Records, report.FirstSequence, report.LastSequence, report.Checkpoints)
+	for _, gap := range report.Gaps {
+		fmt.Printf("Warning: records after sequence %d up to %d were not covered by a signed checkpoint when the log was reopened (lines may have been removed or forged)\n", gap.Anchor, gap.Sequence)
+	}
+	if report.Unanchored > 0 {
+		fmt.Printf("Warning: the last %d records are not covered by a signed checkpoint yet\n", report.Unanchored)
+	}
+
+	return 0
+}
+
+// simulate Function (kubearmor simulate -policy <policy files> <log files>)
+func simulate(args []string) int {
+	flags := flag.NewFlagSet("simulate", flag.ExitOnError)
+	policyPaths := flags.String("policy", "", "comma-separated paths to KubeArmorPolicy YAML files")
+	filePosture := flags.String(cfg.ConfigDefaultFilePosture, "block", "default enforcement action in file context {allow|audit|block}")
+	networkPosture := flags.String(cfg.ConfigDefaultNetworkPosture, "block", "default enforcement action in network context {allow|audit|block}")
+	capabilitiesPosture := flags.String(cfg.ConfigDefaultCapabilitiesPosture, "block", "default enforcement action in capabilities context {allow|audit|block}")
+	output := flags.String("output", "text", "report format {text|json}")
+	details := flags.Bool("details", false, "list every event that would have been blocked or audited")
+	_ = flags.Parse(args)
+
+	if *policyPaths == "" || flags.NArg() == 0 {
+		fmt.Fprintf(os.Stderr, "Usage: %s simulate -policy <policy file>[,<policy file>...] <log file>...\n", os.Args[0])
+		return 2
+	}
+
+	secPolicies := []tp.SecurityPolicy{}
+
+	for _, path := range strings.Split(*policyPaths, ",") {
+		data, err := ioutil.ReadFile(filepath.Clean(path))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to read %s (%s)\n", path, err.Error())
+			return 2
+		}
+
+		policies, err := policy.ParseSecurityPolicies(data)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to parse %s (%s)\n", path, err.Error())
+			return 2
+		}
+		secPolicies = append(secPolicies, policies...)
+	}
+
+	defaultPosture := tp.DefaultPosture{
+		FileAction:         *filePosture,
+		NetworkAction:      *networkPosture,
+		CapabilitiesAction: *capabilitiesPosture,
+	}
+
+	simulator := feeder.NewPolicySimulator(secPolicies, defaultPosture)
+
+	report, err := simulator.SimulateLogFiles(flags.Args(), *details)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Simulation failed (%s)\n", err.Error())
+		return 1
+	}
+
+	if *output == "json" {
+		arr, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to encode the report (%s)\n", err.Error())
+			return 1
+		}
+		fmt.Println(string(arr))
+		return 0
+	}
+
+	fmt.Printf("Simulated %d events with %d policies (%d skipped)\n", report.Events, len(secPolicies), report.Skipped)
+	for _, decision := range []string{feeder.SimulationBlock, feeder.SimulationAudit, feeder.SimulationAllow, feeder.SimulationNone} {
+		fmt.Printf("  %-6s %d\n", decision, report.Decisions[decision])
+	}
+
+	if len(report.Rules) > 0 {
+		fmt.Println("\nMatches:")
+		for _, rule := range report.Rules {
+			fmt.Printf("  %-6s %6d  %s  %s\n", rule.Decision, rule.Count, rule.PolicyName, rule.Rule)
+		}
+	}
+
+	if len(report.Results) > 0 {
+		fmt.Println("\nEvents:")
+		for _, result := range report.Results {
+			fmt.Printf("  %-6s %s/%s %s %s (%s) by %s\n", result.Decision, result.Log.NamespaceName, result.Log.PodName,
+				result.Log.Operation, result.Log.Resource, result.Log.ProcessName, result.PolicyName)
+		}
+	}
+
+	return 0
+}
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "verify-log" {
+		os.Exit(verifyLog(os.Args[2:]))
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "simulate" {
+		os.Exit(simulate(os.Args[2:]))
+	}
+
 	if os.Geteuid() != 0 {
 		kg.Printf("Need to have root privileges to run %s\n", os.Args[0])
 		return
@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 Authors of KubeArmor
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	kg "github.com/kubearmor/KubeArmor/KubeArmor/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// ============= //
+// == Metrics == //
+// ============= //
+
+// Namespace Prefix of KubeArmor metrics
+const Namespace = "kubearmor"
+
+// Registry for KubeArmor metrics
+var Registry *prometheus.Registry
+
+// EventsTotal Counter (type, operation, result)
+var EventsTotal *prometheus.CounterVec
+
+// AlertsTotal Counter (policy name, action)
+var AlertsTotal *prometheus.CounterVec
+
+// LostSamplesTotal Counter
+var LostSamplesTotal prometheus.Counter
+
+// PolicyUpdatesTotal Counter (kind, action)
+var PolicyUpdatesTotal *prometheus.CounterVec
+
+// PolicyUpdateDuration Histogram (kind)
+var PolicyUpdateDuration *prometheus.HistogramVec
+
+func init() {
+	Registry = prometheus.NewRegistry()
+
+	EventsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "events_total",
+		Help:      "Number of events by type, operation and result",
+	}, []string{"type", "operation", "result"})
+
+	AlertsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "alerts_total",
+		Help:      "Number of alerts by policy name and action",
+	}, []string{"policy_name", "action"})
+
+	LostSamplesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "perf_lost_samples_total",
+		Help:      "Number of samples lost in the perf buffer of the system monitor",
+	})
+
+	PolicyUpdatesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "policy_updates_total",
+		Help:      "Number of policy updates by kind and action",
+	}, []string{"kind", "action"})
+
+	PolicyUpdateDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "policy_update_duration_seconds",
+		Help:      "Time taken to apply a policy update",
+		Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 14),
+	}, []string{"kind"})
+
+	Registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		EventsTotal,
+		AlertsTotal,
+		LostSamplesTotal,
+		PolicyUpdatesTotal,
+		PolicyUpdateDuration,
+	)
+}
+
+// ObservePolicyUpdate Function
+func ObservePolicyUpdate(kind, action string, start time.Time) {
+	PolicyUpdatesTotal.WithLabelValues(kind, action).Inc()
+	PolicyUpdateDuration.WithLabelValues(kind).Observe(time.Since(start).Seconds())
+}
+
+// NewGaugeFunc Function
+func NewGaugeFunc(name, help string, function func() float64) prometheus.GaugeFunc {
+	return prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Name:      name,
+		Help:      help,
+	}, function)
+}
+
+// ================= //
+// == HTTP Server == //
+// ================= //
+
+// MetricsServer Structure
+type MetricsServer struct {
+	Listener net.Listener
+	Server   *http.Server
+}
+
+// NewMetricsServer Function
+func NewMetricsServer(port string) (*MetricsServer, error) {
+	ms := &MetricsServer{}
+
+	listener, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		return nil, err
+	}
+	ms.Listener = listener
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+
+	ms.Server = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	return ms, nil
+}
+
+// Serve Function
+func (ms *MetricsServer) Serve() {
+	if err := ms.Server.Serve(ms.Listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		kg.Warnf("Terminated the metrics server (%s)", err.Error())
+	}
+}
+
+// Close Function
+func (ms *MetricsServer) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return ms.Server.Shutdown(ctx)
+}
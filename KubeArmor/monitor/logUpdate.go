@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	kl "github.com/kubearmor/KubeArmor/KubeArmor/common"
+	cfg "github.com/kubearmor/KubeArmor/KubeArmor/config"
 	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
 )
 
@@ -45,6 +46,9 @@ func (mon *SystemMonitor) UpdateContainerInfoByContainerID(log tp.Log) tp.Log {
 		log.FileVisibilityEnabled = val.FileVisibilityEnabled
 		log.NetworkVisibilityEnabled = val.NetworkVisibilityEnabled
 		log.CapabilitiesVisibilityEnabled = val.CapabilitiesVisibilityEnabled
+
+		// update rate limit
+		log.EventRateLimit = val.EventRateLimit
 	}
 
 	return log
@@ -89,6 +93,8 @@ func (mon *SystemMonitor) BuildLogBase(eventID int32, msg ContextCombined) tp.Lo
 	log.ParentProcessName = mon.GetExecPath(msg.ContainerID, msg.ContextSys.HostPPID)
 	log.ProcessName = mon.GetExecPath(msg.ContainerID, msg.ContextSys.HostPID)
 
+	log.Ancestors = mon.GetAncestors(msg.ContainerID, msg.ContextSys.HostPPID, cfg.GlobalCfg.ProcessAncestryDepth)
+
 	return log
 }
 
@@ -118,6 +124,39 @@ func (mon *SystemMonitor) UpdateLogBase(eventID int32, log tp.Log) tp.Log {
 	return log
 }
 
+// setSocketAddress Function
+func setSocketAddress(log tp.Log, sockAddr map[string]string, local bool) tp.Log {
+	if family, ok := sockAddr["sa_family"]; ok {
+		log.SocketFamily = family
+	}
+
+	port := int32(0)
+	if val, err := strconv.Atoi(sockAddr["sin_port"]); err == nil {
+		port = int32(val)
+	}
+
+	if local {
+		log.LocalIP = sockAddr["sin_addr"]
+		log.LocalPort = port
+	} else {
+		log.RemoteIP = sockAddr["sin_addr"]
+		log.RemotePort = port
+	}
+
+	return log
+}
+
+// setReturnValue Function
+func setReturnValue(log tp.Log, retval int64) tp.Log {
+	log.Retval = retval
+
+	if retval < 0 {
+		log.Errno = int32(-retval)
+	}
+
+	return log
+}
+
 // UpdateLogs Function
 func (mon *SystemMonitor) UpdateLogs() {
 	for {
@@ -153,6 +192,10 @@ func (mon *SystemMonitor) UpdateLogs() {
 				log.Resource = fileName
 				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " flags=" + fileOpenFlags
 
+				if fileOpenFlags != "" {
+					log.OpenFlags = strings.Split(fileOpenFlags, "|")
+				}
+
 			case SysOpenAt:
 				if len(msg.ContextArgs) != 3 {
 					continue
@@ -176,6 +219,10 @@ func (mon *SystemMonitor) UpdateLogs() {
 				log.Resource = fileName
 				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " fd=" + fd + " flags=" + fileOpenFlags
 
+				if fileOpenFlags != "" {
+					log.OpenFlags = strings.Split(fileOpenFlags, "|")
+				}
+
 			case SysUnlink:
 				if len(msg.ContextArgs) != 2 {
 					continue
@@ -247,6 +294,10 @@ func (mon *SystemMonitor) UpdateLogs() {
 				log.Resource = "domain=" + sockDomain + " type=" + sockType + " protocol=" + getProtocol(sockProtocol)
 				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID))
 
+				log.SocketFamily = sockDomain
+				log.SocketType = sockType
+				log.SocketProtocol = getProtocol(sockProtocol)
+
 			case TCPConnect, TCPConnectv6, TCPAccept, TCPAcceptv6:
 				if len(msg.ContextArgs) != 2 {
 					continue
@@ -270,6 +321,9 @@ func (mon *SystemMonitor) UpdateLogs() {
 				}
 				log.Data = log.Data + " domain=" + sockAddr["sa_family"]
 
+				log = setSocketAddress(log, sockAddr, false)
+				log.SocketProtocol = protocol
+
 			case SysConnect: // fd, sockaddr
 				if len(msg.ContextArgs) != 2 {
 					continue
@@ -298,6 +352,8 @@ func (mon *SystemMonitor) UpdateLogs() {
 
 				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " fd=" + fd
 
+				log = setSocketAddress(log, sockAddr, false)
+
 			case SysAccept: // fd, sockaddr
 				if len(msg.ContextArgs) != 2 {
 					continue
@@ -325,6 +381,8 @@ func (mon *SystemMonitor) UpdateLogs() {
 					}
 				}
 
+				log = setSocketAddress(log, sockAddr, false)
+
 			case SysBind: // fd, sockaddr
 				if len(msg.ContextArgs) != 2 {
 					continue
@@ -353,6 +411,8 @@ func (mon *SystemMonitor) UpdateLogs() {
 
 				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " fd=" + fd
 
+				log = setSocketAddress(log, sockAddr, true)
+
 			case SysListen: // fd
 				if len(msg.ContextArgs) != 2 {
 					continue
@@ -368,10 +428,145 @@ func (mon *SystemMonitor) UpdateLogs() {
 				log.Resource = ""
 				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " fd=" + fd
 
+			case SysPtrace: // request, pid
+				if len(msg.ContextArgs) != 2 {
+					continue
+				}
+
+				var request string
+				var pid string
+
+				if val, ok := msg.ContextArgs[0].(int32); ok {
+					request = getPtraceRequest(val)
+				}
+				if val, ok := msg.ContextArgs[1].(int32); ok {
+					pid = strconv.Itoa(int(val))
+				}
+
+				log.Operation = "Syscall"
+				log.Resource = "ptrace"
+				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " request=" + request + " pid=" + pid
+
+			case SysMount: // target, flags
+				if len(msg.ContextArgs) != 2 {
+					continue
+				}
+
+				var target string
+				var flags string
+
+				if val, ok := msg.ContextArgs[0].(string); ok {
+					target = val
+				}
+				if val, ok := msg.ContextArgs[1].(int32); ok {
+					flags = getMountFlags(uint32(val))
+				}
+
+				log.Operation = "Syscall"
+				log.Resource = "mount"
+				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " target=" + target + " flags=" + flags
+
+			case SysUnshare: // flags
+				if len(msg.ContextArgs) != 1 {
+					continue
+				}
+
+				var flags string
+
+				if val, ok := msg.ContextArgs[0].(int32); ok {
+					flags = getNamespaceFlags(uint32(val))
+				}
+
+				log.Operation = "Syscall"
+				log.Resource = "unshare"
+				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " flags=" + flags
+
+			case SysSetns: // fd, nstype
+				if len(msg.ContextArgs) != 2 {
+					continue
+				}
+
+				var fd string
+				var nstype string
+
+				if val, ok := msg.ContextArgs[0].(int32); ok {
+					fd = strconv.Itoa(int(val))
+				}
+				if val, ok := msg.ContextArgs[1].(int32); ok {
+					nstype = getNamespaceFlags(uint32(val))
+				}
+
+				log.Operation = "Syscall"
+				log.Resource = "setns"
+				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " fd=" + fd + " nstype=" + nstype
+
+			case SysInitModule, SysKexecLoad: // len or flags
+				if len(msg.ContextArgs) != 1 {
+					continue
+				}
+
+				var arg string
+
+				if val, ok := msg.ContextArgs[0].(int32); ok {
+					arg = strconv.Itoa(int(val))
+				}
+
+				log.Operation = "Syscall"
+
+				if msg.ContextSys.EventID == SysInitModule {
+					log.Resource = "init_module"
+					log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " len=" + arg
+				} else {
+					log.Resource = "kexec_load"
+					log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " flags=" + arg
+				}
+
+			case SysFinitModule, SysKexecFileLoad: // fd, flags
+				if len(msg.ContextArgs) != 2 {
+					continue
+				}
+
+				var fd string
+				var flags string
+
+				if val, ok := msg.ContextArgs[0].(int32); ok {
+					fd = strconv.Itoa(int(val))
+				}
+				if val, ok := msg.ContextArgs[1].(int32); ok {
+					flags = strconv.Itoa(int(val))
+				}
+
+				log.Operation = "Syscall"
+
+				if msg.ContextSys.EventID == SysFinitModule {
+					log.Resource = "finit_module"
+				} else {
+					log.Resource = "kexec_file_load"
+				}
+
+				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " fd=" + fd + " flags=" + flags
+
+			case SysBpf: // cmd
+				if len(msg.ContextArgs) != 1 {
+					continue
+				}
+
+				var cmd string
+
+				if val, ok := msg.ContextArgs[0].(int32); ok {
+					cmd = getBpfCommand(val)
+				}
+
+				log.Operation = "Syscall"
+				log.Resource = "bpf"
+				log.Data = "syscall=" + getSyscallName(int32(msg.ContextSys.EventID)) + " cmd=" + cmd
+
 			default:
 				continue
 			}
 
+			log = setReturnValue(log, msg.ContextSys.Retval)
+
 			// get error message
 			if msg.ContextSys.Retval < 0 {
 				message := getErrorMessage(msg.ContextSys.Retval)
@@ -386,7 +581,11 @@ func (mon *SystemMonitor) UpdateLogs() {
 
 			// push the generated log
 			if mon.Logger != nil {
-				go mon.Logger.PushLog(log)
+				if mon.Logger.Pipeline != nil {
+					mon.Logger.PushLog(log)
+				} else {
+					go mon.Logger.PushLog(log)
+				}
 			}
 		}
 	}
@@ -218,6 +218,50 @@ func (mon *SystemMonitor) GetCommand(containerID string, hostPid uint32) string
 	return ""
 }
 
+// GetAncestors Function (from the parent up to the depth)
+func (mon *SystemMonitor) GetAncestors(containerID string, hostPPid uint32, depth int) []tp.Ancestor {
+	if depth <= 0 {
+		return nil
+	}
+
+	ActiveHostPidMap := *(mon.ActiveHostPidMap)
+	ActivePidMapLock := *(mon.ActivePidMapLock)
+
+	ActivePidMapLock.RLock()
+	defer ActivePidMapLock.RUnlock()
+
+	pidMap, ok := ActiveHostPidMap[containerID]
+	if !ok {
+		return nil
+	}
+
+	ancestors := []tp.Ancestor{}
+	visited := map[uint32]bool{}
+
+	for hostPid := hostPPid; hostPid > 0 && len(ancestors) < depth && !visited[hostPid]; {
+		node, ok := pidMap[hostPid]
+		if !ok {
+			break
+		}
+		visited[hostPid] = true
+
+		ancestors = append(ancestors, tp.Ancestor{
+			HostPID:  int32(node.HostPID),
+			PID:      int32(node.PID),
+			ExecPath: node.ExecPath,
+			Args:     node.Args,
+		})
+
+		hostPid = node.HostPPID
+	}
+
+	if len(ancestors) == 0 {
+		return nil
+	}
+
+	return ancestors
+}
+
 // DeleteActivePid Function
 func (mon *SystemMonitor) DeleteActivePid(containerID string, ctx SyscallContext) {
 	now := time.Now()
@@ -489,6 +489,157 @@ func getCapabilityName(cap int32) string {
 	return res
 }
 
+var ptraceRequests = map[int32]string{
+	0:      "PTRACE_TRACEME",
+	1:      "PTRACE_PEEKTEXT",
+	2:      "PTRACE_PEEKDATA",
+	3:      "PTRACE_PEEKUSR",
+	4:      "PTRACE_POKETEXT",
+	5:      "PTRACE_POKEDATA",
+	6:      "PTRACE_POKEUSR",
+	7:      "PTRACE_CONT",
+	8:      "PTRACE_KILL",
+	9:      "PTRACE_SINGLESTEP",
+	12:     "PTRACE_GETREGS",
+	13:     "PTRACE_SETREGS",
+	16:     "PTRACE_ATTACH",
+	17:     "PTRACE_DETACH",
+	24:     "PTRACE_SYSCALL",
+	0x4200: "PTRACE_SETOPTIONS",
+	0x4206: "PTRACE_SEIZE",
+	0x4207: "PTRACE_INTERRUPT",
+}
+
+// getPtraceRequest Function
+func getPtraceRequest(req int32) string {
+	// include/uapi/linux/ptrace.h
+
+	if reqName, ok := ptraceRequests[req]; ok {
+		return reqName
+	}
+
+	return strconv.Itoa(int(req))
+}
+
+// getNamespaceFlags Function
+func getNamespaceFlags(flags uint32) string {
+	// the CLONE_NEW* flags of the `unshare` and `setns` syscalls
+	// include/uapi/linux/sched.h
+
+	var f []string
+
+	if flags&0x00000080 == 0x00000080 {
+		f = append(f, "CLONE_NEWTIME")
+	}
+	if flags&0x00020000 == 0x00020000 {
+		f = append(f, "CLONE_NEWNS")
+	}
+	if flags&0x02000000 == 0x02000000 {
+		f = append(f, "CLONE_NEWCGROUP")
+	}
+	if flags&0x04000000 == 0x04000000 {
+		f = append(f, "CLONE_NEWUTS")
+	}
+	if flags&0x08000000 == 0x08000000 {
+		f = append(f, "CLONE_NEWIPC")
+	}
+	if flags&0x10000000 == 0x10000000 {
+		f = append(f, "CLONE_NEWUSER")
+	}
+	if flags&0x20000000 == 0x20000000 {
+		f = append(f, "CLONE_NEWPID")
+	}
+	if flags&0x40000000 == 0x40000000 {
+		f = append(f, "CLONE_NEWNET")
+	}
+	if len(f) == 0 {
+		f = append(f, "0x"+strconv.FormatUint(uint64(flags), 16))
+	}
+
+	return strings.Join(f, "|")
+}
+
+// getMountFlags Function
+func getMountFlags(flags uint32) string {
+	// include/uapi/linux/mount.h
+
+	var f []string
+
+	if flags&1 == 1 {
+		f = append(f, "MS_RDONLY")
+	}
+	if flags&2 == 2 {
+		f = append(f, "MS_NOSUID")
+	}
+	if flags&4 == 4 {
+		f = append(f, "MS_NODEV")
+	}
+	if flags&8 == 8 {
+		f = append(f, "MS_NOEXEC")
+	}
+	if flags&32 == 32 {
+		f = append(f, "MS_REMOUNT")
+	}
+	if flags&4096 == 4096 {
+		f = append(f, "MS_BIND")
+	}
+	if flags&8192 == 8192 {
+		f = append(f, "MS_MOVE")
+	}
+	if flags&16384 == 16384 {
+		f = append(f, "MS_REC")
+	}
+	if flags&(1<<18) == 1<<18 {
+		f = append(f, "MS_PRIVATE")
+	}
+	if flags&(1<<19) == 1<<19 {
+		f = append(f, "MS_SLAVE")
+	}
+	if flags&(1<<20) == 1<<20 {
+		f = append(f, "MS_SHARED")
+	}
+	if len(f) == 0 {
+		f = append(f, "0")
+	}
+
+	return strings.Join(f, "|")
+}
+
+var bpfCommands = map[int32]string{
+	0:  "BPF_MAP_CREATE",
+	1:  "BPF_MAP_LOOKUP_ELEM",
+	2:  "BPF_MAP_UPDATE_ELEM",
+	3:  "BPF_MAP_DELETE_ELEM",
+	4:  "BPF_MAP_GET_NEXT_KEY",
+	5:  "BPF_PROG_LOAD",
+	6:  "BPF_OBJ_PIN",
+	7:  "BPF_OBJ_GET",
+	8:  "BPF_PROG_ATTACH",
+	9:  "BPF_PROG_DETACH",
+	10: "BPF_PROG_TEST_RUN",
+	11: "BPF_PROG_GET_NEXT_ID",
+	12: "BPF_MAP_GET_NEXT_ID",
+	13: "BPF_PROG_GET_FD_BY_ID",
+	14: "BPF_MAP_GET_FD_BY_ID",
+	15: "BPF_OBJ_GET_INFO_BY_FD",
+	16: "BPF_PROG_QUERY",
+	17: "BPF_RAW_TRACEPOINT_OPEN",
+	18: "BPF_BTF_LOAD",
+	28: "BPF_LINK_CREATE",
+	29: "BPF_LINK_UPDATE",
+}
+
+// getBpfCommand Function
+func getBpfCommand(cmd int32) string {
+	// include/uapi/linux/bpf.h
+
+	if cmdName, ok := bpfCommands[cmd]; ok {
+		return cmdName
+	}
+
+	return strconv.Itoa(int(cmd))
+}
+
 var syscalls = map[int32]string{
 	0:   "SYS_READ",
 	1:   "SYS_WRITE",
@@ -21,6 +21,7 @@ import (
 	kl "github.com/kubearmor/KubeArmor/KubeArmor/common"
 	cfg "github.com/kubearmor/KubeArmor/KubeArmor/config"
 	fd "github.com/kubearmor/KubeArmor/KubeArmor/feeder"
+	"github.com/kubearmor/KubeArmor/KubeArmor/metrics"
 	tp "github.com/kubearmor/KubeArmor/KubeArmor/types"
 )
 
@@ -45,6 +46,16 @@ const (
 	SysExecve   = 59
 	SysExecveAt = 322
 
+	SysPtrace        = 101
+	SysMount         = 165
+	SysInitModule    = 175
+	SysKexecLoad     = 246
+	SysUnshare       = 272
+	SysSetns         = 308
+	SysFinitModule   = 313
+	SysKexecFileLoad = 320
+	SysBpf           = 321
+
 	DoExit            = 351
 	SecurityBprmCheck = 352
 
@@ -230,6 +241,8 @@ func (mon *SystemMonitor) InitBPF() error {
 
 	// sysPrefix := bcc.GetSyscallPrefix()
 	systemCalls := []string{"open", "openat", "execve", "execveat", "socket", "connect", "accept", "bind", "listen", "unlink", "unlinkat"}
+	// system calls for syscall rules (not every kernel provides all of them)
+	optionalSystemCalls := []string{"ptrace", "mount", "unshare", "setns", "init_module", "finit_module", "kexec_load", "kexec_file_load", "bpf"}
 	// {category, event}
 	sysTracepoints := [][2]string{{"syscalls", "sys_exit_openat"}}
 	sysKprobes := []string{"do_exit", "security_bprm_check", "security_file_open", "security_path_unlink"}
@@ -253,6 +266,24 @@ func (mon *SystemMonitor) InitBPF() error {
 
 		}
 
+		for _, syscallName := range optionalSystemCalls {
+			kp, err := link.Kprobe("sys_"+syscallName, mon.BpfModule.Programs["kprobe__"+syscallName], nil)
+			if err != nil {
+				mon.Logger.Warnf("Failed to load kprobe %s (%s)", syscallName, err.Error())
+				continue
+			}
+
+			krp, err := link.Kretprobe("sys_"+syscallName, mon.BpfModule.Programs["kretprobe__"+syscallName], nil)
+			if err != nil {
+				mon.Logger.Warnf("Failed to load kretprobe %s (%s)", syscallName, err.Error())
+				_ = kp.Close()
+				continue
+			}
+
+			mon.Probes["kprobe__"+syscallName] = kp
+			mon.Probes["kretprobe__"+syscallName] = krp
+		}
+
 		for _, sysTracepoint := range sysTracepoints {
 			mon.Probes[sysTracepoint[1]], err = link.Tracepoint(sysTracepoint[0], sysTracepoint[1], mon.BpfModule.Programs[sysTracepoint[1]], nil)
 			if err != nil {
@@ -441,6 +472,7 @@ func (mon *SystemMonitor) TraceSyscall() {
 								log.Resource = log.Resource + " " + arg
 							}
 						}
+						log.ExecArgs = val
 					}
 
 					log.Operation = "Process"
@@ -469,6 +501,8 @@ func (mon *SystemMonitor) TraceSyscall() {
 					// update the log again
 					log = mon.UpdateLogBase(ctx.EventID, log)
 
+					log = setReturnValue(log, ctx.Retval)
+
 					// get error message
 					if ctx.Retval < 0 {
 						message := getErrorMessage(ctx.Retval)
@@ -483,7 +517,11 @@ func (mon *SystemMonitor) TraceSyscall() {
 
 					// push the generated log
 					if mon.Logger != nil {
-						go mon.Logger.PushLog(log)
+						if mon.Logger.Pipeline != nil {
+							mon.Logger.PushLog(log)
+						} else {
+							go mon.Logger.PushLog(log)
+						}
 					}
 				}
 
@@ -525,6 +563,7 @@ func (mon *SystemMonitor) TraceSyscall() {
 								log.Resource = log.Resource + " " + arg
 							}
 						}
+						log.ExecArgs = val
 					}
 					if val, ok := args[3].(string); ok {
 						procExecFlag = val
@@ -556,6 +595,8 @@ func (mon *SystemMonitor) TraceSyscall() {
 					// update the log again
 					log = mon.UpdateLogBase(ctx.EventID, log)
 
+					log = setReturnValue(log, ctx.Retval)
+
 					// get error message
 					if ctx.Retval < 0 {
 						message := getErrorMessage(ctx.Retval)
@@ -570,7 +611,11 @@ func (mon *SystemMonitor) TraceSyscall() {
 
 					// push the generated log
 					if mon.Logger != nil {
-						go mon.Logger.PushLog(log)
+						if mon.Logger.Pipeline != nil {
+							mon.Logger.PushLog(log)
+						} else {
+							go mon.Logger.PushLog(log)
+						}
 					}
 				}
 
@@ -610,8 +655,8 @@ func (mon *SystemMonitor) TraceSyscall() {
 			// push the context to the channel for logging
 			mon.ContextChan <- ContextCombined{ContainerID: containerID, ContextSys: ctx, ContextArgs: args}
 
-		case <-mon.SyscallLostChannel:
-			continue
+		case lost := <-mon.SyscallLostChannel:
+			metrics.LostSamplesTotal.Add(float64(lost))
 		}
 	}
 }
@@ -277,3 +277,63 @@ func TestTraceSyscallWithHost(t *testing.T) {
 	}
 	t.Log("[PASS] Destroyed logger")
 }
+
+func TestGetAncestors(t *testing.T) {
+	// pid map (1 -> 10 -> 20 -> 30)
+	ActiveHostPidMap := map[string]tp.PidMap{
+		"container": {
+			1:  {HostPID: 1, HostPPID: 0, PID: 1, ExecPath: "/sbin/init"},
+			10: {HostPID: 10, HostPPID: 1, PID: 2, ExecPath: "/usr/bin/bash"},
+			20: {HostPID: 20, HostPPID: 10, PID: 3, ExecPath: "/bin/sh", Args: "-c curl"},
+			30: {HostPID: 30, HostPPID: 20, PID: 4, ExecPath: "/usr/bin/curl"},
+		},
+	}
+	ActivePidMapLock := new(sync.RWMutex)
+
+	mon := &SystemMonitor{ActiveHostPidMap: &ActiveHostPidMap, ActivePidMapLock: &ActivePidMapLock}
+
+	ancestors := mon.GetAncestors("container", 20, 5)
+	if len(ancestors) != 3 || ancestors[0].ExecPath != "/bin/sh" || ancestors[0].Args != "-c curl" || ancestors[2].HostPID != 1 {
+		t.Errorf("[FAIL] Unexpected ancestors (%+v)", ancestors)
+		return
+	}
+	t.Log("[PASS] Walked the process tree")
+
+	if ancestors := mon.GetAncestors("container", 20, 2); len(ancestors) != 2 || ancestors[1].ExecPath != "/usr/bin/bash" {
+		t.Errorf("[FAIL] Ignored the depth cap (%+v)", ancestors)
+		return
+	}
+	t.Log("[PASS] Capped the ancestry depth")
+
+	if ancestors := mon.GetAncestors("container", 20, 0); ancestors != nil {
+		t.Errorf("[FAIL] Returned ancestors while disabled (%+v)", ancestors)
+		return
+	}
+	t.Log("[PASS] Disabled the ancestry")
+}
+
+func TestStructuredFields(t *testing.T) {
+	log := setSocketAddress(tp.Log{}, map[string]string{"sa_family": "AF_INET", "sin_addr": "10.0.0.1", "sin_port": "443"}, false)
+	if log.SocketFamily != "AF_INET" || log.RemoteIP != "10.0.0.1" || log.RemotePort != 443 || log.LocalIP != "" {
+		t.Errorf("[FAIL] Unexpected remote address (%+v)", log)
+		return
+	}
+
+	log = setSocketAddress(tp.Log{}, map[string]string{"sa_family": "AF_INET6", "sin_addr": "::1", "sin_port": "8080"}, true)
+	if log.LocalIP != "::1" || log.LocalPort != 8080 || log.RemoteIP != "" {
+		t.Errorf("[FAIL] Unexpected local address (%+v)", log)
+		return
+	}
+	t.Log("[PASS] Set socket addresses")
+
+	if log := setReturnValue(tp.Log{}, -13); log.Retval != -13 || log.Errno != 13 {
+		t.Errorf("[FAIL] Unexpected return value (%+v)", log)
+		return
+	}
+
+	if log := setReturnValue(tp.Log{}, 3); log.Retval != 3 || log.Errno != 0 {
+		t.Errorf("[FAIL] Unexpected return value (%+v)", log)
+		return
+	}
+	t.Log("[PASS] Set return values")
+}
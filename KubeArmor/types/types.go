@@ -4,6 +4,7 @@
 package types
 
 import (
+	"net"
 	"regexp"
 	"time"
 
@@ -42,6 +43,8 @@ type Container struct {
 	FileVisibilityEnabled         bool `json:"fileVisibilityEnabled"`
 	NetworkVisibilityEnabled      bool `json:"networkVisibilityEnabled"`
 	CapabilitiesVisibilityEnabled bool `json:"capabilitiesVisibilityEnabled"`
+
+	EventRateLimit int `json:"eventRateLimit"` // events/s (0: global default, -1: unlimited)
 }
 
 // EndPoint Structure
@@ -168,6 +171,15 @@ type K8sKubeArmorHostPolicies struct {
 // == Logging == //
 // ============= //
 
+// Ancestor Structure
+type Ancestor struct {
+	HostPID int32 `json:"hostPid"`
+	PID     int32 `json:"pid"`
+
+	ExecPath string `json:"execPath"`
+	Args     string `json:"args,omitempty"`
+}
+
 // Log Structure
 type Log struct {
 	// updated time
@@ -199,8 +211,9 @@ type Log struct {
 	UID      int32 `json:"uid"`
 
 	// process
-	ParentProcessName string `json:"parentProcessName"`
-	ProcessName       string `json:"processName"`
+	ParentProcessName string     `json:"parentProcessName"`
+	ProcessName       string     `json:"processName"`
+	Ancestors         []Ancestor `json:"ancestors,omitempty"`
 
 	// enforcer
 	Enforcer string `json:"enforcer,omitempty"`
@@ -222,6 +235,24 @@ type Log struct {
 	Action    string `json:"action,omitempty"`
 	Result    string `json:"result"`
 
+	// structured fields (also encoded in Resource and Data)
+	ExecArgs       []string `json:"execArgs,omitempty"`
+	OpenFlags      []string `json:"openFlags,omitempty"`
+	SocketFamily   string   `json:"socketFamily,omitempty"`
+	SocketType     string   `json:"socketType,omitempty"`
+	SocketProtocol string   `json:"socketProtocol,omitempty"`
+	LocalIP        string   `json:"localIP,omitempty"`
+	LocalPort      int32    `json:"localPort,omitempty"`
+	RemoteIP       string   `json:"remoteIP,omitempty"`
+	RemotePort     int32    `json:"remotePort,omitempty"`
+	Errno          int32    `json:"errno,omitempty"`
+	Retval         int64    `json:"retval,omitempty"`
+
+	// aggregation
+	Count     int32  `json:"count,omitempty"`
+	FirstSeen string `json:"firstSeen,omitempty"`
+	LastSeen  string `json:"lastSeen,omitempty"`
+
 	// == //
 
 	PolicyEnabled int `json:"policyEnabled,omitempty"`
@@ -230,6 +261,8 @@ type Log struct {
 	FileVisibilityEnabled         bool `json:"fileVisibilityEnabled,omitempty"`
 	NetworkVisibilityEnabled      bool `json:"networkVisibilityEnabled,omitempty"`
 	CapabilitiesVisibilityEnabled bool `json:"capabilitiesVisibilityEnabled,omitempty"`
+
+	EventRateLimit int `json:"eventRateLimit,omitempty"`
 }
 
 // MatchPolicy Structure
@@ -253,9 +286,20 @@ type MatchPolicy struct {
 	Regexp *regexp.Regexp
 	Native bool
 
+	// network endpoints and listeners
+	IPNet     *net.IPNet
+	Ports     []PortRange
+	Direction string
+
 	Action string
 }
 
+// PortRange Structure
+type PortRange struct {
+	Min uint16
+	Max uint16
+}
+
 // MatchPolicies Structure
 type MatchPolicies struct {
 	Policies []MatchPolicy
@@ -270,6 +314,7 @@ const (
 	KubeArmorPolicyDisabled = 0
 	KubeArmorPolicyEnabled  = 1
 	KubeArmorPolicyAudited  = 2
+	KubeArmorPolicyLearning = 3
 )
 
 // SelectorType Structure
@@ -393,9 +438,36 @@ type NetworkProtocolType struct {
 	Action   string   `json:"action,omitempty"`
 }
 
+// NetworkEndpointType Structure
+type NetworkEndpointType struct {
+	CIDR       string            `json:"cidr"`
+	Ports      []string          `json:"ports,omitempty"`     // e.g., 5432 or 8000-8080
+	Direction  string            `json:"direction,omitempty"` // connect or accept
+	FromSource []MatchSourceType `json:"fromSource,omitempty"`
+
+	Severity int      `json:"severity,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Message  string   `json:"message,omitempty"`
+	Action   string   `json:"action,omitempty"`
+}
+
+// NetworkListenerType Structure
+type NetworkListenerType struct {
+	Address    string            `json:"address,omitempty"` // local address, e.g., 127.0.0.1 (loopback) or 0.0.0.0 (all interfaces)
+	Ports      []string          `json:"ports,omitempty"`   // e.g., 8080 or 8000-8080
+	FromSource []MatchSourceType `json:"fromSource,omitempty"`
+
+	Severity int      `json:"severity,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Message  string   `json:"message,omitempty"`
+	Action   string   `json:"action,omitempty"`
+}
+
 // NetworkType Structure
 type NetworkType struct {
 	MatchProtocols []NetworkProtocolType `json:"matchProtocols,omitempty"`
+	MatchEndpoints []NetworkEndpointType `json:"matchEndpoints,omitempty"`
+	MatchListeners []NetworkListenerType `json:"matchListeners,omitempty"`
 
 	Severity int      `json:"severity,omitempty"`
 	Tags     []string `json:"tags,omitempty"`
@@ -424,6 +496,27 @@ type CapabilitiesType struct {
 	Action   string   `json:"action,omitempty"`
 }
 
+// SyscallMatchType Structure
+type SyscallMatchType struct {
+	Syscall    string            `json:"syscall"`
+	FromSource []MatchSourceType `json:"fromSource,omitempty"`
+
+	Severity int      `json:"severity,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Message  string   `json:"message,omitempty"`
+	Action   string   `json:"action,omitempty"`
+}
+
+// SyscallsType Structure
+type SyscallsType struct {
+	MatchSyscalls []SyscallMatchType `json:"matchSyscalls,omitempty"`
+
+	Severity int      `json:"severity,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Message  string   `json:"message,omitempty"`
+	Action   string   `json:"action,omitempty"`
+}
+
 // SecuritySpec Structure
 type SecuritySpec struct {
 	Selector SelectorType `json:"selector"`
@@ -432,6 +525,7 @@ type SecuritySpec struct {
 	File         FileType         `json:"file,omitempty"`
 	Network      NetworkType      `json:"network,omitempty"`
 	Capabilities CapabilitiesType `json:"capabilities,omitempty"`
+	Syscalls     SyscallsType     `json:"syscalls,omitempty"`
 
 	AppArmor string `json:"apparmor,omitempty"`
 
@@ -465,6 +559,7 @@ type HostSecuritySpec struct {
 	File         FileType         `json:"file,omitempty"`
 	Network      NetworkType      `json:"network,omitempty"`
 	Capabilities CapabilitiesType `json:"capabilities,omitempty"`
+	Syscalls     SyscallsType     `json:"syscalls,omitempty"`
 
 	AppArmor string `json:"apparmor,omitempty"`
 
@@ -190,8 +190,62 @@ type MatchNetworkProtocolType struct {
 	Action ActionType `json:"action,omitempty"`
 }
 
+// +kubebuilder:validation:Pattern=^[0-9a-fA-F:.\/, ]+$
+type MatchCIDRType string
+
+// +kubebuilder:validation:Pattern=^[0-9]{1,5}(-[0-9]{1,5})?$
+type MatchPortType string
+
+// +kubebuilder:validation:Enum=connect;accept
+type MatchDirectionType string
+
+type MatchNetworkEndpointType struct {
+	CIDR MatchCIDRType `json:"cidr"`
+
+	// +kubebuilder:validation:optional
+	Ports []MatchPortType `json:"ports,omitempty"`
+	// +kubebuilder:validation:optional
+	Direction MatchDirectionType `json:"direction,omitempty"`
+
+	// +kubebuilder:validation:optional
+	FromSource []MatchSourceType `json:"fromSource,omitempty"`
+
+	// +kubebuilder:validation:optional
+	Severity SeverityType `json:"severity,omitempty"`
+	// +kubebuilder:validation:optional
+	Tags []string `json:"tags,omitempty"`
+	// +kubebuilder:validation:optional
+	Message string `json:"message,omitempty"`
+	// +kubebuilder:validation:optional
+	Action ActionType `json:"action,omitempty"`
+}
+
+type MatchNetworkListenerType struct {
+	// +kubebuilder:validation:optional
+	Address MatchCIDRType `json:"address,omitempty"`
+	// +kubebuilder:validation:optional
+	Ports []MatchPortType `json:"ports,omitempty"`
+
+	// +kubebuilder:validation:optional
+	FromSource []MatchSourceType `json:"fromSource,omitempty"`
+
+	// +kubebuilder:validation:optional
+	Severity SeverityType `json:"severity,omitempty"`
+	// +kubebuilder:validation:optional
+	Tags []string `json:"tags,omitempty"`
+	// +kubebuilder:validation:optional
+	Message string `json:"message,omitempty"`
+	// +kubebuilder:validation:optional
+	Action ActionType `json:"action,omitempty"`
+}
+
 type NetworkType struct {
-	MatchProtocols []MatchNetworkProtocolType `json:"matchProtocols"`
+	// +kubebuilder:validation:optional
+	MatchProtocols []MatchNetworkProtocolType `json:"matchProtocols,omitempty"`
+	// +kubebuilder:validation:optional
+	MatchEndpoints []MatchNetworkEndpointType `json:"matchEndpoints,omitempty"`
+	// +kubebuilder:validation:optional
+	MatchListeners []MatchNetworkListenerType `json:"matchListeners,omitempty"`
 
 	// +kubebuilder:validation:optional
 	Severity SeverityType `json:"severity,omitempty"`
@@ -233,6 +287,38 @@ type CapabilitiesType struct {
 	Action ActionType `json:"action,omitempty"`
 }
 
+// +kubebuilder:validation:Pattern=(ptrace|mount|unshare|setns|init_module|finit_module|kexec_load|kexec_file_load|bpf)$
+type MatchSyscallStringType string
+
+type MatchSyscallType struct {
+	Syscall MatchSyscallStringType `json:"syscall"`
+
+	// +kubebuilder:validation:optional
+	FromSource []MatchSourceType `json:"fromSource,omitempty"`
+
+	// +kubebuilder:validation:optional
+	Severity SeverityType `json:"severity,omitempty"`
+	// +kubebuilder:validation:optional
+	Tags []string `json:"tags,omitempty"`
+	// +kubebuilder:validation:optional
+	Message string `json:"message,omitempty"`
+	// +kubebuilder:validation:optional
+	Action ActionType `json:"action,omitempty"`
+}
+
+type SyscallsType struct {
+	MatchSyscalls []MatchSyscallType `json:"matchSyscalls"`
+
+	// +kubebuilder:validation:optional
+	Severity SeverityType `json:"severity,omitempty"`
+	// +kubebuilder:validation:optional
+	Tags []string `json:"tags,omitempty"`
+	// +kubebuilder:validation:optional
+	Message string `json:"message,omitempty"`
+	// +kubebuilder:validation:optional
+	Action ActionType `json:"action,omitempty"`
+}
+
 // +kubebuilder:validation:Enum=Allow;Audit;Block
 type ActionType string
 
@@ -244,6 +330,7 @@ type KubeArmorHostPolicySpec struct {
 	File         FileType         `json:"file,omitempty"`
 	Network      NetworkType      `json:"network,omitempty"`
 	Capabilities CapabilitiesType `json:"capabilities,omitempty"`
+	Syscalls     SyscallsType     `json:"syscalls,omitempty"`
 
 	AppArmor string `json:"apparmor,omitempty"`
 
@@ -217,6 +217,7 @@ func (in *KubeArmorHostPolicySpec) DeepCopyInto(out *KubeArmorHostPolicySpec) {
 	in.File.DeepCopyInto(&out.File)
 	in.Network.DeepCopyInto(&out.Network)
 	in.Capabilities.DeepCopyInto(&out.Capabilities)
+	in.Syscalls.DeepCopyInto(&out.Syscalls)
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make([]string, len(*in))
@@ -274,6 +275,66 @@ func (in *MatchCapabilitiesType) DeepCopy() *MatchCapabilitiesType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchNetworkEndpointType) DeepCopyInto(out *MatchNetworkEndpointType) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]MatchPortType, len(*in))
+		copy(*out, *in)
+	}
+	if in.FromSource != nil {
+		in, out := &in.FromSource, &out.FromSource
+		*out = make([]MatchSourceType, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchNetworkEndpointType.
+func (in *MatchNetworkEndpointType) DeepCopy() *MatchNetworkEndpointType {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchNetworkEndpointType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchNetworkListenerType) DeepCopyInto(out *MatchNetworkListenerType) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]MatchPortType, len(*in))
+		copy(*out, *in)
+	}
+	if in.FromSource != nil {
+		in, out := &in.FromSource, &out.FromSource
+		*out = make([]MatchSourceType, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchNetworkListenerType.
+func (in *MatchNetworkListenerType) DeepCopy() *MatchNetworkListenerType {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchNetworkListenerType)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MatchNetworkProtocolType) DeepCopyInto(out *MatchNetworkProtocolType) {
 	*out = *in
@@ -314,6 +375,31 @@ func (in *MatchSourceType) DeepCopy() *MatchSourceType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchSyscallType) DeepCopyInto(out *MatchSyscallType) {
+	*out = *in
+	if in.FromSource != nil {
+		in, out := &in.FromSource, &out.FromSource
+		*out = make([]MatchSourceType, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchSyscallType.
+func (in *MatchSyscallType) DeepCopy() *MatchSyscallType {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchSyscallType)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkType) DeepCopyInto(out *NetworkType) {
 	*out = *in
@@ -324,6 +410,20 @@ func (in *NetworkType) DeepCopyInto(out *NetworkType) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MatchEndpoints != nil {
+		in, out := &in.MatchEndpoints, &out.MatchEndpoints
+		*out = make([]MatchNetworkEndpointType, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MatchListeners != nil {
+		in, out := &in.MatchListeners, &out.MatchListeners
+		*out = make([]MatchNetworkListenerType, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make([]string, len(*in))
@@ -473,3 +573,30 @@ func (in *ProcessType) DeepCopy() *ProcessType {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyscallsType) DeepCopyInto(out *SyscallsType) {
+	*out = *in
+	if in.MatchSyscalls != nil {
+		in, out := &in.MatchSyscalls, &out.MatchSyscalls
+		*out = make([]MatchSyscallType, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyscallsType.
+func (in *SyscallsType) DeepCopy() *SyscallsType {
+	if in == nil {
+		return nil
+	}
+	out := new(SyscallsType)
+	in.DeepCopyInto(out)
+	return out
+}
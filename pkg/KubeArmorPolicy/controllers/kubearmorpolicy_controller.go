@@ -6,6 +6,9 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"net"
+	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -62,6 +65,11 @@ func (r *KubeArmorPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		goto POLICYERROR
 	}
 
+	policyErr = validateSyscallsSchema(policy, req)
+	if policyErr != nil {
+		goto POLICYERROR
+	}
+
 POLICYERROR:
 	if policyErr != nil {
 		// Update PolicyStatus
@@ -146,12 +154,74 @@ func validateNetworkSchema(policy *securityv1.KubeArmorPolicy, req ctrl.Request)
 	var policyErr error
 	// for _, matchProtocols := range policy.Spec.Network.MatchProtocols {
 	// }
+	for _, matchEndpoints := range policy.Spec.Network.MatchEndpoints {
+		for _, cidr := range strings.Split(string(matchEndpoints.CIDR), ",") {
+			cidr = strings.TrimSpace(cidr)
+			if _, _, err := net.ParseCIDR(cidr); err != nil && net.ParseIP(cidr) == nil {
+				policyErr = fmt.Errorf("invalid cidr %s in %v", cidr, req.NamespacedName)
+				return policyErr
+			}
+		}
+		for _, port := range matchEndpoints.Ports {
+			if !isValidPortRange(string(port)) {
+				policyErr = fmt.Errorf("invalid port %s in %v", port, req.NamespacedName)
+				return policyErr
+			}
+		}
+	}
+	for _, matchListeners := range policy.Spec.Network.MatchListeners {
+		for _, address := range strings.Split(string(matchListeners.Address), ",") {
+			address = strings.TrimSpace(address)
+			if address == "" {
+				continue
+			}
+			if _, _, err := net.ParseCIDR(address); err != nil && net.ParseIP(address) == nil {
+				policyErr = fmt.Errorf("invalid address %s in %v", address, req.NamespacedName)
+				return policyErr
+			}
+		}
+		for _, port := range matchListeners.Ports {
+			if !isValidPortRange(string(port)) {
+				policyErr = fmt.Errorf("invalid port %s in %v", port, req.NamespacedName)
+				return policyErr
+			}
+		}
+	}
 	return policyErr
 }
 
+func isValidPortRange(port string) bool {
+	ports := strings.SplitN(port, "-", 2)
+	min, err := strconv.ParseUint(ports[0], 10, 16)
+	max := min
+	if err == nil && len(ports) == 2 {
+		max, err = strconv.ParseUint(ports[1], 10, 16)
+	}
+	return err == nil && min <= max
+}
+
 func validateCapabilitiesSchema(policy *securityv1.KubeArmorPolicy, req ctrl.Request) error {
 	var policyErr error
 	// for _, matchCapabilities := range policy.Spec.Capabilities.MatchCapabilities {
 	// }
 	return policyErr
 }
+
+func validateSyscallsSchema(policy *securityv1.KubeArmorPolicy, req ctrl.Request) error {
+	var policyErr error
+	for _, matchSyscalls := range policy.Spec.Syscalls.MatchSyscalls {
+		action := matchSyscalls.Action
+		if action == "" {
+			action = policy.Spec.Syscalls.Action
+		}
+		if action == "" {
+			action = policy.Spec.Action
+		}
+		// syscalls can only be blocked or audited
+		if action == "Allow" {
+			policyErr = fmt.Errorf("allow is not supported for syscall %s in %v", matchSyscalls.Syscall, req.NamespacedName)
+			return policyErr
+		}
+	}
+	return policyErr
+}
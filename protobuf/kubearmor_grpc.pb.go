@@ -26,6 +26,9 @@ type LogServiceClient interface {
 	WatchMessages(ctx context.Context, in *RequestMessage, opts ...grpc.CallOption) (LogService_WatchMessagesClient, error)
 	WatchAlerts(ctx context.Context, in *RequestMessage, opts ...grpc.CallOption) (LogService_WatchAlertsClient, error)
 	WatchLogs(ctx context.Context, in *RequestMessage, opts ...grpc.CallOption) (LogService_WatchLogsClient, error)
+	QueryAlerts(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (LogService_QueryAlertsClient, error)
+	QueryLogs(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (LogService_QueryLogsClient, error)
+	GetLearnedPolicy(ctx context.Context, in *LearnedPolicyRequest, opts ...grpc.CallOption) (*LearnedPolicyReply, error)
 }
 
 type logServiceClient struct {
@@ -141,6 +144,79 @@ func (x *logServiceWatchLogsClient) Recv() (*Log, error) {
 	return m, nil
 }
 
+func (c *logServiceClient) QueryAlerts(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (LogService_QueryAlertsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &LogService_ServiceDesc.Streams[3], "/feeder.LogService/QueryAlerts", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &logServiceQueryAlertsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type LogService_QueryAlertsClient interface {
+	Recv() (*Alert, error)
+	grpc.ClientStream
+}
+
+type logServiceQueryAlertsClient struct {
+	grpc.ClientStream
+}
+
+func (x *logServiceQueryAlertsClient) Recv() (*Alert, error) {
+	m := new(Alert)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *logServiceClient) QueryLogs(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (LogService_QueryLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &LogService_ServiceDesc.Streams[4], "/feeder.LogService/QueryLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &logServiceQueryLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type LogService_QueryLogsClient interface {
+	Recv() (*Log, error)
+	grpc.ClientStream
+}
+
+type logServiceQueryLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *logServiceQueryLogsClient) Recv() (*Log, error) {
+	m := new(Log)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *logServiceClient) GetLearnedPolicy(ctx context.Context, in *LearnedPolicyRequest, opts ...grpc.CallOption) (*LearnedPolicyReply, error) {
+	out := new(LearnedPolicyReply)
+	err := c.cc.Invoke(ctx, "/feeder.LogService/GetLearnedPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LogServiceServer is the server API for LogService service.
 // All implementations should embed UnimplementedLogServiceServer
 // for forward compatibility
@@ -149,6 +225,9 @@ type LogServiceServer interface {
 	WatchMessages(*RequestMessage, LogService_WatchMessagesServer) error
 	WatchAlerts(*RequestMessage, LogService_WatchAlertsServer) error
 	WatchLogs(*RequestMessage, LogService_WatchLogsServer) error
+	QueryAlerts(*QueryRequest, LogService_QueryAlertsServer) error
+	QueryLogs(*QueryRequest, LogService_QueryLogsServer) error
+	GetLearnedPolicy(context.Context, *LearnedPolicyRequest) (*LearnedPolicyReply, error)
 }
 
 // UnimplementedLogServiceServer should be embedded to have forward compatible implementations.
@@ -167,6 +246,15 @@ func (UnimplementedLogServiceServer) WatchAlerts(*RequestMessage, LogService_Wat
 func (UnimplementedLogServiceServer) WatchLogs(*RequestMessage, LogService_WatchLogsServer) error {
 	return status.Errorf(codes.Unimplemented, "method WatchLogs not implemented")
 }
+func (UnimplementedLogServiceServer) QueryAlerts(*QueryRequest, LogService_QueryAlertsServer) error {
+	return status.Errorf(codes.Unimplemented, "method QueryAlerts not implemented")
+}
+func (UnimplementedLogServiceServer) QueryLogs(*QueryRequest, LogService_QueryLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method QueryLogs not implemented")
+}
+func (UnimplementedLogServiceServer) GetLearnedPolicy(context.Context, *LearnedPolicyRequest) (*LearnedPolicyReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLearnedPolicy not implemented")
+}
 
 // UnsafeLogServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to LogServiceServer will
@@ -260,6 +348,66 @@ func (x *logServiceWatchLogsServer) Send(m *Log) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _LogService_QueryAlerts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(QueryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LogServiceServer).QueryAlerts(m, &logServiceQueryAlertsServer{stream})
+}
+
+type LogService_QueryAlertsServer interface {
+	Send(*Alert) error
+	grpc.ServerStream
+}
+
+type logServiceQueryAlertsServer struct {
+	grpc.ServerStream
+}
+
+func (x *logServiceQueryAlertsServer) Send(m *Alert) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _LogService_QueryLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(QueryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LogServiceServer).QueryLogs(m, &logServiceQueryLogsServer{stream})
+}
+
+type LogService_QueryLogsServer interface {
+	Send(*Log) error
+	grpc.ServerStream
+}
+
+type logServiceQueryLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *logServiceQueryLogsServer) Send(m *Log) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _LogService_GetLearnedPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LearnedPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogServiceServer).GetLearnedPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feeder.LogService/GetLearnedPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogServiceServer).GetLearnedPolicy(ctx, req.(*LearnedPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // LogService_ServiceDesc is the grpc.ServiceDesc for LogService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -271,6 +419,10 @@ var LogService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "HealthCheck",
 			Handler:    _LogService_HealthCheck_Handler,
 		},
+		{
+			MethodName: "GetLearnedPolicy",
+			Handler:    _LogService_GetLearnedPolicy_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -288,6 +440,16 @@ var LogService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _LogService_WatchLogs_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "QueryAlerts",
+			Handler:       _LogService_QueryAlerts_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "QueryLogs",
+			Handler:       _LogService_QueryLogs_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "kubearmor.proto",
 }